@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github-webhook/internal/bot/middleware"
+	"html"
 	"log"
 	"net/http"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github-webhook/internal/config"
 	"github-webhook/internal/db"
 	"github-webhook/internal/github"
+	"github-webhook/internal/i18n"
 	"github-webhook/internal/models"
 	"github-webhook/internal/utils"
 
@@ -32,9 +34,11 @@ func main() {
 
 	oauth := github.NewOAuth(cfg)
 	clientFactory := github.NewClientFactory()
-	oauthStateCache := cache.New[string, int64]()
+	oauthStateCache := cache.New[string, models.OAuthState]()
 	contextCache := cache.New[string, models.MessageContext]()
 	actionCache := cache.New[string, models.PRActionContext]()
+	runActions := cache.New[string, models.RunActionContext]()
+	alertActions := cache.New[string, models.AlertActionContext]()
 	adminCache := cache.New[int64, []int64]()
 	reloadRateLimit := cache.New[int64, time.Time]()
 
@@ -43,6 +47,17 @@ func main() {
 		log.Fatalf("Failed to create bot: %v", err)
 	}
 
+	// Register the command menu for every supported language; English is also the default for all others
+	for _, l := range i18n.Languages {
+		opts := &gotgbot.SetMyCommandsOpts{LanguageCode: l.Code}
+		if l.Code == i18n.DefaultLanguage {
+			opts.LanguageCode = ""
+		}
+		if _, err := b.SetMyCommands(i18n.Commands(l.Code), opts); err != nil {
+			log.Printf("Failed to set %s bot commands: %v", l.Code, err)
+		}
+	}
+
 	dispatcher := ext.NewDispatcher(&ext.DispatcherOpts{
 		Error: func(b *gotgbot.Bot, ctx *ext.Context, err error) ext.DispatcherAction {
 			log.Printf("Error processing update: %v", err)
@@ -53,25 +68,49 @@ func main() {
 	dispatcher.AddHandlerToGroup(handlers.NewMessage(nil, middleware.TrackUserAndChat(database)), -1)
 
 	// Commands
-	cmdHandler := commands.NewCommandHandler(cfg, database, oauth, oauthStateCache, clientFactory, cfg.EncryptionKey, contextCache, adminCache, reloadRateLimit)
+	cmdHandler := commands.NewCommandHandler(cfg, database, oauth, oauthStateCache, clientFactory, cfg.EncryptionKey, contextCache, runActions, alertActions, adminCache, reloadRateLimit)
 	dispatcher.AddHandler(handlers.NewCommand("start", cmdHandler.Start))
 	dispatcher.AddHandler(handlers.NewCommand("connect", cmdHandler.Connect))
 	dispatcher.AddHandler(handlers.NewCommand("add", cmdHandler.AddRepo))
 	dispatcher.AddHandler(handlers.NewCommand("addrepo", cmdHandler.AddRepo))
+	dispatcher.AddHandler(handlers.NewCommand("addorg", cmdHandler.AddOrg))
 	dispatcher.AddHandler(handlers.NewCommand("rm", cmdHandler.RemoveRepo))
 	dispatcher.AddHandler(handlers.NewCommand("removerepo", cmdHandler.RemoveRepo))
 	dispatcher.AddHandler(handlers.NewCommand("repos", cmdHandler.Repos))
 	dispatcher.AddHandler(handlers.NewCommand("config", cmdHandler.Settings))
 	dispatcher.AddHandler(handlers.NewCommand("settings", cmdHandler.Settings))
+	dispatcher.AddHandler(handlers.NewCommand("template", cmdHandler.Template))
 	dispatcher.AddHandler(handlers.NewCommand("help", cmdHandler.Help))
 	dispatcher.AddHandler(handlers.NewCommand("reload", cmdHandler.Reload))
 	dispatcher.AddHandler(handlers.NewCommand("privacy", cmdHandler.Privacy))
 	dispatcher.AddHandler(handlers.NewCommand("logout", cmdHandler.Logout))
+	dispatcher.AddHandler(handlers.NewCommand("mentions", cmdHandler.Mentions))
+	dispatcher.AddHandler(handlers.NewCommand("me", cmdHandler.Me))
 	dispatcher.AddHandler(handlers.NewCommand("close", cmdHandler.Close))
 	dispatcher.AddHandler(handlers.NewCommand("reopen", cmdHandler.Reopen))
 	dispatcher.AddHandler(handlers.NewCommand("approve", cmdHandler.Approve))
+	dispatcher.AddHandler(handlers.NewCommand("issue", cmdHandler.Issue))
+	dispatcher.AddHandler(handlers.NewCommand("label", cmdHandler.Label))
+	dispatcher.AddHandler(handlers.NewCommand("unlabel", cmdHandler.Unlabel))
+	dispatcher.AddHandler(handlers.NewCommand("assign", cmdHandler.Assign))
+	dispatcher.AddHandler(handlers.NewCommand("unassign", cmdHandler.Unassign))
+	dispatcher.AddHandler(handlers.NewCommand("milestone", cmdHandler.Milestone))
+	dispatcher.AddHandler(handlers.NewCommand("merge", cmdHandler.Merge))
+	dispatcher.AddHandler(handlers.NewCommand("review", cmdHandler.Review))
+	dispatcher.AddHandler(handlers.NewCommand("dismiss", cmdHandler.Dismiss))
+	dispatcher.AddHandler(handlers.NewCommand("rerun", cmdHandler.Rerun))
+	dispatcher.AddHandler(handlers.NewCommand("deploy", cmdHandler.Deploy))
+	dispatcher.AddHandler(handlers.NewCommand("dispatch", cmdHandler.Dispatch))
+	dispatcher.AddHandler(handlers.NewCommand("prs", cmdHandler.PRs))
+	dispatcher.AddHandler(handlers.NewCommand("issues", cmdHandler.Issues))
+	dispatcher.AddHandler(handlers.NewCommand("answer", cmdHandler.Answer))
+
+	// Answers to /dispatch prompts are replies too, so they must be matched before comment replies
+	dispatcher.AddHandler(handlers.NewMessage(cmdHandler.IsDispatchAnswer, cmdHandler.DispatchAnswer))
 
 	replyHandler := commands.NewReplyHandler(database, clientFactory, cfg.EncryptionKey, contextCache)
+	dispatcher.AddHandler(handlers.NewCommand("delete", replyHandler.Delete))
+	dispatcher.AddHandler(handlers.NewReaction(nil, replyHandler.HandleReaction))
 	dispatcher.AddHandler(handlers.NewMessage(func(msg *gotgbot.Message) bool {
 		if msg.GetText() == "" {
 			return false
@@ -83,17 +122,31 @@ func main() {
 		}
 
 		return msg.ReplyToMessage != nil
-	}, replyHandler.HandleReply))
+	}, replyHandler.HandleReply).SetAllowEdited(true))
 
 	cbHandler := callbacks.NewCallbackHandler(cfg, database, clientFactory, cfg.EncryptionKey, actionCache, adminCache)
 	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("c:"), cbHandler.HandleSettings))
 	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("act:"), cbHandler.HandlePRAction))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("me:"), cbHandler.HandleMe))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("ni:"), cmdHandler.IssueRepoCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("tr:"), cmdHandler.TriageCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("mg:"), cmdHandler.MergeCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("run:"), cmdHandler.RunCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("dep:"), cmdHandler.DeployCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("sa:"), cmdHandler.AlertCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("dp:"), cmdHandler.DispatchCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("ls:"), cmdHandler.ListCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("rp:"), cmdHandler.RepoPickerCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("rm:"), cmdHandler.RemovePickerCallback))
+	dispatcher.AddHandler(handlers.NewCallback(callbackquery.Prefix("cc:"), replyHandler.CommitPickCallback))
 
 	go func() {
 		err = updater.StartPolling(b, &ext.PollingOpts{
 			DropPendingUpdates: true,
 			GetUpdatesOpts: &gotgbot.GetUpdatesOpts{
 				Timeout: 9,
+				// Reactions are only delivered when asked for explicitly
+				AllowedUpdates: []string{"message", "edited_message", "callback_query", "message_reaction"},
 				RequestOpts: &gotgbot.RequestOpts{
 					Timeout: time.Second * 10,
 				},
@@ -120,7 +173,9 @@ func main() {
 		_, _ = writer.Write([]byte(html))
 	})
 
-	webhookHandler := github.NewWebhookServer(cfg, database, b, contextCache, actionCache).Handler
+	webhookServer := github.NewWebhookServer(cfg, database, clientFactory, b, contextCache, actionCache, runActions, alertActions)
+	webhookServer.RestoreDeferred()
+	webhookHandler := webhookServer.Handler
 	http.HandleFunc("/webhook/", webhookHandler)
 	http.HandleFunc("/oauth/callback", func(w http.ResponseWriter, r *http.Request) {
 		code := r.URL.Query().Get("code")
@@ -131,7 +186,7 @@ func main() {
 			return
 		}
 
-		telegramID, ok := oauthStateCache.Get(state)
+		pending, ok := oauthStateCache.Get(state)
 		if !ok {
 			http.Error(w, "Invalid or expired state", http.StatusBadRequest)
 			return
@@ -162,7 +217,7 @@ func main() {
 		}
 
 		user := &models.User{
-			ID:                  telegramID,
+			ID:                  pending.TelegramID,
 			GitHubUserID:        u.GetID(),
 			GitHubUsername:      u.GetLogin(),
 			EncryptedOAuthToken: encToken,
@@ -172,24 +227,26 @@ func main() {
 			return
 		}
 
-		_, _ = b.SendMessage(telegramID, fmt.Sprintf("✅ GitHub account <b>%s</b> connected successfully!", u.GetLogin()), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		lang := i18n.Resolve(pending.Language)
+		_, _ = b.SendMessage(pending.TelegramID, i18n.T(lang, "oauth.connected", html.EscapeString(u.GetLogin())), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
 
-		html := fmt.Sprintf(`
-		<html>
-		<head><title>Connected</title></head>
+		botURL := "https://t.me/" + b.User.Username
+		page := fmt.Sprintf(`
+		<html lang="%s">
+		<head><meta charset="utf-8"><title>%s</title></head>
 		<body style="font-family: sans-serif; text-align: center; padding: 50px;">
-			<h1>Authentication Successful</h1>
-			<p>Your GitHub account has been connected.</p>
+			<h1>%s</h1>
+			<p>%s</p>
 			<script>
 				window.opener = null;
 				setTimeout(function() { window.close(); }, 1000);
-				setTimeout(function() { window.location.href = "https://t.me/%s"; }, 2000);
+				setTimeout(function() { window.location.href = "%s"; }, 2000);
 			</script>
-			<p>If the window does not close automatically, you can <a href="https://t.me/%s">return to Telegram</a>.</p>
+			<p>%s</p>
 		</body>
-		</html>`, b.User.Username, b.User.Username)
+		</html>`, lang, i18n.T(lang, "oauth.page_title"), i18n.T(lang, "oauth.page_heading"), i18n.T(lang, "oauth.page_text"), botURL, i18n.T(lang, "oauth.page_return", botURL))
 		w.Header().Set("Content-Type", "text/html")
-		_, _ = w.Write([]byte(html))
+		_, _ = w.Write([]byte(page))
 	})
 
 	log.Printf("Server listening on port %s", cfg.Port)
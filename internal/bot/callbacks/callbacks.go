@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github-webhook/internal/cache"
 	"github-webhook/internal/config"
 	"github-webhook/internal/db"
 	"github-webhook/internal/github"
+	"github-webhook/internal/i18n"
 	"github-webhook/internal/models"
 	"github-webhook/internal/utils"
 
@@ -46,7 +48,7 @@ var eventToShort = map[string]string{}
 var shortToEvent = map[string]string{}
 
 func init() {
-	for _, e := range github.SupportedEvents {
+	for _, e := range github.LinkEvents(true) {
 		eventToShort[e.Name] = e.Short
 		shortToEvent[e.Short] = e.Name
 	}
@@ -54,7 +56,7 @@ func init() {
 
 func (h *CallbackHandler) HandleSettings(b *gotgbot.Bot, ctx *ext.Context) error {
 	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Only admins can change settings", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "admin.change_settings"), ShowAlert: true})
 		return nil
 	}
 
@@ -65,6 +67,8 @@ func (h *CallbackHandler) HandleSettings(b *gotgbot.Bot, ctx *ext.Context) error
 	// c:r -> conf:repo
 	// c:te -> conf:toggle_evt
 	// c:ep -> conf:evt_pg
+	// c:n -> conf:notifications
+	// c:lang -> conf:language
 
 	if len(parts) < 2 {
 		return nil
@@ -77,6 +81,12 @@ func (h *CallbackHandler) HandleSettings(b *gotgbot.Bot, ctx *ext.Context) error
 		if action == "ls" {
 			return h.showRepoList(b, ctx)
 		}
+		if action == "n" {
+			return h.handleNotificationSettings(b, ctx, parts[2:])
+		}
+		if action == "lang" {
+			return h.handleLanguage(b, ctx, parts[2:])
+		}
 		if action == "ar" {
 			if len(parts) < 3 {
 				return nil
@@ -99,7 +109,7 @@ func (h *CallbackHandler) HandleSettings(b *gotgbot.Bot, ctx *ext.Context) error
 		repoName := parts[2]
 		link, err := h.DB.GetRepoLink(context.Background(), ctx.EffectiveChat.Id, repoName)
 		if err != nil {
-			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Repo not found"})
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "repo.link_not_found")})
 			return nil
 		}
 
@@ -123,32 +133,26 @@ func (h *CallbackHandler) HandleSettings(b *gotgbot.Bot, ctx *ext.Context) error
 
 			user, uErr := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 			if uErr != nil || user.EncryptedOAuthToken == "" {
-				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Please /connect to GitHub first.", ShowAlert: true})
+				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.connect_first"), ShowAlert: true})
 				return nil
 			}
 			token, tErr := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 			if tErr != nil {
-				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Auth error.", ShowAlert: true})
+				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.auth"), ShowAlert: true})
 				return nil
 			}
 
 			client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 			if err != nil {
-				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to create GitHub client.", ShowAlert: true})
-				return nil
-			}
-			repoParts := strings.Split(link.RepoFullName, "/")
-			if len(repoParts) != 2 {
+				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.client"), ShowAlert: true})
 				return nil
 			}
-			owner, repoName := repoParts[0], repoParts[1]
-
-			hook, _, hErr := client.Repositories.GetHook(context.Background(), owner, repoName, link.WebhookID)
+			hook, hErr := github.GetLinkHook(context.Background(), client, *link)
 			if hErr != nil {
 				if h.handleAuthError(b, ctx, hErr) {
 					return nil
 				}
-				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to fetch GitHub settings.", ShowAlert: true})
+				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "settings.fetch_failed"), ShowAlert: true})
 				return nil
 			}
 
@@ -163,7 +167,7 @@ func (h *CallbackHandler) HandleSettings(b *gotgbot.Bot, ctx *ext.Context) error
 			}
 
 			if hasWildcard {
-				for _, se := range github.SupportedEvents {
+				for _, se := range github.LinkEvents(link.Org) {
 					currentEvents = append(currentEvents, se.Name)
 				}
 			}
@@ -182,12 +186,12 @@ func (h *CallbackHandler) HandleSettings(b *gotgbot.Bot, ctx *ext.Context) error
 			}
 
 			hook.Events = newEvents
-			_, _, editErr := client.Repositories.EditHook(context.Background(), owner, repoName, link.WebhookID, hook)
+			editErr := github.EditLinkHook(context.Background(), client, *link, hook)
 			if editErr != nil {
 				if h.handleAuthError(b, ctx, editErr) {
 					return nil
 				}
-				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to update GitHub.", ShowAlert: true})
+				_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "settings.update_failed"), ShowAlert: true})
 				return nil
 			}
 
@@ -218,22 +222,22 @@ func (h *CallbackHandler) showRepoMenu(b *gotgbot.Bot, ctx *ext.Context, l *mode
 	var kb [][]gotgbot.InlineKeyboardButton
 
 	kb = append(kb, []gotgbot.InlineKeyboardButton{
-		{Text: "Just the push event", CallbackData: fmt.Sprintf("c:presets:%s:push", l.RepoFullName)},
+		{Text: h.t(ctx, "settings.preset_push"), CallbackData: fmt.Sprintf("c:presets:%s:push", l.RepoFullName)},
 	})
 
 	kb = append(kb, []gotgbot.InlineKeyboardButton{
-		{Text: "Send me everything", CallbackData: fmt.Sprintf("c:presets:%s:all", l.RepoFullName)},
+		{Text: h.t(ctx, "settings.preset_all"), CallbackData: fmt.Sprintf("c:presets:%s:all", l.RepoFullName)},
 	})
 
 	kb = append(kb, []gotgbot.InlineKeyboardButton{
-		{Text: "Let me select individual events", CallbackData: fmt.Sprintf("c:iev:%s:1", l.RepoFullName)},
+		{Text: h.t(ctx, "settings.individual"), CallbackData: fmt.Sprintf("c:iev:%s:1", l.RepoFullName)},
 	})
 
 	kb = append(kb, []gotgbot.InlineKeyboardButton{
-		{Text: "🔙 Back to Repo List", CallbackData: "c:ls"},
+		{Text: h.t(ctx, "settings.back_list"), CallbackData: "c:ls"},
 	})
 
-	_, _, err := ctx.EffectiveMessage.EditText(b, fmt.Sprintf("Configuration for <b>%s</b>:", l.RepoFullName), &gotgbot.EditMessageTextOpts{
+	_, _, err := ctx.EffectiveMessage.EditText(b, h.t(ctx, "settings.config_for", l.RepoFullName), &gotgbot.EditMessageTextOpts{
 		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
 		ParseMode:   "HTML",
 	})
@@ -243,33 +247,27 @@ func (h *CallbackHandler) showRepoMenu(b *gotgbot.Bot, ctx *ext.Context, l *mode
 func (h *CallbackHandler) handlePresets(b *gotgbot.Bot, ctx *ext.Context, l *models.RepoLink, mode string) error {
 	user, uErr := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 	if uErr != nil || user.EncryptedOAuthToken == "" {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Please /connect to GitHub first.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.connect_first"), ShowAlert: true})
 		return nil
 	}
 
 	token, tErr := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	if tErr != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Auth error.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.auth"), ShowAlert: true})
 		return nil
 	}
 
 	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to create GitHub client.", ShowAlert: true})
-		return nil
-	}
-	repoParts := strings.Split(l.RepoFullName, "/")
-	if len(repoParts) != 2 {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.client"), ShowAlert: true})
 		return nil
 	}
-	owner, repoName := repoParts[0], repoParts[1]
-
-	hook, _, hErr := client.Repositories.GetHook(context.Background(), owner, repoName, l.WebhookID)
+	hook, hErr := github.GetLinkHook(context.Background(), client, *l)
 	if hErr != nil {
 		if h.handleAuthError(b, ctx, hErr) {
 			return nil
 		}
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to fetch GitHub hook.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "settings.hook_fetch"), ShowAlert: true})
 		return nil
 	}
 
@@ -283,22 +281,22 @@ func (h *CallbackHandler) handlePresets(b *gotgbot.Bot, ctx *ext.Context, l *mod
 	}
 
 	hook.Events = newEvents
-	_, _, editErr := client.Repositories.EditHook(context.Background(), owner, repoName, l.WebhookID, hook)
+	editErr := github.EditLinkHook(context.Background(), client, *l, hook)
 	if editErr != nil {
 		if h.handleAuthError(b, ctx, editErr) {
 			return nil
 		}
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to update GitHub hook.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "settings.hook_update"), ShowAlert: true})
 		return nil
 	}
 
-	responseText := "✅ <b>Success!</b> I've updated the repository settings to send <b>everything</b>."
+	responseText := h.t(ctx, "settings.all_done")
 	if mode == "push" {
-		responseText = "✅ <b>Success!</b> I've updated the repository settings to send <b>push events only</b>."
+		responseText = h.t(ctx, "settings.push_done")
 	}
 
 	kb := [][]gotgbot.InlineKeyboardButton{
-		{{Text: "🔙 Back", CallbackData: fmt.Sprintf("c:r:%s", l.RepoFullName)}},
+		{{Text: h.t(ctx, "settings.back"), CallbackData: fmt.Sprintf("c:r:%s", l.RepoFullName)}},
 	}
 
 	_, _, err = ctx.EffectiveMessage.EditText(b, responseText, &gotgbot.EditMessageTextOpts{
@@ -311,33 +309,27 @@ func (h *CallbackHandler) handlePresets(b *gotgbot.Bot, ctx *ext.Context, l *mod
 func (h *CallbackHandler) showIndividualEvents(b *gotgbot.Bot, ctx *ext.Context, l *models.RepoLink, page int) error {
 	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 	if err != nil || user.EncryptedOAuthToken == "" {
-		_, _, _ = ctx.EffectiveMessage.EditText(b, "Error: You must be connected to GitHub to view/edit settings.", nil)
+		_, _, _ = ctx.EffectiveMessage.EditText(b, h.t(ctx, "events.must_connect"), nil)
 		return nil
 	}
 
 	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	if err != nil {
-		_, _, _ = ctx.EffectiveMessage.EditText(b, "Auth error. Please reconnect.", nil)
+		_, _, _ = ctx.EffectiveMessage.EditText(b, h.t(ctx, "events.auth"), nil)
 		return nil
 	}
 
 	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 	if err != nil {
-		_, _, _ = ctx.EffectiveMessage.EditText(b, "Failed to create GitHub client.", nil)
-		return nil
-	}
-	parts := strings.Split(l.RepoFullName, "/")
-	if len(parts) != 2 {
+		_, _, _ = ctx.EffectiveMessage.EditText(b, h.t(ctx, "err.client"), nil)
 		return nil
 	}
-	owner, repoName := parts[0], parts[1]
-
-	hook, _, err := client.Repositories.GetHook(context.Background(), owner, repoName, l.WebhookID)
+	hook, err := github.GetLinkHook(context.Background(), client, *l)
 	if err != nil {
 		if h.handleAuthError(b, ctx, err) {
 			return nil
 		}
-		_, _, _ = ctx.EffectiveMessage.EditText(b, "Error fetching webhook settings from GitHub. Check permissions.", nil)
+		_, _, _ = ctx.EffectiveMessage.EditText(b, h.t(ctx, "events.fetch_failed"), nil)
 		return nil
 	}
 
@@ -345,7 +337,7 @@ func (h *CallbackHandler) showIndividualEvents(b *gotgbot.Bot, ctx *ext.Context,
 	if hook != nil {
 		for _, e := range hook.Events {
 			if e == "*" {
-				for _, supported := range github.SupportedEvents {
+				for _, supported := range github.LinkEvents(l.Org) {
 					enabledEvents[supported.Name] = true
 				}
 				break
@@ -357,7 +349,7 @@ func (h *CallbackHandler) showIndividualEvents(b *gotgbot.Bot, ctx *ext.Context,
 	var kb [][]gotgbot.InlineKeyboardButton
 	var row []gotgbot.InlineKeyboardButton
 
-	for _, e := range github.SupportedEvents {
+	for _, e := range github.LinkEvents(l.Org) {
 		status := "❌"
 		if enabledEvents[e.Name] {
 			status = "✅"
@@ -378,14 +370,13 @@ func (h *CallbackHandler) showIndividualEvents(b *gotgbot.Bot, ctx *ext.Context,
 		kb = append(kb, row)
 	}
 
-	webhookSettingsURL := fmt.Sprintf("https://github.com/%s/%s/settings/hooks/%d", owner, repoName, l.WebhookID)
 	kb = append(kb, []gotgbot.InlineKeyboardButton{
-		{Text: "🌐 Edit more on GitHub", Url: webhookSettingsURL},
+		{Text: h.t(ctx, "events.edit_on_github"), Url: github.LinkHookSettingsURL(*l)},
 	})
 
-	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: "🔙 Back", CallbackData: fmt.Sprintf("c:r:%s", l.RepoFullName)}})
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "settings.back"), CallbackData: fmt.Sprintf("c:r:%s", l.RepoFullName)}})
 
-	_, _, err = ctx.EffectiveMessage.EditText(b, fmt.Sprintf("Individual Events for <b>%s</b>:", l.RepoFullName), &gotgbot.EditMessageTextOpts{
+	_, _, err = ctx.EffectiveMessage.EditText(b, h.t(ctx, "events.title", l.RepoFullName), &gotgbot.EditMessageTextOpts{
 		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
 		ParseMode:   "HTML",
 	})
@@ -398,19 +389,23 @@ func (h *CallbackHandler) showRepoList(b *gotgbot.Bot, ctx *ext.Context) error {
 		return err
 	}
 
-	if len(links) == 0 {
-		_, _, err = ctx.EffectiveMessage.EditText(b, "No repositories linked. Use /addrepo first.", nil)
-		return err
-	}
-
 	var kb [][]gotgbot.InlineKeyboardButton
 	for _, l := range links {
 		kb = append(kb, []gotgbot.InlineKeyboardButton{
 			{Text: l.RepoFullName, CallbackData: fmt.Sprintf("c:r:%s", l.RepoFullName)},
 		})
 	}
+	kb = append(kb, []gotgbot.InlineKeyboardButton{
+		{Text: h.t(ctx, "settings.notifications"), CallbackData: "c:n"},
+	})
+	kb = append(kb, []gotgbot.InlineKeyboardButton{h.languageButton(ctx)})
 
-	_, _, err = ctx.EffectiveMessage.EditText(b, "Select a repository to configure:", &gotgbot.EditMessageTextOpts{
+	// Chat-wide settings stay reachable before any repository is linked
+	text := h.t(ctx, "settings.select_repo")
+	if len(links) == 0 {
+		text = h.t(ctx, "settings.none_linked")
+	}
+	_, _, err = ctx.EffectiveMessage.EditText(b, text, &gotgbot.EditMessageTextOpts{
 		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
 	})
 	return err
@@ -419,19 +414,19 @@ func (h *CallbackHandler) showRepoList(b *gotgbot.Bot, ctx *ext.Context) error {
 func (h *CallbackHandler) handleRepoPage(b *gotgbot.Bot, ctx *ext.Context, page int) error {
 	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 	if err != nil || user.EncryptedOAuthToken == "" {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Auth error. Please /connect again.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.auth_again"), ShowAlert: true})
 		return nil
 	}
 
 	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Auth error.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.auth"), ShowAlert: true})
 		return nil
 	}
 
 	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to create GitHub client.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.client"), ShowAlert: true})
 		return nil
 	}
 	opts := &gh.RepositoryListOptions{
@@ -445,7 +440,7 @@ func (h *CallbackHandler) handleRepoPage(b *gotgbot.Bot, ctx *ext.Context, page
 		if h.handleAuthError(b, ctx, err) {
 			return nil
 		}
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "GitHub API error.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.api"), ShowAlert: true})
 		return nil
 	}
 
@@ -456,40 +451,11 @@ func (h *CallbackHandler) handleRepoPage(b *gotgbot.Bot, ctx *ext.Context, page
 		})
 	}
 
-	var navRow []gotgbot.InlineKeyboardButton
-	if resp.FirstPage != 0 && resp.PrevPage != 0 {
-		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: "< Prev", CallbackData: fmt.Sprintf("c:ar:pg:%d", resp.PrevPage)})
-	}
-
-	startPage := page - 1
-	if startPage < 1 {
-		startPage = 1
-	}
-	endPage := page + 1
-	if resp.LastPage != 0 && endPage > resp.LastPage {
-		endPage = resp.LastPage
-	}
-	if resp.LastPage == 0 && resp.NextPage != 0 {
-		endPage = resp.NextPage
-	}
-
-	for i := startPage; i <= endPage; i++ {
-		text := fmt.Sprintf("%d", i)
-		if i == page {
-			text = fmt.Sprintf("· %d ·", i)
-		}
-		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: text, CallbackData: fmt.Sprintf("c:ar:pg:%d", i)})
-	}
-
-	if resp.NextPage != 0 {
-		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: "Next >", CallbackData: fmt.Sprintf("c:ar:pg:%d", resp.NextPage)})
-	}
-
-	if len(navRow) > 0 {
+	if navRow := PaginationRow(h.lang(ctx), resp, page, func(page int) string { return fmt.Sprintf("c:ar:pg:%d", page) }); len(navRow) > 0 {
 		kb = append(kb, navRow)
 	}
 
-	_, _, err = ctx.EffectiveMessage.EditText(b, fmt.Sprintf("Select a repository to add (Page %d):", page), &gotgbot.EditMessageTextOpts{
+	_, _, err = ctx.EffectiveMessage.EditText(b, h.t(ctx, "repo.select_add", page), &gotgbot.EditMessageTextOpts{
 		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
 	})
 
@@ -505,7 +471,7 @@ func (h *CallbackHandler) handleAddRepoByID(b *gotgbot.Bot, ctx *ext.Context, re
 	token, _ := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to create GitHub client.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.client"), ShowAlert: true})
 		return nil
 	}
 
@@ -514,13 +480,13 @@ func (h *CallbackHandler) handleAddRepoByID(b *gotgbot.Bot, ctx *ext.Context, re
 		if h.handleAuthError(b, ctx, err) {
 			return nil
 		}
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Repo not found or access denied.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "repo.access_denied"), ShowAlert: true})
 		return nil
 	}
 
 	chatToken, encErr := utils.Encrypt(fmt.Sprintf("%d", ctx.EffectiveChat.Id), h.EncryptionKey)
 	if encErr != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Error generating webhook token.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.webhook_token"), ShowAlert: true})
 		return nil
 	}
 
@@ -548,24 +514,27 @@ func (h *CallbackHandler) handleAddRepoByID(b *gotgbot.Bot, ctx *ext.Context, re
 		if h.handleAuthError(b, ctx, hookErr) {
 			return nil
 		}
-		msg := fmt.Sprintf("Webhook creation failed: %v. Check permissions", hookErr)
+		msg := h.t(ctx, "repo.webhook_failed_v", hookErr)
 		_, _, err = ctx.EffectiveMessage.EditText(b, msg, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
 		return err
 	}
 
 	webhookID := createdHook.GetID()
 	link := models.RepoLink{
-		RepoFullName: repo.GetFullName(),
-		WebhookID:    webhookID,
+		RepoFullName:  repo.GetFullName(),
+		WebhookID:     webhookID,
+		CreatedBy:     ctx.EffectiveUser.Id,
+		CreatedByName: ctx.EffectiveUser.FirstName,
+		CreatedAt:     time.Now(),
 	}
 
 	err = h.DB.AddRepoLink(context.Background(), ctx.EffectiveChat.Id, link)
 	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Error linking repository."})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.link_repo")})
 		return nil
 	}
 
-	msg := fmt.Sprintf("✅ Repository <b>%s</b> linked successfully!", repo.GetFullName())
+	msg := h.t(ctx, "repo.linked_check", repo.GetFullName())
 	_, _, err = ctx.EffectiveMessage.EditText(b, msg, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
 	return err
 }
@@ -583,7 +552,7 @@ func (h *CallbackHandler) HandlePRAction(b *gotgbot.Bot, ctx *ext.Context) error
 
 	prContext, ok := h.ActionCache.Get(actionID)
 	if !ok {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Action expired. Please open the PR link manually.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "pr.action_expired"), ShowAlert: true})
 		return nil
 	}
 
@@ -591,28 +560,30 @@ func (h *CallbackHandler) HandlePRAction(b *gotgbot.Bot, ctx *ext.Context) error
 	repo := prContext.Repo
 	prNum := prContext.PRNumber
 
-	repoFullName := fmt.Sprintf("%s/%s", owner, repo)
-	_, err := h.DB.GetRepoLink(context.Background(), ctx.EffectiveChat.Id, repoFullName)
-	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "This chat is not linked to the repo.", ShowAlert: true})
-		return nil
+	// Personal notifications arrive in private chats that don't link the repo; the user's own token decides there
+	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate {
+		repoFullName := fmt.Sprintf("%s/%s", owner, repo)
+		if _, err := h.DB.GetRepoLink(context.Background(), ctx.EffectiveChat.Id, repoFullName); err != nil {
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "pr.not_linked"), ShowAlert: true})
+			return nil
+		}
 	}
 
 	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 	if err != nil || user.EncryptedOAuthToken == "" {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Please connect GitHub account first via /connect", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.connect_via"), ShowAlert: true})
 		return nil
 	}
 
 	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Auth error. Reconnect via /connect", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.auth_reconnect"), ShowAlert: true})
 		return nil
 	}
 
 	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 	if err != nil {
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "Failed to create GitHub client.", ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.client"), ShowAlert: true})
 		return nil
 	}
 	ctxBg := context.Background()
@@ -622,17 +593,17 @@ func (h *CallbackHandler) HandlePRAction(b *gotgbot.Bot, ctx *ext.Context) error
 	switch action {
 	case "approve":
 		_, _, err = client.PullRequests.CreateReview(ctxBg, owner, repo, prNum, &gh.PullRequestReviewRequest{Event: gh.String("APPROVE")})
-		msg = "Approved!"
+		msg = h.t(ctx, "pr.approved")
 	case "close":
 		_, _, err = client.PullRequests.Edit(ctxBg, owner, repo, prNum, &gh.PullRequest{State: new("closed")})
-		msg = "Closed!"
+		msg = h.t(ctx, "pr.closed")
 	}
 
 	if err != nil {
 		if h.handleAuthError(b, ctx, err) {
 			return nil
 		}
-		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: fmt.Sprintf("Failed: %v", err), ShowAlert: true})
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.failed", err), ShowAlert: true})
 		return nil
 	}
 
@@ -644,9 +615,20 @@ func (h *CallbackHandler) handleAuthError(b *gotgbot.Bot, ctx *ext.Context, err
 	if errResp, ok := errors.AsType[*gh.ErrorResponse](err); ok {
 		if errResp.Response.StatusCode == http.StatusUnauthorized || errResp.Response.StatusCode == http.StatusForbidden {
 			_ = h.DB.ClearUserToken(context.Background(), ctx.EffectiveUser.Id)
-			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: "GitHub auth error. Token revoked or expired.", ShowAlert: true})
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.token_revoked"), ShowAlert: true})
 			return true
 		}
 	}
 	return false
 }
+
+// lang returns the language for replies in the current chat
+func (h *CallbackHandler) lang(ctx *ext.Context) string {
+	settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	return i18n.Pick(settings.Language, ctx.EffectiveUser.LanguageCode)
+}
+
+// t translates a message into the language of the current chat
+func (h *CallbackHandler) t(ctx *ext.Context, key string, args ...interface{}) string {
+	return i18n.T(h.lang(ctx), key, args...)
+}
@@ -0,0 +1,69 @@
+package callbacks
+
+import (
+	"context"
+
+	"github-webhook/internal/i18n"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// handleLanguage routes c:lang callbacks: c:lang shows the picker, c:lang:<code> saves the choice
+func (h *CallbackHandler) handleLanguage(b *gotgbot.Bot, ctx *ext.Context, args []string) error {
+	if len(args) == 0 {
+		return h.showLanguagePicker(b, ctx)
+	}
+
+	settings, err := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	if err != nil {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.load_settings"), ShowAlert: true})
+		return nil
+	}
+
+	settings.Language = ""
+	if args[0] != "auto" {
+		settings.Language = i18n.Resolve(args[0])
+	}
+
+	if err := h.DB.SetChatSettings(context.Background(), ctx.EffectiveChat.Id, settings); err != nil {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.save_settings"), ShowAlert: true})
+		return nil
+	}
+	return h.showRepoList(b, ctx)
+}
+
+func (h *CallbackHandler) showLanguagePicker(b *gotgbot.Bot, ctx *ext.Context) error {
+	settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	for _, l := range i18n.Languages {
+		text := l.Name
+		if l.Code == settings.Language {
+			text = "✅ " + text
+		}
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: text, CallbackData: "c:lang:" + l.Code}})
+	}
+
+	auto := h.t(ctx, "lang.auto")
+	if settings.Language == "" {
+		auto = "✅ " + auto
+	}
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: auto, CallbackData: "c:lang:auto"}})
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "settings.back_list"), CallbackData: "c:ls"}})
+
+	_, _, err := ctx.EffectiveMessage.EditText(b, h.t(ctx, "lang.pick"), &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+	})
+	return err
+}
+
+// languageButton returns the settings button showing the chat's current language
+func (h *CallbackHandler) languageButton(ctx *ext.Context) gotgbot.InlineKeyboardButton {
+	settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	name := h.t(ctx, "lang.auto_short")
+	if settings.Language != "" {
+		name = i18n.Name(settings.Language)
+	}
+	return gotgbot.InlineKeyboardButton{Text: h.t(ctx, "settings.language", name), CallbackData: "c:lang"}
+}
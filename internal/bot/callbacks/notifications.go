@@ -0,0 +1,277 @@
+package callbacks
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github-webhook/internal/github"
+	"github-webhook/internal/i18n"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// quietTimezones are offered in the timezone picker for quiet hours
+var quietTimezones = []string{
+	"UTC",
+	"Europe/London",
+	"Europe/Berlin",
+	"Europe/Moscow",
+	"Asia/Dubai",
+	"Asia/Kolkata",
+	"Asia/Singapore",
+	"Asia/Tokyo",
+	"Australia/Sydney",
+	"America/Sao_Paulo",
+	"America/New_York",
+	"America/Chicago",
+	"America/Denver",
+	"America/Los_Angeles",
+}
+
+// pushWindows are the coalescing windows offered for pushes, in seconds
+var pushWindows = []int{0, 30, 60, 120, 300}
+
+// soundEvents lists the event kinds that can be forced silent or loud
+func soundEvents() []github.Event {
+	return append(append([]github.Event{}, github.SupportedEvents...), github.CIFailureEvent)
+}
+
+// handleNotificationSettings routes c:n:* callbacks
+func (h *CallbackHandler) handleNotificationSettings(b *gotgbot.Bot, ctx *ext.Context, args []string) error {
+	chatID := ctx.EffectiveChat.Id
+	settings, err := h.DB.GetChatSettings(context.Background(), chatID)
+	if err != nil {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.load_settings"), ShowAlert: true})
+		return nil
+	}
+
+	if len(args) == 0 {
+		return h.showNotificationMenu(b, ctx, settings)
+	}
+
+	q := &settings.QuietHours
+	switch args[0] {
+	case "q":
+		// c:n:q
+		q.Enabled = !q.Enabled
+		if q.Start == q.End {
+			q.Start, q.End = 22, 7
+		}
+	case "d":
+		// c:n:d
+		q.Defer = !q.Defer
+	case "s", "e":
+		// c:n:s:1, c:n:e:-1
+		if len(args) < 2 {
+			return nil
+		}
+		delta, _ := strconv.Atoi(args[1])
+		if args[0] == "s" {
+			q.Start = (q.Start + delta + 24) % 24
+		} else {
+			q.End = (q.End + delta + 24) % 24
+		}
+	case "ci":
+		// c:n:ci
+		settings.CIAggregate = !settings.CIAggregate
+	case "cf":
+		// c:n:cf
+		settings.CIFinalOnly = !settings.CIFinalOnly
+	case "rc":
+		// c:n:rc
+		settings.NoReplyConfirm = !settings.NoReplyConfirm
+	case "pw":
+		// c:n:pw
+		next := pushWindows[0]
+		for i, w := range pushWindows {
+			if w == settings.PushWindow && i+1 < len(pushWindows) {
+				next = pushWindows[i+1]
+				break
+			}
+		}
+		settings.PushWindow = next
+	case "tz":
+		// c:n:tz, c:n:tz:idx
+		if len(args) < 2 {
+			return h.showTimezonePicker(b, ctx)
+		}
+		idx, _ := strconv.Atoi(args[1])
+		if idx < 0 || idx >= len(quietTimezones) {
+			return nil
+		}
+		q.Timezone = quietTimezones[idx]
+	case "ev":
+		// c:n:ev
+		return h.showEventSounds(b, ctx, settings)
+	case "es":
+		// c:n:es:shortEvt
+		if len(args) < 2 {
+			return nil
+		}
+		var name string
+		for _, e := range soundEvents() {
+			if e.Short == args[1] {
+				name = e.Name
+				break
+			}
+		}
+		if name == "" {
+			return nil
+		}
+		if settings.EventSound == nil {
+			settings.EventSound = map[string]string{}
+		}
+		switch settings.EventSound[name] {
+		case models.SoundDefault:
+			settings.EventSound[name] = models.SoundSilent
+		case models.SoundSilent:
+			settings.EventSound[name] = models.SoundLoud
+		default:
+			delete(settings.EventSound, name)
+		}
+		if err := h.DB.SetChatSettings(context.Background(), chatID, settings); err != nil {
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.save_settings"), ShowAlert: true})
+			return nil
+		}
+		return h.showEventSounds(b, ctx, settings)
+	default:
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		return nil
+	}
+
+	if err := h.DB.SetChatSettings(context.Background(), chatID, settings); err != nil {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.save_settings"), ShowAlert: true})
+		return nil
+	}
+	return h.showNotificationMenu(b, ctx, settings)
+}
+
+func (h *CallbackHandler) showNotificationMenu(b *gotgbot.Bot, ctx *ext.Context, settings models.ChatSettings) error {
+	_, _ = ctx.CallbackQuery.Answer(b, nil)
+
+	q := settings.QuietHours
+	tz := q.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+
+	lang := h.lang(ctx)
+	status := onOff(lang, q.Enabled)
+	mode := i18n.T(lang, "notify.mode_silent")
+	if q.Defer {
+		mode = i18n.T(lang, "notify.mode_hold")
+	}
+
+	text := i18n.T(lang, "notify.menu", status, q.Start, q.End, tz, mode)
+
+	kb := [][]gotgbot.InlineKeyboardButton{
+		{{Text: i18n.T(lang, "notify.quiet", status), CallbackData: "c:n:q"}},
+		{
+			{Text: "−", CallbackData: "c:n:s:-1"},
+			{Text: i18n.T(lang, "notify.start", q.Start), CallbackData: "c:n"},
+			{Text: "+", CallbackData: "c:n:s:1"},
+		},
+		{
+			{Text: "−", CallbackData: "c:n:e:-1"},
+			{Text: i18n.T(lang, "notify.end", q.End), CallbackData: "c:n"},
+			{Text: "+", CallbackData: "c:n:e:1"},
+		},
+		{{Text: i18n.T(lang, "notify.timezone", tz), CallbackData: "c:n:tz"}},
+		{{Text: mode, CallbackData: "c:n:d"}},
+		{{Text: i18n.T(lang, "notify.sounds"), CallbackData: "c:n:ev"}},
+		{{Text: i18n.T(lang, "notify.ci", onOff(lang, settings.CIAggregate)), CallbackData: "c:n:ci"}},
+		{{Text: i18n.T(lang, "notify.ci_final", onOff(lang, settings.CIFinalOnly)), CallbackData: "c:n:cf"}},
+		{{Text: i18n.T(lang, "notify.push_window", FormatWindow(lang, settings.PushWindow)), CallbackData: "c:n:pw"}},
+		{{Text: i18n.T(lang, "notify.reply_confirm", onOff(lang, !settings.NoReplyConfirm)), CallbackData: "c:n:rc"}},
+		{{Text: i18n.T(lang, "settings.back_list"), CallbackData: "c:ls"}},
+	}
+
+	_, _, err := ctx.EffectiveMessage.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+		ParseMode:   "HTML",
+	})
+	return err
+}
+
+func (h *CallbackHandler) showTimezonePicker(b *gotgbot.Bot, ctx *ext.Context) error {
+	_, _ = ctx.CallbackQuery.Answer(b, nil)
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	var row []gotgbot.InlineKeyboardButton
+
+	for i, tz := range quietTimezones {
+		row = append(row, gotgbot.InlineKeyboardButton{Text: tz, CallbackData: fmt.Sprintf("c:n:tz:%d", i)})
+		if len(row) == 2 {
+			kb = append(kb, row)
+			row = []gotgbot.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		kb = append(kb, row)
+	}
+
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "settings.back"), CallbackData: "c:n"}})
+
+	_, _, err := ctx.EffectiveMessage.EditText(b, h.t(ctx, "notify.tz_pick"), &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+	})
+	return err
+}
+
+func (h *CallbackHandler) showEventSounds(b *gotgbot.Bot, ctx *ext.Context, settings models.ChatSettings) error {
+	_, _ = ctx.CallbackQuery.Answer(b, nil)
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	var row []gotgbot.InlineKeyboardButton
+
+	for _, e := range soundEvents() {
+		status := "▫️"
+		switch settings.EventSound[e.Name] {
+		case models.SoundSilent:
+			status = "🔕"
+		case models.SoundLoud:
+			status = "🔊"
+		}
+
+		// c:n:es:shortEvt
+		row = append(row, gotgbot.InlineKeyboardButton{Text: fmt.Sprintf("%s %s", status, e.Label), CallbackData: fmt.Sprintf("c:n:es:%s", e.Short)})
+		if len(row) == 2 {
+			kb = append(kb, row)
+			row = []gotgbot.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		kb = append(kb, row)
+	}
+
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "settings.back"), CallbackData: "c:n"}})
+
+	text := h.t(ctx, "notify.sounds_text")
+
+	_, _, err := ctx.EffectiveMessage.EditText(b, text, &gotgbot.EditMessageTextOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+		ParseMode:   "HTML",
+	})
+	return err
+}
+
+func onOff(lang string, v bool) string {
+	if v {
+		return i18n.T(lang, "notify.on")
+	}
+	return i18n.T(lang, "notify.off")
+}
+
+// FormatWindow describes a merge window in seconds, "off" when it is disabled
+func FormatWindow(lang string, seconds int) string {
+	switch {
+	case seconds == 0:
+		return i18n.T(lang, "notify.off")
+	case seconds%60 == 0:
+		return fmt.Sprintf("%dm", seconds/60)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
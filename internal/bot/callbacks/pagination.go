@@ -0,0 +1,44 @@
+package callbacks
+
+import (
+	"fmt"
+	"strconv"
+
+	"github-webhook/internal/i18n"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	gh "github.com/google/go-github/v89/github"
+)
+
+// PaginationRow renders previous/next buttons around the neighbouring page numbers of a GitHub listing.
+// data returns the callback data that opens a page.
+func PaginationRow(lang string, resp *gh.Response, page int, data func(page int) string) []gotgbot.InlineKeyboardButton {
+	var navRow []gotgbot.InlineKeyboardButton
+	if resp.FirstPage != 0 && resp.PrevPage != 0 {
+		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: i18n.T(lang, "nav.prev"), CallbackData: data(resp.PrevPage)})
+	}
+
+	startPage := max(page-1, 1)
+	endPage := page + 1
+	if resp.LastPage != 0 && endPage > resp.LastPage {
+		endPage = resp.LastPage
+	}
+	if resp.LastPage == 0 && resp.NextPage == 0 {
+		endPage = page
+	}
+
+	if endPage > startPage {
+		for i := startPage; i <= endPage; i++ {
+			text := strconv.Itoa(i)
+			if i == page {
+				text = fmt.Sprintf("· %d ·", i)
+			}
+			navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: text, CallbackData: data(i)})
+		}
+	}
+
+	if resp.NextPage != 0 {
+		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: i18n.T(lang, "nav.next"), CallbackData: data(resp.NextPage)})
+	}
+	return navRow
+}
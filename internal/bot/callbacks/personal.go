@@ -0,0 +1,91 @@
+package callbacks
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/i18n"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// personalReasons are the personal notification kinds shown in /me, in menu order
+var personalReasons = []string{gh.MentionReview, gh.MentionAssign, gh.MentionMention}
+
+// PersonalMenu renders the /me preferences menu for a user
+func PersonalMenu(lang string, user *models.User) (string, gotgbot.InlineKeyboardMarkup) {
+	onOff := func(v bool) string {
+		if v {
+			return i18n.T(lang, "notify.on")
+		}
+		return i18n.T(lang, "notify.off")
+	}
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	for _, r := range personalReasons {
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{
+			Text:         i18n.T(lang, "me."+r, onOff(!slices.Contains(user.DMOff, r))),
+			CallbackData: "me:t:" + r,
+		}})
+	}
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{
+		Text:         i18n.T(lang, "me.group_mentions", onOff(!user.NoMentions)),
+		CallbackData: "me:t:tg",
+	}})
+
+	return i18n.T(lang, "me.menu", user.GitHubUsername), gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb}
+}
+
+// HandleMe handles the /me preference buttons and the mute button on personal notifications:
+// me:t:<reason|tg> toggles a preference, me:off:<reason> turns a notification kind off
+func (h *CallbackHandler) HandleMe(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+	action, reason := parts[1], parts[2]
+
+	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
+	if err != nil || user.GitHubUsername == "" {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.connect_first"), ShowAlert: true})
+		return nil
+	}
+
+	if reason == "tg" {
+		user.NoMentions = !user.NoMentions
+		err = h.DB.SetUserMentions(context.Background(), user.ID, !user.NoMentions)
+	} else {
+		if !slices.Contains(personalReasons, reason) {
+			return nil
+		}
+		off := slices.Contains(user.DMOff, reason)
+		switch {
+		case action == "off" && off:
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "me.muted")})
+			return nil
+		case off:
+			user.DMOff = slices.DeleteFunc(user.DMOff, func(r string) bool { return r == reason })
+		default:
+			user.DMOff = append(user.DMOff, reason)
+		}
+		err = h.DB.SetUserDMOff(context.Background(), user.ID, user.DMOff)
+	}
+	if err != nil {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.save_settings"), ShowAlert: true})
+		return nil
+	}
+
+	if action == "off" {
+		// The mute button sits on a notification, which is left as it is
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "me.muted"), ShowAlert: true})
+		return nil
+	}
+
+	msg, markup := PersonalMenu(h.lang(ctx), user)
+	_, _, err = ctx.EffectiveMessage.EditText(b, msg, &gotgbot.EditMessageTextOpts{ParseMode: "HTML", ReplyMarkup: markup})
+	return err
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"strings"
 	"time"
 
+	"github-webhook/internal/bot/callbacks"
 	"github-webhook/internal/cache"
 	"github-webhook/internal/config"
 	"github-webhook/internal/db"
 	gh "github-webhook/internal/github"
+	"github-webhook/internal/i18n"
 	"github-webhook/internal/models"
 	"github-webhook/internal/utils"
 
@@ -27,15 +30,27 @@ type CommandHandler struct {
 	Config          *config.Config
 	DB              *db.DB
 	OAuth           *gh.OAuth
-	StateCache      *cache.Cache[string, int64]
+	StateCache      *cache.Cache[string, models.OAuthState]
 	ClientFactory   *gh.ClientFactory
 	EncryptionKey   string
 	AdminCache      *cache.Cache[int64, []int64]
 	ReloadRateLimit *cache.Cache[int64, time.Time]
 	ContextCache    *cache.Cache[string, models.MessageContext]
+	RunActions      *cache.Cache[string, models.RunActionContext]   // Key: short ID in the run and deployment buttons' callback data
+	AlertActions    *cache.Cache[string, models.AlertActionContext] // Key: short ID in the alert triage buttons' callback data
+
+	issueDrafts   *cache.Cache[string, issueDraft]   // Key: short ID in the repo picker's callback data
+	triagePickers *cache.Cache[string, triagePicker] // Key: short ID in the picker's callback data
+	mergeDrafts   *cache.Cache[string, mergeDraft]   // Key: short ID in the confirmation's callback data
+
+	dispatchSessions *cache.Cache[string, dispatchSession] // Key: short ID in the /dispatch buttons' callback data
+	dispatchPrompts  *cache.Cache[string, string]          // Key: "chat_id:message_id" of an input prompt, value: session ID
+	listSessions     *cache.Cache[string, listSession]     // Key: short ID in the /prs and /issues buttons' callback data
+	repoPickers      *cache.Cache[string, repoPicker]      // Key: short ID in the /addrepo search results' callback data
+	removePickers    *cache.Cache[string, removePicker]    // Key: short ID in the /removerepo buttons' callback data
 }
 
-func NewCommandHandler(cfg *config.Config, database *db.DB, oauth *gh.OAuth, stateCache *cache.Cache[string, int64], factory *gh.ClientFactory, key string, ctxCache *cache.Cache[string, models.MessageContext], adminCache *cache.Cache[int64, []int64], reloadLimit *cache.Cache[int64, time.Time]) *CommandHandler {
+func NewCommandHandler(cfg *config.Config, database *db.DB, oauth *gh.OAuth, stateCache *cache.Cache[string, models.OAuthState], factory *gh.ClientFactory, key string, ctxCache *cache.Cache[string, models.MessageContext], runActions *cache.Cache[string, models.RunActionContext], alertActions *cache.Cache[string, models.AlertActionContext], adminCache *cache.Cache[int64, []int64], reloadLimit *cache.Cache[int64, time.Time]) *CommandHandler {
 	return &CommandHandler{
 		Config:          cfg,
 		DB:              database,
@@ -46,27 +61,29 @@ func NewCommandHandler(cfg *config.Config, database *db.DB, oauth *gh.OAuth, sta
 		AdminCache:      adminCache,
 		ReloadRateLimit: reloadLimit,
 		ContextCache:    ctxCache,
+		RunActions:      runActions,
+		AlertActions:    alertActions,
+		issueDrafts:     cache.New[string, issueDraft](),
+		triagePickers:   cache.New[string, triagePicker](),
+		mergeDrafts:     cache.New[string, mergeDraft](),
+
+		dispatchSessions: cache.New[string, dispatchSession](),
+		dispatchPrompts:  cache.New[string, string](),
+		listSessions:     cache.New[string, listSession](),
+		repoPickers:      cache.New[string, repoPicker](),
+		removePickers:    cache.New[string, removePicker](),
 	}
 }
 
 func (h *CommandHandler) Start(b *gotgbot.Bot, ctx *ext.Context) error {
-	msg := `<b>Welcome to the GitHub Bot!</b> 🤖
-
-I can help you manage your GitHub repositories and notifications directly from Telegram.
-
-<b>Get Started:</b>
-1. Use /connect to link your GitHub account.
-2. Use /addrepo to link a repository and start receiving notifications.
-3. Use /settings to customize your notification preferences.
-
-Need help? Type /help for a full list of commands.`
+	msg := h.t(ctx, "start")
 	_, err := ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
 	return err
 }
 
 func (h *CommandHandler) Connect(b *gotgbot.Bot, ctx *ext.Context) error {
 	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate {
-		_, err := ctx.EffectiveMessage.Reply(b, "⚠️ The /connect command can only be used in a private chat with the bot.", nil)
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "connect.private_only"), nil)
 		return err
 	}
 
@@ -75,18 +92,18 @@ func (h *CommandHandler) Connect(b *gotgbot.Bot, ctx *ext.Context) error {
 		return err
 	}
 
-	h.StateCache.Set(state, ctx.EffectiveUser.Id, 10*time.Minute)
+	h.StateCache.Set(state, models.OAuthState{TelegramID: ctx.EffectiveUser.Id, CreatedAt: time.Now(), Language: h.lang(ctx)}, 10*time.Minute)
 
 	url := h.OAuth.GetLoginURL(state)
 
-	msg := fmt.Sprintf("Please [connect your GitHub account](%s) to enable automatic webhook setup and perform actions like approving PRs.", url)
+	msg := h.t(ctx, "connect.prompt", url)
 	_, err = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
 	return err
 }
 
 func (h *CommandHandler) AddRepo(b *gotgbot.Bot, ctx *ext.Context) error {
 	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
-		_, err := ctx.EffectiveMessage.Reply(b, "Only admins can add repositories.", nil)
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "admin.add_repo"), nil)
 		return err
 	}
 
@@ -94,24 +111,27 @@ func (h *CommandHandler) AddRepo(b *gotgbot.Bot, ctx *ext.Context) error {
 	if len(args) < 2 {
 		return h.listUserRepos(b, ctx)
 	}
+	if !repoArgRe.MatchString(args[1]) {
+		return h.searchRepos(b, ctx, strings.Join(args[1:], " "))
+	}
 
 	repoFullName := args[1]
 	user, uErr := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 	if uErr != nil || user.EncryptedOAuthToken == "" {
-		msg := fmt.Sprintf("Please [connect your GitHub account](%s) first to link repository %s.", h.OAuth.GetLoginURL("connect"), repoFullName)
+		msg := h.t(ctx, "connect.first_repo", h.OAuth.GetLoginURL("connect"), repoFullName)
 		_, _ = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
 		return nil
 	}
 
 	token, decErr := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	if decErr != nil {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Auth error. Reconnect via /connect", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.auth_reconnect"), nil)
 		return nil
 	}
 
 	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 	if err != nil {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Failed to create GitHub client.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.client"), nil)
 		return nil
 	}
 
@@ -127,7 +147,7 @@ func (h *CommandHandler) AddRepo(b *gotgbot.Bot, ctx *ext.Context) error {
 	}
 
 	if owner == "" || repo == "" {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Invalid repository format. Use owner/repo", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.invalid_format"), nil)
 		return nil
 	}
 
@@ -138,74 +158,38 @@ func (h *CommandHandler) AddRepo(b *gotgbot.Bot, ctx *ext.Context) error {
 			return nil
 		}
 		if errResp, ok := errors.AsType[*github.ErrorResponse](getErr); ok && errResp.Response.StatusCode == http.StatusNotFound {
-			_, _ = ctx.EffectiveMessage.Reply(b, "❌ <b>Repository not found.</b>\nPlease check the name and ensure you have access.", &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.not_found"), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
 			return nil
 		}
-		_, _ = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error fetching repository: %v", getErr), nil)
-		return nil
-	}
-
-	payload := fmt.Sprintf("%d", ctx.EffectiveChat.Id)
-	if ctx.EffectiveMessage.MessageThreadId != 0 {
-		payload = fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, ctx.EffectiveMessage.MessageThreadId)
-	}
-
-	token, encErr := utils.Encrypt(payload, h.EncryptionKey)
-	if encErr != nil {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Error generating webhook token.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.fetch_error", getErr), nil)
 		return nil
 	}
 
-	webhookURL := fmt.Sprintf("%s/webhook/%s", h.Config.TelegramWebhookURL, token)
-	webhookConfig := &github.HookConfig{
-		URL:         github.String(webhookURL),
-		ContentType: github.String("json"),
-		Secret:      github.String(h.Config.GitHubWebhookSecret),
-	}
-
-	var defaultEvents []string
-	for _, e := range gh.SupportedEvents {
-		defaultEvents = append(defaultEvents, e.Name)
-	}
-
-	hook := &github.Hook{
-		Name:   github.String("web"),
-		Events: defaultEvents,
-		Config: webhookConfig,
-		Active: github.Bool(true),
-	}
-
-	createdHook, _, hookErr := client.Repositories.CreateHook(context.Background(), owner, repo, hook)
-	if hookErr != nil {
-		if h.handleAuthError(b, ctx, hookErr) {
+	if err := h.linkRepo(ctx, client, owner, repo); err != nil {
+		switch {
+		case errors.Is(err, errWebhookToken):
+			_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.webhook_token"), nil)
+			return nil
+		case errors.Is(err, errSaveLink):
+			_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.link_repo"), nil)
+			return err
+		case h.handleAuthError(b, ctx, err):
 			return nil
 		}
-		if errResp, ok := errors.AsType[*github.ErrorResponse](hookErr); ok && errResp.Response.StatusCode == http.StatusNotFound {
+		if errResp, ok := errors.AsType[*github.ErrorResponse](err); ok && errResp.Response.StatusCode == http.StatusNotFound {
 			safeRepoName := html.EscapeString(repoFullName)
-			msg := fmt.Sprintf("❌ <b>Insufficient permissions.</b>\nYou need admin access to repository <b>%s</b> to create webhooks.", safeRepoName)
+			msg := h.t(ctx, "repo.no_permission", safeRepoName)
 			_, err := ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
 			return err
 		}
 
-		log.Printf("Webhook creation failed for %s: %v", repoFullName, hookErr)
-		msg := "⚠️ <b>Webhook creation failed.</b>\nPlease ensure you have admin rights and try again."
+		log.Printf("Webhook creation failed for %s: %v", repoFullName, err)
+		msg := h.t(ctx, "repo.webhook_failed")
 		_, err := ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
 		return err
 	}
 
-	webhookID := createdHook.GetID()
-	link := models.RepoLink{
-		RepoFullName: repoFullName,
-		WebhookID:    webhookID,
-	}
-
-	err = h.DB.AddRepoLink(context.Background(), ctx.EffectiveChat.Id, link)
-	if err != nil {
-		_, err := ctx.EffectiveMessage.Reply(b, "Error linking repository.", nil)
-		return err
-	}
-
-	msg := fmt.Sprintf("Repository <b>%s</b> linked successfully!", repoFullName)
+	msg := h.t(ctx, "repo.linked", repoFullName)
 	_, err = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
 	return err
 }
@@ -217,19 +201,19 @@ func (h *CommandHandler) listUserRepos(b *gotgbot.Bot, ctx *ext.Context) error {
 func (h *CommandHandler) sendRepoList(b *gotgbot.Bot, ctx *ext.Context, page int) error {
 	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 	if err != nil || user.EncryptedOAuthToken == "" {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Please /connect your GitHub account first to list repositories.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.connect_to_list"), nil)
 		return nil
 	}
 
 	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	if err != nil {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Auth error. Reconnect via /connect", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.auth_reconnect"), nil)
 		return nil
 	}
 
 	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 	if err != nil {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Failed to create GitHub client.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.client"), nil)
 		return nil
 	}
 
@@ -244,12 +228,12 @@ func (h *CommandHandler) sendRepoList(b *gotgbot.Bot, ctx *ext.Context, page int
 		if h.handleAuthError(b, ctx, err) {
 			return nil
 		}
-		_, _ = ctx.EffectiveMessage.Reply(b, "Failed to fetch repositories from GitHub.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.fetch_failed"), nil)
 		return nil
 	}
 
 	if len(repos) == 0 && page == 1 {
-		_, _ = ctx.EffectiveMessage.Reply(b, "No repositories found.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.none_found"), nil)
 		return nil
 	}
 
@@ -260,42 +244,11 @@ func (h *CommandHandler) sendRepoList(b *gotgbot.Bot, ctx *ext.Context, page int
 		})
 	}
 
-	var navRow []gotgbot.InlineKeyboardButton
-
-	if resp.FirstPage != 0 && resp.PrevPage != 0 {
-		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: "< Prev", CallbackData: fmt.Sprintf("c:ar:pg:%d", resp.PrevPage)})
-	}
-
-	startPage := page - 1
-	if startPage < 1 {
-		startPage = 1
-	}
-	endPage := page + 1
-	if resp.LastPage != 0 && endPage > resp.LastPage {
-		endPage = resp.LastPage
-	}
-
-	if resp.LastPage == 0 && resp.NextPage != 0 {
-		endPage = resp.NextPage
-	}
-
-	for i := startPage; i <= endPage; i++ {
-		text := fmt.Sprintf("%d", i)
-		if i == page {
-			text = fmt.Sprintf("· %d ·", i)
-		}
-		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: text, CallbackData: fmt.Sprintf("c:ar:pg:%d", i)})
-	}
-
-	if resp.NextPage != 0 {
-		navRow = append(navRow, gotgbot.InlineKeyboardButton{Text: "Next >", CallbackData: fmt.Sprintf("c:ar:pg:%d", resp.NextPage)})
-	}
-
-	if len(navRow) > 0 {
+	if navRow := callbacks.PaginationRow(h.lang(ctx), resp, page, func(page int) string { return fmt.Sprintf("c:ar:pg:%d", page) }); len(navRow) > 0 {
 		kb = append(kb, navRow)
 	}
 
-	_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Select a repository to add (Page %d):", page), &gotgbot.SendMessageOpts{
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.select_add", page)+"\n"+h.t(ctx, "repo.search_hint"), &gotgbot.SendMessageOpts{
 		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
 	})
 	return err
@@ -303,7 +256,7 @@ func (h *CommandHandler) sendRepoList(b *gotgbot.Bot, ctx *ext.Context, page int
 
 func (h *CommandHandler) Settings(b *gotgbot.Bot, ctx *ext.Context) error {
 	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
-		_, err := ctx.EffectiveMessage.Reply(b, "Only admins can modify settings.", nil)
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "admin.settings"), nil)
 		return err
 	}
 
@@ -312,19 +265,30 @@ func (h *CommandHandler) Settings(b *gotgbot.Bot, ctx *ext.Context) error {
 		return err
 	}
 
-	if len(links) == 0 {
-		_, err = ctx.EffectiveMessage.Reply(b, "No repositories linked. Use /addrepo first.", nil)
-		return err
-	}
-
 	var kb [][]gotgbot.InlineKeyboardButton
 	for _, l := range links {
 		kb = append(kb, []gotgbot.InlineKeyboardButton{
 			{Text: l.RepoFullName, CallbackData: fmt.Sprintf("c:r:%s", l.RepoFullName)},
 		})
 	}
+	kb = append(kb, []gotgbot.InlineKeyboardButton{
+		{Text: h.t(ctx, "settings.notifications"), CallbackData: "c:n"},
+	})
+
+	name := h.t(ctx, "lang.auto_short")
+	if settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id); settings.Language != "" {
+		name = i18n.Name(settings.Language)
+	}
+	kb = append(kb, []gotgbot.InlineKeyboardButton{
+		{Text: h.t(ctx, "settings.language", name), CallbackData: "c:lang"},
+	})
 
-	_, err = ctx.EffectiveMessage.Reply(b, "Select a repository to configure:", &gotgbot.SendMessageOpts{
+	// Chat-wide settings stay reachable before any repository is linked
+	text := h.t(ctx, "settings.select_repo")
+	if len(links) == 0 {
+		text = h.t(ctx, "settings.none_linked")
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{
 		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
 	})
 	return err
@@ -332,56 +296,60 @@ func (h *CommandHandler) Settings(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func (h *CommandHandler) RemoveRepo(b *gotgbot.Bot, ctx *ext.Context) error {
 	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
-		_, err := ctx.EffectiveMessage.Reply(b, "Only admins can remove repositories.", nil)
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "admin.remove_repo"), nil)
 		return err
 	}
 
 	args := ctx.Args()
 	if len(args) < 2 {
-		_, err := ctx.EffectiveMessage.Reply(b, "Usage: /removerepo owner/repo", nil)
-		return err
+		return h.sendRemovePicker(b, ctx)
 	}
 
 	repoFullName := args[1]
 	link, err := h.DB.GetRepoLink(context.Background(), ctx.EffectiveChat.Id, repoFullName)
 	if err != nil {
-		_, err := ctx.EffectiveMessage.Reply(b, "Error finding repository link or not found.", nil)
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "remove.not_found"), nil)
+		return err
+	}
+
+	webhookStatusMsg, err := h.unlinkRepo(b, ctx, link)
+	if err != nil {
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "remove.db_error"), nil)
 		return err
 	}
 
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "remove.done", repoFullName, webhookStatusMsg), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+	return err
+}
+
+// unlinkRepo deletes the webhook of a link with the user's token and removes the link from the chat.
+// Failing to delete the webhook does not keep the link; the returned warning explains it instead.
+func (h *CommandHandler) unlinkRepo(b *gotgbot.Bot, ctx *ext.Context, link *models.RepoLink) (string, error) {
+	repoFullName := link.RepoFullName
 	var webhookStatusMsg string
 
 	if link.WebhookID != 0 {
 		user, uErr := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 		if uErr != nil || user.EncryptedOAuthToken == "" {
-			webhookStatusMsg = "\n\n⚠️ <b>Warning:</b> You are not connected to GitHub. The webhook could not be removed from the repository settings. Please remove it manually."
+			webhookStatusMsg = h.t(ctx, "remove.warn_connect")
 		} else {
 			token, decErr := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 			if decErr != nil {
-				webhookStatusMsg = "\n\n⚠️ <b>Warning:</b> Could not decrypt your access token. Webhook not removed from GitHub."
+				webhookStatusMsg = h.t(ctx, "remove.warn_decrypt")
 			} else {
 				client, err := h.ClientFactory.GetUserClient(context.Background(), token)
 				if err != nil {
-					webhookStatusMsg = "\n\n⚠️ <b>Warning:</b> Failed to create GitHub client. Webhook not removed."
+					webhookStatusMsg = h.t(ctx, "remove.warn_client")
 				} else {
-					var owner, repo string
-					for i := 0; i < len(repoFullName); i++ {
-						if repoFullName[i] == '/' {
-							owner = repoFullName[:i]
-							repo = repoFullName[i+1:]
-							break
-						}
-					}
-
-					if owner != "" && repo != "" {
-						_, err := client.Repositories.DeleteHook(context.Background(), owner, repo, link.WebhookID)
+					if strings.Contains(repoFullName, "/") {
+						err := gh.DeleteLinkHook(context.Background(), client, *link)
 						if err != nil {
 							if h.handleAuthError(b, ctx, err) {
-								webhookStatusMsg = "\n\n⚠️ <b>Warning:</b> GitHub authentication failed. Webhook not removed."
+								webhookStatusMsg = h.t(ctx, "remove.warn_auth")
 							} else {
 								if errResp, ok := errors.AsType[*github.ErrorResponse](err); ok && errResp.Response.StatusCode == http.StatusNotFound {
 								} else {
-									webhookStatusMsg = fmt.Sprintf("\n\n⚠️ <b>Warning:</b> Failed to remove webhook from GitHub: %v", err)
+									webhookStatusMsg = h.t(ctx, "remove.warn_failed", err)
 								}
 							}
 						}
@@ -391,57 +359,11 @@ func (h *CommandHandler) RemoveRepo(b *gotgbot.Bot, ctx *ext.Context) error {
 		}
 	}
 
-	err = h.DB.RemoveRepoLink(context.Background(), ctx.EffectiveChat.Id, repoFullName)
-	if err != nil {
-		_, err := ctx.EffectiveMessage.Reply(b, "Error removing repository from database.", nil)
-		return err
-	}
-
-	_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Repository <b>%s</b> removed successfully.%s", repoFullName, webhookStatusMsg), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
-	return err
-}
-
-func (h *CommandHandler) Repos(b *gotgbot.Bot, ctx *ext.Context) error {
-	links, err := h.DB.GetChatLinks(context.Background(), ctx.EffectiveChat.Id)
-	if err != nil {
-		return err
-	}
-
-	if len(links) == 0 {
-		_, err = ctx.EffectiveMessage.Reply(b, "No repositories linked.", nil)
-		return err
-	}
-
-	var msg string
-	for _, l := range links {
-		msg += fmt.Sprintf("• <b>%s</b>\n", l.RepoFullName)
-	}
-
-	_, err = ctx.EffectiveMessage.Reply(b, "<b>Linked Repositories:</b>\n"+msg, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
-	return err
+	return webhookStatusMsg, h.DB.RemoveRepoLink(context.Background(), ctx.EffectiveChat.Id, repoFullName)
 }
 
 func (h *CommandHandler) Help(b *gotgbot.Bot, ctx *ext.Context) error {
-	msg := `<b>GitHub Bot Commands:</b>
-
-<b>Account</b>
-/connect - Link your GitHub account (<i>Must be used in private chat</i>)
-
-<b>Repository Management</b>
-/addrepo [owner/repo] - Link a repository
-/removerepo [owner/repo] - Unlink a repository
-/repos - List linked repositories
-/close - Close an issue or PR (reply to notification).
-/reopen - Reopen an issue or PR (reply to notification).
-/approve - Approve a PR (reply to notification).
-
-<b>Configuration</b>
-/settings - Configure event notifications
-/reload - Reload admin cache
-
-
-<b>Need more help?</b>
-Visit the <a href="https://github.com/AshokShau/GithubBot">GitHub page</a> for more details.`
+	msg := h.t(ctx, "help")
 
 	_, err := ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
 	return err
@@ -456,14 +378,14 @@ func (h *CommandHandler) Reload(b *gotgbot.Bot, ctx *ext.Context) error {
 		remaining := time.Until(expiry)
 		if remaining > 0 {
 			minutes := int(math.Ceil(remaining.Minutes()))
-			_, _ = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Please wait %d minutes before reloading again.", minutes), nil)
+			_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "reload.wait", minutes), nil)
 			return nil
 		}
 	}
 
 	member, err := b.GetChatMember(ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, nil)
 	if err != nil {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Failed to check permissions.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "reload.perm_failed"), nil)
 		return nil
 	}
 
@@ -471,53 +393,92 @@ func (h *CommandHandler) Reload(b *gotgbot.Bot, ctx *ext.Context) error {
 	isAdmin := status == "administrator" || status == "creator"
 
 	if !isAdmin {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Only admins can reload the cache.", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "admin.reload"), nil)
 		return nil
 	}
 
 	h.AdminCache.Delete(ctx.EffectiveChat.Id)
 	expiry := time.Now().Add(10 * time.Minute)
 	h.ReloadRateLimit.Set(ctx.EffectiveChat.Id, expiry, 10*time.Minute)
-	_, err = ctx.EffectiveMessage.Reply(b, "Admin cache reloaded.", nil)
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "reload.done"), nil)
 	return err
 }
 
 func (h *CommandHandler) Privacy(b *gotgbot.Bot, ctx *ext.Context) error {
-	msg := `<b>Privacy Policy</b>
+	msg := h.t(ctx, "privacy")
 
-We value your privacy and are committed to protecting your data. This policy outlines how we collect, use, and safeguard your information.
+	_, err := ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+	return err
+}
 
-<b>1. Data Collection</b>
-• <b>Telegram Data:</b> We store your Telegram User ID, Chat ID, and basic profile information to route notifications and manage permissions.
-• <b>GitHub Data:</b> When you connect your account, we securely store your encrypted OAuth token. We also store the names of repositories you link and the Webhook IDs created.
-• <b>Events:</b> We process incoming GitHub webhook events (e.g., pushes, issues) to send notifications to your chat. The content of these events is processed in real-time and not permanently stored.
+func (h *CommandHandler) Logout(b *gotgbot.Bot, ctx *ext.Context) error {
+	err := h.DB.ClearUserToken(context.Background(), ctx.EffectiveUser.Id)
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "logout.error"), nil)
+		return err
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "logout.done"), nil)
+	return err
+}
 
-<b>2. Data Usage</b>
-• <b>Functionality:</b> Your data is used strictly to provide the bot's services: sending notifications, managing repository links, and verifying permissions.
-• <b>Security:</b> Your OAuth tokens are encrypted using AES-GCM before being stored in our database.
+// Mentions shows or toggles whether notifications mention the user on Telegram
+func (h *CommandHandler) Mentions(b *gotgbot.Bot, ctx *ext.Context) error {
+	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
+	if err != nil || user.GitHubUsername == "" {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.connect_first"), nil)
+		return err
+	}
 
-<b>3. Data Sharing</b>
-• We do <b>not</b> share, sell, or rent your personal data to third parties.
-• Data is only shared with GitHub APIs to the extent necessary to perform requested actions (e.g., creating webhooks).
+	args := ctx.Args()
+	if len(args) < 2 {
+		key := "mentions.status_on"
+		if user.NoMentions {
+			key = "mentions.status_off"
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, key, html.EscapeString(user.GitHubUsername)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
 
-<b>4. Data Control & Rights</b>
-• <b>Disconnect:</b> You can unlink your GitHub account at any time, which invalidates the stored token.
-• <b>Removal:</b> You can remove repositories using /removerepo. To request full data deletion, please contact the developer or simply block the bot.
+	var enabled bool
+	switch strings.ToLower(args[1]) {
+	case "on":
+		enabled = true
+	case "off":
+		enabled = false
+	default:
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "mentions.usage"), nil)
+		return err
+	}
 
-<b>5. Contact</b>
-If you have questions or concerns, please visit our <a href="https://github.com/AshokShau/GithubBot">GitHub repository</a> or join our <a href="https://t.me/GuardxSupport">Telegram Support Group</a>.`
+	if err := h.DB.SetUserMentions(context.Background(), user.ID, enabled); err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.save_settings"), nil)
+		return err
+	}
 
-	_, err := ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+	key := "mentions.enabled"
+	if !enabled {
+		key = "mentions.disabled"
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, key), nil)
 	return err
 }
 
-func (h *CommandHandler) Logout(b *gotgbot.Bot, ctx *ext.Context) error {
-	err := h.DB.ClearUserToken(context.Background(), ctx.EffectiveUser.Id)
-	if err != nil {
-		_, err = ctx.EffectiveMessage.Reply(b, "Error logging out.", nil)
+// Me shows the user's personal notification preferences
+func (h *CommandHandler) Me(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate {
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "me.private_only"), nil)
 		return err
 	}
-	_, err = ctx.EffectiveMessage.Reply(b, "✅ You have been logged out. Use /connect to reconnect.", nil)
+
+	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
+	if err != nil || user.GitHubUsername == "" {
+		msg := h.t(ctx, "connect.first", h.OAuth.GetLoginURL("connect"))
+		_, err = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
+		return err
+	}
+
+	msg, markup := callbacks.PersonalMenu(h.lang(ctx), user)
+	_, err = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML", ReplyMarkup: markup})
 	return err
 }
 
@@ -525,7 +486,7 @@ func (h *CommandHandler) handleAuthError(b *gotgbot.Bot, ctx *ext.Context, err e
 	if errResp, ok := errors.AsType[*github.ErrorResponse](err); ok {
 		if errResp.Response.StatusCode == http.StatusUnauthorized || errResp.Response.StatusCode == http.StatusForbidden {
 			_ = h.DB.ClearUserToken(context.Background(), ctx.EffectiveUser.Id)
-			msg := "⚠️ <b>GitHub authentication failed.</b>\nIt seems your token has expired or was revoked. Please /connect again."
+			msg := h.t(ctx, "err.auth_failed")
 			_, _ = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
 			return true
 		}
@@ -533,6 +494,30 @@ func (h *CommandHandler) handleAuthError(b *gotgbot.Bot, ctx *ext.Context, err e
 	return false
 }
 
+// handleActionError is handleAuthError for actions that need more than a valid token, such as
+// re-running a workflow or dismissing an alert. There a 403 means the user lacks permission, so the
+// token is kept and the refusal is shown, as an alert when the action came from a button.
+func (h *CommandHandler) handleActionError(b *gotgbot.Bot, ctx *ext.Context, err error) bool {
+	errResp, ok := errors.AsType[*github.ErrorResponse](err)
+	if !ok || errResp.Response.StatusCode != http.StatusForbidden {
+		if !h.handleAuthError(b, ctx, err) {
+			return false
+		}
+		if ctx.CallbackQuery != nil {
+			_, _ = ctx.CallbackQuery.Answer(b, nil)
+		}
+		return true
+	}
+
+	text := h.t(ctx, "err.forbidden", mergeErrorMessage(err))
+	if ctx.CallbackQuery != nil {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: text, ShowAlert: true})
+	} else {
+		_, _ = ctx.EffectiveMessage.Reply(b, text, nil)
+	}
+	return true
+}
+
 func (h *CommandHandler) Close(b *gotgbot.Bot, ctx *ext.Context) error {
 	return h.handleIssueAction(b, ctx, "closed")
 }
@@ -543,20 +528,13 @@ func (h *CommandHandler) Reopen(b *gotgbot.Bot, ctx *ext.Context) error {
 
 func (h *CommandHandler) Approve(b *gotgbot.Bot, ctx *ext.Context) error {
 	msg := ctx.EffectiveMessage
-	if msg.ReplyToMessage == nil {
-		_, err := msg.Reply(b, "Please use this command in reply to a notification.", nil)
-		return err
-	}
-
-	key := fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, msg.ReplyToMessage.MessageId)
-	mContext, found := h.ContextCache.Get(key)
-	if !found {
-		_, err := msg.Reply(b, "Context not found. The message might be too old.", nil)
-		return err
+	mContext, ok := h.replyContext(b, ctx)
+	if !ok {
+		return nil
 	}
 
 	if mContext.Type != "pr" && mContext.Type != "pr_review" {
-		_, err := msg.Reply(b, "This command is only for Pull Requests.", nil)
+		_, err := msg.Reply(b, h.t(ctx, "reply.pr_only"), nil)
 		return err
 	}
 
@@ -574,26 +552,19 @@ func (h *CommandHandler) Approve(b *gotgbot.Bot, ctx *ext.Context) error {
 		if h.handleAuthError(b, ctx, err) {
 			return nil
 		}
-		_, _ = msg.Reply(b, fmt.Sprintf("Failed to approve: %v", err), nil)
+		_, _ = msg.Reply(b, h.t(ctx, "approve.failed", err), nil)
 		return nil
 	}
 
-	_, err = msg.Reply(b, fmt.Sprintf("✅ PR #%d approved.", mContext.IssueNumber), nil)
+	_, err = msg.Reply(b, h.t(ctx, "approve.done", mContext.IssueNumber), nil)
 	return err
 }
 
 func (h *CommandHandler) handleIssueAction(b *gotgbot.Bot, ctx *ext.Context, state string) error {
 	msg := ctx.EffectiveMessage
-	if msg.ReplyToMessage == nil {
-		_, err := msg.Reply(b, "Please use this command in reply to a notification.", nil)
-		return err
-	}
-
-	key := fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, msg.ReplyToMessage.MessageId)
-	mContext, found := h.ContextCache.Get(key)
-	if !found {
-		_, err := msg.Reply(b, "Context not found. The message might be too old.", nil)
-		return err
+	mContext, ok := h.issueReplyContext(b, ctx)
+	if !ok {
+		return nil
 	}
 
 	client, err := h.getAuthenticatedClient(b, ctx)
@@ -608,31 +579,46 @@ func (h *CommandHandler) handleIssueAction(b *gotgbot.Bot, ctx *ext.Context, sta
 		if h.handleAuthError(b, ctx, err) {
 			return nil
 		}
-		_, _ = msg.Reply(b, fmt.Sprintf("Failed to update state: %v", err), nil)
+		_, _ = msg.Reply(b, h.t(ctx, "state.failed", err), nil)
 		return nil
 	}
 
-	action := "closed"
+	done := "state.closed"
 	if state == "open" {
-		action = "reopened"
+		done = "state.reopened"
 	}
-	_, err = msg.Reply(b, fmt.Sprintf("✅ Issue/PR #%d %s.", mContext.IssueNumber, action), nil)
+	_, err = msg.Reply(b, h.t(ctx, done, mContext.IssueNumber), nil)
 	return err
 }
 
 func (h *CommandHandler) getAuthenticatedClient(b *gotgbot.Bot, ctx *ext.Context) (*github.Client, error) {
 	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
 	if err != nil || user.EncryptedOAuthToken == "" {
-		msg := fmt.Sprintf("Please [connect your GitHub account](%s) first.", h.OAuth.GetLoginURL("connect"))
+		msg := h.t(ctx, "connect.first", h.OAuth.GetLoginURL("connect"))
 		_, _ = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "Markdown"})
 		return nil, fmt.Errorf("auth required")
 	}
 
 	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
 	if err != nil {
-		_, _ = ctx.EffectiveMessage.Reply(b, "Auth error. Reconnect via /connect", nil)
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.auth_reconnect"), nil)
 		return nil, err
 	}
 
 	return h.ClientFactory.GetUserClient(context.Background(), token)
 }
+
+// lang returns the language for replies in the current chat
+func (h *CommandHandler) lang(ctx *ext.Context) string {
+	var userLang string
+	if ctx.EffectiveUser != nil {
+		userLang = ctx.EffectiveUser.LanguageCode
+	}
+	settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	return i18n.Pick(settings.Language, userLang)
+}
+
+// t translates a message into the language of the current chat
+func (h *CommandHandler) t(ctx *ext.Context, key string, args ...interface{}) string {
+	return i18n.T(h.lang(ctx), key, args...)
+}
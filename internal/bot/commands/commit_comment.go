@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// commitPickTTL is how long the commit picker of a reply to a multi-commit push stays usable
+const commitPickTTL = 15 * time.Minute
+
+// maxCommitTitle is the length commit titles are cut to on picker buttons
+const maxCommitTitle = 40
+
+// commitPick is a reply to a multi-commit push waiting for the user to choose the commit
+type commitPick struct {
+	UserID    int64
+	MessageID int64 // The Telegram reply, so the comment can be edited and deleted later
+	Context   models.MessageContext
+	Body      string
+}
+
+// pickCommit asks which commit of a push the reply should comment on, newest first
+func (h *ReplyHandler) pickCommit(b *gotgbot.Bot, ctx *ext.Context, mContext models.MessageContext, body string) error {
+	id, err := github.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+	h.commitPicks.Set(id, commitPick{UserID: ctx.EffectiveUser.Id, MessageID: ctx.EffectiveMessage.MessageId, Context: mContext, Body: body}, commitPickTTL)
+
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "commit.pick"), &gotgbot.SendMessageOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: commitPickerButtons(id, mContext.Commits, h.t(ctx, "commit.cancel"))},
+	})
+	return err
+}
+
+// commitPickerButtons lists commits one per row with their short SHA and title: cc:<id>:<index>
+func commitPickerButtons(id string, commits []models.CommitRef, cancel string) [][]gotgbot.InlineKeyboardButton {
+	var kb [][]gotgbot.InlineKeyboardButton
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		sha := c.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		title := c.Title
+		if r := []rune(title); len(r) > maxCommitTitle {
+			title = string(r[:maxCommitTitle-1]) + "…"
+		}
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{
+			Text:         strings.TrimSpace(sha + " " + title),
+			CallbackData: fmt.Sprintf("cc:%s:%d", id, i),
+		}})
+	}
+	return append(kb, []gotgbot.InlineKeyboardButton{{Text: cancel, CallbackData: "cc:" + id + ":x"}})
+}
+
+// CommitPickCallback posts the waiting reply on the chosen commit: cc:<id>:<index>, or cc:<id>:x to cancel
+func (h *ReplyHandler) CommitPickCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	cq := ctx.CallbackQuery
+	parts := strings.Split(cq.Data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	id := parts[1]
+	pick, ok := h.commitPicks.Get(id)
+	if !ok {
+		_, _ = cq.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "commit.expired"), ShowAlert: true})
+		return nil
+	}
+	if pick.UserID != ctx.EffectiveUser.Id {
+		_, _ = cq.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "commit.not_yours"), ShowAlert: true})
+		return nil
+	}
+
+	if parts[2] == "x" {
+		h.commitPicks.Delete(id)
+		_, _ = cq.Answer(b, nil)
+		_, _ = ctx.EffectiveMessage.Delete(b, nil)
+		return nil
+	}
+
+	i, err := strconv.Atoi(parts[2])
+	if err != nil || i < 0 || i >= len(pick.Context.Commits) {
+		return nil
+	}
+
+	_, client, err := h.connectedClient(ctx.EffectiveUser.Id)
+	if err != nil {
+		_, _ = cq.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.auth_reconnect"), ShowAlert: true})
+		return nil
+	}
+
+	target := pick.Context
+	target.CommitSHA, target.Commits = target.Commits[i].SHA, nil
+	posted, url, err := createComment(client, target, pick.Body)
+	if err != nil {
+		log.Printf("Failed to comment on commit %s in %s/%s: %v", target.CommitSHA, target.Owner, target.Repo, err)
+		_, _ = cq.Answer(b, nil)
+		return h.reportError(b, ctx, err)
+	}
+	h.commitPicks.Delete(id)
+	posted.ChatID, posted.MessageID, posted.UserID = ctx.EffectiveChat.Id, pick.MessageID, pick.UserID
+	if err := h.DB.SavePostedComment(context.Background(), posted); err != nil {
+		log.Printf("Failed to store posted comment %d in %s/%s: %v", posted.CommentID, posted.Owner, posted.Repo, err)
+	}
+
+	_, _ = cq.Answer(b, nil)
+	if !h.confirmations(ctx) {
+		_, _ = ctx.EffectiveMessage.Delete(b, nil)
+		return nil
+	}
+	_, _, err = ctx.EffectiveMessage.EditText(b, h.t(ctx, "comment.posted", url), &gotgbot.EditMessageTextOpts{
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	})
+	return err
+}
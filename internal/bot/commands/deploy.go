@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+var (
+	errNoPendingDeployments = errors.New("no deployments waiting for review")
+	errNotReviewer          = errors.New("not a required reviewer")
+)
+
+// deployReview is the outcome of reviewing the deployments of a run
+type deployReview struct {
+	Environments []string // Environments the user approved or rejected
+	Remaining    int      // Environments still waiting on other reviewers
+}
+
+// Deploy approves or rejects the deployment of the replied review request: /deploy approve|reject [comment]
+func (h *CommandHandler) Deploy(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	args := strings.Fields(msg.GetText())
+	if len(args) < 2 {
+		_, err := msg.Reply(b, h.t(ctx, "deploy.usage"), nil)
+		return err
+	}
+
+	var state string
+	switch strings.ToLower(args[1]) {
+	case "approve":
+		state = gh.DeployApprove
+	case "reject":
+		state = gh.DeployReject
+	default:
+		_, err := msg.Reply(b, h.t(ctx, "deploy.usage"), nil)
+		return err
+	}
+	// The comment keeps its line breaks, so it is cut from the text instead of joined from the fields
+	rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(msg.GetText()), args[0]))
+	comment := strings.TrimSpace(strings.TrimPrefix(rest, args[1]))
+
+	target, ok := h.replyContext(b, ctx)
+	if !ok {
+		return nil
+	}
+	if target.Type != "deployment_review" {
+		_, err := msg.Reply(b, h.t(ctx, "deploy.not_pending"), nil)
+		return err
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	run := models.RunActionContext{Owner: target.Owner, Repo: target.Repo, RunID: target.RunID, Attempt: target.RunAttempt}
+	review, err := reviewDeployments(client, run, state, comment)
+	if err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return nil
+		}
+		_, err = msg.Reply(b, h.deployErrorMessage(ctx, err), nil)
+		return err
+	}
+
+	h.markDeployment(b, ctx, msg.ReplyToMessage, review, state, comment)
+	_, err = msg.Reply(b, h.t(ctx, "deploy.done."+state), nil)
+	return err
+}
+
+// DeployCallback handles the buttons on deployment review notifications: dep:<state>:<id>
+func (h *CommandHandler) DeployCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 3 || (parts[1] != gh.DeployApprove && parts[1] != gh.DeployReject) {
+		return nil
+	}
+
+	state, id := parts[1], parts[2]
+	run, ok := h.RunActions.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "pr.action_expired"), ShowAlert: true})
+		return nil
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	review, err := reviewDeployments(client, run, state, "")
+	if err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return nil
+		}
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.deployErrorMessage(ctx, err), ShowAlert: true})
+		return nil
+	}
+	if review.Remaining == 0 {
+		h.RunActions.Delete(id)
+	}
+
+	_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "deploy.done."+state)})
+	h.markDeployment(b, ctx, ctx.EffectiveMessage, review, state, "")
+	return nil
+}
+
+// reviewDeployments approves or rejects the deployments of a run waiting on the user.
+// GitHub tells which environments the token's user is a required reviewer for, other ones are left alone.
+func reviewDeployments(client *github.Client, run models.RunActionContext, state, comment string) (deployReview, error) {
+	bg := context.Background()
+	pending, _, err := client.Actions.GetPendingDeployments(bg, run.Owner, run.Repo, run.RunID)
+	if err != nil {
+		return deployReview{}, err
+	}
+	if len(pending) == 0 {
+		return deployReview{}, errNoPendingDeployments
+	}
+
+	var review deployReview
+	var ids []int64
+	for _, p := range pending {
+		if !p.GetCurrentUserCanApprove() {
+			review.Remaining++
+			continue
+		}
+		ids = append(ids, p.GetEnvironment().GetID())
+		review.Environments = append(review.Environments, p.GetEnvironment().GetName())
+	}
+	if len(ids) == 0 {
+		return deployReview{}, errNotReviewer
+	}
+
+	_, _, err = client.Actions.PendingDeployments(bg, run.Owner, run.Repo, run.RunID, &github.PendingDeploymentsRequest{
+		EnvironmentIDs: ids,
+		State:          state,
+		Comment:        comment,
+	})
+	return review, err
+}
+
+// deployErrorMessage explains why a deployment review failed
+func (h *CommandHandler) deployErrorMessage(ctx *ext.Context, err error) string {
+	switch {
+	case errors.Is(err, errNoPendingDeployments):
+		return h.t(ctx, "deploy.none_pending")
+	case errors.Is(err, errNotReviewer):
+		return h.t(ctx, "deploy.not_reviewer")
+	}
+	return h.t(ctx, "deploy.failed", mergeErrorMessage(err))
+}
+
+// markDeployment appends the decision, who made it and their comment to the notification.
+// The buttons stay while other environments of the run still wait on someone else.
+func (h *CommandHandler) markDeployment(b *gotgbot.Bot, ctx *ext.Context, notification *gotgbot.Message, review deployReview, state, comment string) {
+	line := h.t(ctx, "deploy.marked."+state, strings.Join(review.Environments, ", "), ctx.EffectiveUser.FirstName)
+	if comment != "" {
+		line += "\n💬 " + comment
+	}
+	markNotification(b, notification, line, review.Remaining == 0)
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"context"
+	"html"
+
+	gh "github-webhook/internal/github"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// Answer marks the discussion comment of the replied notification as the discussion's answer: /answer
+func (h *CommandHandler) Answer(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	target, ok := h.replyContext(b, ctx)
+	if !ok {
+		return nil
+	}
+	if target.CommentNodeID == "" {
+		_, err := msg.Reply(b, h.t(ctx, "answer.not_comment"), nil)
+		return err
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	if err := gh.MarkDiscussionAnswer(context.Background(), client, target.CommentNodeID); err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		_, err = msg.Reply(b, h.t(ctx, "answer.failed", html.EscapeString(mergeErrorMessage(err))), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	_, err = msg.Reply(b, h.t(ctx, "answer.done"), nil)
+	return err
+}
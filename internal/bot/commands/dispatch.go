@@ -0,0 +1,618 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/i18n"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+const (
+	// dispatchTTL is how long a /dispatch conversation waits for the next answer
+	dispatchTTL = 15 * time.Minute
+	// maxDispatchWorkflows caps the workflow files read when listing dispatchable workflows
+	maxDispatchWorkflows = 25
+	// maxDispatchRefs is the number of branches offered when picking the ref
+	maxDispatchRefs = 8
+	// followInterval and followTimeout control how a dispatched run's status is followed
+	followInterval = 15 * time.Second
+	followTimeout  = time.Hour
+)
+
+// dispatchSkip is the answer that keeps an input's default, or leaves an optional input empty
+const dispatchSkip = "-"
+
+// dispatchWorkflow is a workflow with a workflow_dispatch trigger
+type dispatchWorkflow struct {
+	ID     int64
+	Name   string
+	Path   string
+	Inputs []gh.DispatchInput
+}
+
+// dispatchSession is a /dispatch conversation: the repository and workflow, then one step
+// per input, then the ref. Step == len(Workflow.Inputs) is the ref step.
+type dispatchSession struct {
+	UserID     int64
+	Repo       string
+	Repos      []string           // Repository choices while picking the repository
+	Query      string             // Workflow named in the command, matched once the repository is picked
+	Workflows  []dispatchWorkflow // Workflow choices while picking the workflow
+	Workflow   dispatchWorkflow
+	Step       int
+	OptionPage int // Page of the current choice input's options
+	Values     map[string]string
+	Refs       []string
+	Ref        string
+}
+
+// Dispatch triggers a workflow_dispatch workflow: /dispatch [owner/repo] [workflow]
+func (h *CommandHandler) Dispatch(b *gotgbot.Bot, ctx *ext.Context) error {
+	args := ctx.Args()[1:]
+	var repo, workflow string
+	if len(args) > 0 && repoArgRe.MatchString(args[0]) {
+		repo, args = args[0], args[1:]
+	}
+	if len(args) > 0 {
+		workflow = strings.Join(args, " ")
+	}
+
+	id, err := gh.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+	s := dispatchSession{UserID: ctx.EffectiveUser.Id, Repo: repo, Query: workflow, Values: map[string]string{}}
+
+	if repo == "" {
+		links, err := h.DB.GetChatLinks(context.Background(), ctx.EffectiveChat.Id)
+		links = repoLinks(links)
+		if err != nil || len(links) == 0 {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "dispatch.no_repo"), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		if len(links) > 1 {
+			var kb [][]gotgbot.InlineKeyboardButton
+			for i, l := range links {
+				s.Repos = append(s.Repos, l.RepoFullName)
+				kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: l.RepoFullName, CallbackData: fmt.Sprintf("dp:%s:r:%d", id, i)}})
+			}
+			h.dispatchSessions.Set(id, s, dispatchTTL)
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "dispatch.pick_repo"), &gotgbot.SendMessageOpts{ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb}})
+			return err
+		}
+		s.Repo = links[0].RepoFullName
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+	return h.pickDispatchWorkflow(b, ctx, client, id, s, workflow, false)
+}
+
+// pickDispatchWorkflow lists the repository's dispatchable workflows and starts the one matching
+// the given name, or offers them as buttons
+func (h *CommandHandler) pickDispatchWorkflow(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, id string, s dispatchSession, name string, edit bool) error {
+	workflows, err := listDispatchWorkflows(client, s.Repo)
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		return h.replyOrEdit(b, ctx, edit, h.t(ctx, "dispatch.failed", html.EscapeString(mergeErrorMessage(err))), nil)
+	}
+	if len(workflows) == 0 {
+		return h.replyOrEdit(b, ctx, edit, h.t(ctx, "dispatch.no_workflows", html.EscapeString(s.Repo)), nil)
+	}
+
+	if name != "" {
+		wf, ok := findDispatchWorkflow(workflows, name)
+		if !ok {
+			return h.replyOrEdit(b, ctx, edit, h.t(ctx, "dispatch.workflow_not_found", html.EscapeString(name), html.EscapeString(s.Repo)), nil)
+		}
+		s.Workflow = wf
+		return h.dispatchStep(b, ctx, client, id, s, edit)
+	}
+	if len(workflows) == 1 {
+		s.Workflow = workflows[0]
+		return h.dispatchStep(b, ctx, client, id, s, edit)
+	}
+
+	s.Workflows = workflows
+	h.dispatchSessions.Set(id, s, dispatchTTL)
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	for i, wf := range workflows {
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: wf.Name, CallbackData: fmt.Sprintf("dp:%s:w:%d", id, i)}})
+	}
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "dispatch.cancel"), CallbackData: fmt.Sprintf("dp:%s:x", id)}})
+	return h.replyOrEdit(b, ctx, edit, h.t(ctx, "dispatch.pick_workflow", html.EscapeString(s.Repo)), &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+}
+
+// listDispatchWorkflows returns the active workflows whose YAML has a workflow_dispatch trigger
+func listDispatchWorkflows(client *github.Client, repoFullName string) ([]dispatchWorkflow, error) {
+	owner, repo, _ := strings.Cut(repoFullName, "/")
+	bg := context.Background()
+
+	list, _, err := client.Actions.ListWorkflows(bg, owner, repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	var workflows []dispatchWorkflow
+	read := 0
+	for _, wf := range list.Workflows {
+		// Dynamic workflows such as Dependabot's have no file to read
+		if wf.GetState() != "active" || !strings.HasPrefix(wf.GetPath(), ".github/workflows/") {
+			continue
+		}
+		if read++; read > maxDispatchWorkflows {
+			break
+		}
+
+		file, _, _, err := client.Repositories.GetContents(bg, owner, repo, wf.GetPath(), nil)
+		if err != nil || file == nil {
+			continue
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			continue
+		}
+
+		inputs, ok, err := gh.ParseDispatchInputs([]byte(content))
+		if err != nil {
+			log.Printf("Failed to parse workflow %s in %s: %v", wf.GetPath(), repoFullName, err)
+			continue
+		}
+		if ok {
+			workflows = append(workflows, dispatchWorkflow{ID: wf.GetID(), Name: wf.GetName(), Path: wf.GetPath(), Inputs: inputs})
+		}
+	}
+	return workflows, nil
+}
+
+// findDispatchWorkflow matches a workflow by name, file name, or file name without extension
+func findDispatchWorkflow(workflows []dispatchWorkflow, name string) (dispatchWorkflow, bool) {
+	for _, wf := range workflows {
+		file := path.Base(wf.Path)
+		if strings.EqualFold(wf.Name, name) || strings.EqualFold(file, name) || strings.EqualFold(strings.TrimSuffix(file, path.Ext(file)), name) {
+			return wf, true
+		}
+	}
+	return dispatchWorkflow{}, false
+}
+
+// dispatchStep shows the prompt for the session's current step: an input, the ref, or the confirmation
+func (h *CommandHandler) dispatchStep(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, id string, s dispatchSession, edit bool) error {
+	inputs := s.Workflow.Inputs
+	name := html.EscapeString(s.Workflow.Name)
+
+	if s.Step < len(inputs) {
+		in := inputs[s.Step]
+		text := h.dispatchInputPrompt(ctx, s, in)
+		h.dispatchSessions.Set(id, s, dispatchTTL)
+
+		options := in.Options
+		if in.Type == gh.InputBoolean {
+			options = []string{"true", "false"}
+		}
+		if in.Type == gh.InputChoice || in.Type == gh.InputBoolean {
+			// Long choice lists are paged, the option index in the callback data stays absolute
+			page := min(max(s.OptionPage, 0), (len(options)-1)/maxPickerOptions)
+			start := page * maxPickerOptions
+			end := min(start+maxPickerOptions, len(options))
+
+			var kb [][]gotgbot.InlineKeyboardButton
+			var row []gotgbot.InlineKeyboardButton
+			for i := start; i < end; i++ {
+				o := options[i]
+				label := o
+				if o == in.Default {
+					label = "✅ " + o
+				}
+				row = append(row, gotgbot.InlineKeyboardButton{Text: label, CallbackData: fmt.Sprintf("dp:%s:o:%d", id, i)})
+				if len(row) == 2 {
+					kb, row = append(kb, row), nil
+				}
+			}
+			if len(row) > 0 {
+				kb = append(kb, row)
+			}
+			var nav []gotgbot.InlineKeyboardButton
+			if page > 0 {
+				nav = append(nav, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "nav.prev"), CallbackData: fmt.Sprintf("dp:%s:op:%d", id, page-1)})
+			}
+			if end < len(options) {
+				nav = append(nav, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "nav.next"), CallbackData: fmt.Sprintf("dp:%s:op:%d", id, page+1)})
+			}
+			if len(nav) > 0 {
+				kb = append(kb, nav)
+			}
+			kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "dispatch.cancel"), CallbackData: fmt.Sprintf("dp:%s:x", id)}})
+			return h.replyOrEdit(b, ctx, edit, text, &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+		}
+
+		// Free text answers are replies to the prompt; force_reply opens the reply box for the user
+		if edit {
+			_, _, _ = ctx.EffectiveMessage.EditReplyMarkup(b, nil)
+		}
+		sent, err := ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: gotgbot.ForceReply{ForceReply: true, Selective: true, InputFieldPlaceholder: in.Name},
+		})
+		if err == nil {
+			h.dispatchPrompts.Set(fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, sent.MessageId), id, dispatchTTL)
+		}
+		return err
+	}
+
+	if s.Ref == "" {
+		refs, err := dispatchRefs(client, s.Repo)
+		if err != nil {
+			if h.handleAuthError(b, ctx, err) {
+				return nil
+			}
+			return h.replyOrEdit(b, ctx, edit, h.t(ctx, "dispatch.failed", html.EscapeString(mergeErrorMessage(err))), nil)
+		}
+		s.Refs = refs
+		h.dispatchSessions.Set(id, s, dispatchTTL)
+
+		var kb [][]gotgbot.InlineKeyboardButton
+		for i, r := range refs {
+			kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: r, CallbackData: fmt.Sprintf("dp:%s:ref:%d", id, i)}})
+		}
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "dispatch.cancel"), CallbackData: fmt.Sprintf("dp:%s:x", id)}})
+		return h.replyOrEdit(b, ctx, edit, h.t(ctx, "dispatch.pick_ref", name), &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+	}
+
+	h.dispatchSessions.Set(id, s, dispatchTTL)
+
+	var msg strings.Builder
+	msg.WriteString(h.t(ctx, "dispatch.confirm", name, html.EscapeString(s.Repo), html.EscapeString(s.Ref)))
+	for _, in := range inputs {
+		if v, ok := s.Values[in.Name]; ok {
+			fmt.Fprintf(&msg, "\n• <code>%s</code> = %s", html.EscapeString(in.Name), html.EscapeString(v))
+		}
+	}
+	kb := [][]gotgbot.InlineKeyboardButton{{
+		{Text: h.t(ctx, "dispatch.run"), CallbackData: fmt.Sprintf("dp:%s:go", id)},
+		{Text: h.t(ctx, "dispatch.cancel"), CallbackData: fmt.Sprintf("dp:%s:x", id)},
+	}}
+	return h.replyOrEdit(b, ctx, edit, msg.String(), &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+}
+
+// dispatchInputPrompt describes the input being asked for
+func (h *CommandHandler) dispatchInputPrompt(ctx *ext.Context, s dispatchSession, in gh.DispatchInput) string {
+	var msg strings.Builder
+	msg.WriteString(h.t(ctx, "dispatch.input", html.EscapeString(s.Workflow.Name), s.Step+1, len(s.Workflow.Inputs)))
+	fmt.Fprintf(&msg, "\n\n<code>%s</code>", html.EscapeString(in.Name))
+	if in.Required {
+		msg.WriteString(" " + h.t(ctx, "dispatch.input_required"))
+	}
+	if in.Description != "" {
+		msg.WriteString("\n" + html.EscapeString(in.Description))
+	}
+
+	if in.Type == gh.InputChoice || in.Type == gh.InputBoolean {
+		return msg.String()
+	}
+	switch {
+	case in.Default != "":
+		msg.WriteString("\n\n" + h.t(ctx, "dispatch.reply_default", html.EscapeString(in.Default)))
+	case !in.Required:
+		msg.WriteString("\n\n" + h.t(ctx, "dispatch.reply_optional"))
+	default:
+		msg.WriteString("\n\n" + h.t(ctx, "dispatch.reply"))
+	}
+	return msg.String()
+}
+
+// dispatchRefs returns the default branch followed by other branches
+func dispatchRefs(client *github.Client, repoFullName string) ([]string, error) {
+	owner, repo, _ := strings.Cut(repoFullName, "/")
+	bg := context.Background()
+
+	r, _, err := client.Repositories.Get(bg, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	refs := []string{r.GetDefaultBranch()}
+
+	branches, _, err := client.Repositories.ListBranches(bg, owner, repo, &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: maxDispatchRefs}})
+	if err != nil {
+		return refs, nil
+	}
+	for _, br := range branches {
+		if br.GetName() != r.GetDefaultBranch() && len(refs) < maxDispatchRefs {
+			refs = append(refs, br.GetName())
+		}
+	}
+	return refs, nil
+}
+
+// replyOrEdit edits the callback's message, or replies to the command
+func (h *CommandHandler) replyOrEdit(b *gotgbot.Bot, ctx *ext.Context, edit bool, text string, markup *gotgbot.InlineKeyboardMarkup) error {
+	if edit {
+		opts := &gotgbot.EditMessageTextOpts{ParseMode: "HTML"}
+		if markup != nil {
+			opts.ReplyMarkup = *markup
+		}
+		_, _, err := ctx.EffectiveMessage.EditText(b, text, opts)
+		return err
+	}
+
+	opts := &gotgbot.SendMessageOpts{ParseMode: "HTML"}
+	if markup != nil {
+		opts.ReplyMarkup = *markup
+	}
+	_, err := ctx.EffectiveMessage.Reply(b, text, opts)
+	return err
+}
+
+// checkDispatchValue validates a typed answer for an input and returns the value to send.
+// The returned key is the error message to show when the answer is not accepted.
+func checkDispatchValue(in gh.DispatchInput, answer string) (value string, set bool, errKey string) {
+	answer = strings.TrimSpace(answer)
+	if answer == dispatchSkip {
+		switch {
+		case in.Default != "":
+			return in.Default, true, ""
+		case in.Required:
+			return "", false, "dispatch.required"
+		}
+		return "", false, ""
+	}
+	if in.Type == gh.InputNumber {
+		if _, err := strconv.ParseFloat(answer, 64); err != nil {
+			return "", false, "dispatch.not_number"
+		}
+	}
+	return answer, true, ""
+}
+
+// IsDispatchAnswer reports whether a message answers a /dispatch input prompt
+func (h *CommandHandler) IsDispatchAnswer(msg *gotgbot.Message) bool {
+	if msg.ReplyToMessage == nil || msg.GetText() == "" {
+		return false
+	}
+	_, ok := h.dispatchPrompts.Get(fmt.Sprintf("%d:%d", msg.Chat.Id, msg.ReplyToMessage.MessageId))
+	return ok
+}
+
+// DispatchAnswer takes a typed value for the current /dispatch input and moves to the next step
+func (h *CommandHandler) DispatchAnswer(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	key := fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, msg.ReplyToMessage.MessageId)
+	id, _ := h.dispatchPrompts.Get(key)
+
+	s, ok := h.dispatchSessions.Get(id)
+	if !ok || s.UserID != ctx.EffectiveUser.Id || s.Step >= len(s.Workflow.Inputs) {
+		return nil
+	}
+
+	in := s.Workflow.Inputs[s.Step]
+	value, set, errKey := checkDispatchValue(in, msg.GetText())
+	if errKey != "" {
+		sent, err := msg.Reply(b, h.t(ctx, errKey, html.EscapeString(strings.TrimSpace(msg.GetText()))), &gotgbot.SendMessageOpts{
+			ParseMode:   "HTML",
+			ReplyMarkup: gotgbot.ForceReply{ForceReply: true, Selective: true, InputFieldPlaceholder: in.Name},
+		})
+		if err == nil {
+			h.dispatchPrompts.Set(fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, sent.MessageId), id, dispatchTTL)
+		}
+		return err
+	}
+	h.dispatchPrompts.Delete(key)
+
+	if set {
+		s.Values[in.Name] = value
+	}
+	s.Step++
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+	return h.dispatchStep(b, ctx, client, id, s, false)
+}
+
+// DispatchCallback handles the /dispatch buttons: dp:<id>:r:<i> picks the repository,
+// dp:<id>:w:<i> the workflow, dp:<id>:o:<i> a choice, dp:<id>:op:<page> pages the choices, dp:<id>:ref:<i> the ref,
+// dp:<id>:go fires the dispatch and dp:<id>:x cancels
+func (h *CommandHandler) DispatchCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) < 3 {
+		return nil
+	}
+
+	id := parts[1]
+	s, ok := h.dispatchSessions.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.expired"), ShowAlert: true})
+		return nil
+	}
+	if s.UserID != ctx.EffectiveUser.Id {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.not_yours"), ShowAlert: true})
+		return nil
+	}
+
+	if parts[2] == "x" {
+		h.dispatchSessions.Delete(id)
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		_, _, err := ctx.EffectiveMessage.EditText(b, h.t(ctx, "dispatch.cancelled"), nil)
+		return err
+	}
+
+	var idx int
+	if len(parts) == 4 {
+		idx, _ = strconv.Atoi(parts[3])
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+	_, _ = ctx.CallbackQuery.Answer(b, nil)
+
+	switch parts[2] {
+	case "r":
+		if idx < 0 || idx >= len(s.Repos) {
+			return nil
+		}
+		s.Repo = s.Repos[idx]
+		return h.pickDispatchWorkflow(b, ctx, client, id, s, s.Query, true)
+
+	case "w":
+		if idx < 0 || idx >= len(s.Workflows) {
+			return nil
+		}
+		s.Workflow = s.Workflows[idx]
+		return h.dispatchStep(b, ctx, client, id, s, true)
+
+	case "o":
+		if s.Step >= len(s.Workflow.Inputs) {
+			return nil
+		}
+		in := s.Workflow.Inputs[s.Step]
+		options := in.Options
+		if in.Type == gh.InputBoolean {
+			options = []string{"true", "false"}
+		}
+		if idx < 0 || idx >= len(options) {
+			return nil
+		}
+		s.Values[in.Name] = options[idx]
+		s.Step++
+		s.OptionPage = 0
+		return h.dispatchStep(b, ctx, client, id, s, true)
+
+	case "op":
+		if s.Step >= len(s.Workflow.Inputs) {
+			return nil
+		}
+		s.OptionPage = idx
+		return h.dispatchStep(b, ctx, client, id, s, true)
+
+	case "ref":
+		if idx < 0 || idx >= len(s.Refs) {
+			return nil
+		}
+		s.Ref = s.Refs[idx]
+		return h.dispatchStep(b, ctx, client, id, s, true)
+
+	case "go":
+		if s.Ref == "" {
+			return nil
+		}
+		h.dispatchSessions.Delete(id)
+		return h.fireDispatch(b, ctx, client, s)
+	}
+	return nil
+}
+
+// fireDispatch triggers the workflow and follows the resulting run in the confirmation message
+func (h *CommandHandler) fireDispatch(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, s dispatchSession) error {
+	owner, repo, _ := strings.Cut(s.Repo, "/")
+	inputs := make(map[string]any, len(s.Values))
+	for k, v := range s.Values {
+		inputs[k] = v
+	}
+
+	var actor string
+	if user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id); err == nil {
+		actor = user.GitHubUsername
+	}
+
+	started := time.Now().UTC().Truncate(time.Second)
+	details, _, err := client.Actions.CreateWorkflowDispatchEventByID(context.Background(), owner, repo, s.Workflow.ID, github.CreateWorkflowDispatchEventRequest{
+		Ref:              s.Ref,
+		Inputs:           inputs,
+		ReturnRunDetails: github.Ptr(true),
+	})
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		_, _, err = ctx.EffectiveMessage.EditText(b, h.t(ctx, "dispatch.failed", html.EscapeString(mergeErrorMessage(err))), &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	name, ref := html.EscapeString(s.Workflow.Name), html.EscapeString(s.Ref)
+	lang := h.lang(ctx)
+	msg, _, err := ctx.EffectiveMessage.EditText(b, i18n.T(lang, "dispatch.dispatched", name, ref), &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
+	if err != nil {
+		return err
+	}
+
+	go h.followDispatch(b, client, msg.Chat.Id, msg.MessageId, lang, s, details.GetWorkflowRunID(), actor, started)
+	return nil
+}
+
+// followDispatch edits the message with the run's status until it completes.
+// Servers that do not return run details are searched for the dispatched run instead: the caller's
+// workflow_dispatch runs on the ref created since the dispatch, so someone else's run is never followed.
+func (h *CommandHandler) followDispatch(b *gotgbot.Bot, client *github.Client, chatID, messageID int64, lang string, s dispatchSession, runID int64, actor string, started time.Time) {
+	owner, repo, _ := strings.Cut(s.Repo, "/")
+	bg := context.Background()
+	deadline := time.Now().Add(followTimeout)
+	var last string
+
+	for time.Now().Before(deadline) {
+		time.Sleep(followInterval)
+
+		if runID == 0 {
+			if actor == "" {
+				return
+			}
+			runs, _, err := client.Actions.ListWorkflowRunsByID(bg, owner, repo, s.Workflow.ID, &github.ListWorkflowRunsOptions{
+				Actor:       actor,
+				Event:       "workflow_dispatch",
+				Branch:      s.Ref,
+				Created:     ">=" + started.Format(time.RFC3339),
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			if err != nil || len(runs.WorkflowRuns) == 0 {
+				continue
+			}
+			runID = runs.WorkflowRuns[0].GetID()
+		}
+
+		run, _, err := client.Actions.GetWorkflowRunByID(bg, owner, repo, runID)
+		if err != nil {
+			log.Printf("Failed to follow run %d in %s: %v", runID, s.Repo, err)
+			return
+		}
+
+		state := run.GetStatus()
+		if state == "completed" {
+			state = run.GetConclusion()
+		}
+		if state != last {
+			last = state
+			text := i18n.T(lang, "dispatch.status", html.EscapeString(s.Workflow.Name), html.EscapeString(s.Ref),
+				gh.RunStatusEmoji(run.GetStatus(), run.GetConclusion()), html.EscapeString(strings.ReplaceAll(state, "_", " ")))
+			markup := gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{{
+				{Text: i18n.T(lang, "dispatch.view_run"), Url: run.GetHTMLURL()},
+			}}}
+			_, _, err := b.EditMessageText(text, &gotgbot.EditMessageTextOpts{ChatId: chatID, MessageId: messageID, ParseMode: "HTML", ReplyMarkup: markup})
+			if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+				log.Printf("Failed to update dispatch message %d in chat %d: %v", messageID, chatID, err)
+			}
+		}
+
+		if run.GetStatus() == "completed" {
+			return
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"testing"
+
+	gh "github-webhook/internal/github"
+)
+
+func TestCheckDispatchValue(t *testing.T) {
+	tests := []struct {
+		in     gh.DispatchInput
+		answer string
+		value  string
+		set    bool
+		errKey string
+	}{
+		{gh.DispatchInput{Type: gh.InputString}, " v1.2 ", "v1.2", true, ""},
+		{gh.DispatchInput{Type: gh.InputString, Default: "main"}, "-", "main", true, ""},
+		{gh.DispatchInput{Type: gh.InputString}, "-", "", false, ""},
+		{gh.DispatchInput{Type: gh.InputString, Required: true}, "-", "", false, "dispatch.required"},
+		{gh.DispatchInput{Type: gh.InputNumber}, "3", "3", true, ""},
+		{gh.DispatchInput{Type: gh.InputNumber}, "three", "", false, "dispatch.not_number"},
+	}
+
+	for _, tt := range tests {
+		value, set, errKey := checkDispatchValue(tt.in, tt.answer)
+		if value != tt.value || set != tt.set || errKey != tt.errKey {
+			t.Errorf("checkDispatchValue(%+v, %q) = %q, %v, %q, want %q, %v, %q", tt.in, tt.answer, value, set, errKey, tt.value, tt.set, tt.errKey)
+		}
+	}
+}
+
+func TestFindDispatchWorkflow(t *testing.T) {
+	workflows := []dispatchWorkflow{
+		{ID: 1, Name: "CI", Path: ".github/workflows/ci.yml"},
+		{ID: 2, Name: "Deploy to production", Path: ".github/workflows/deploy.yaml"},
+	}
+
+	for name, want := range map[string]int64{"deploy": 2, "deploy.yaml": 2, "deploy to Production": 2, "ci": 1} {
+		if wf, ok := findDispatchWorkflow(workflows, name); !ok || wf.ID != want {
+			t.Errorf("findDispatchWorkflow(%q) = %d, %v, want %d", name, wf.ID, ok, want)
+		}
+	}
+	if _, ok := findDispatchWorkflow(workflows, "release"); ok {
+		t.Error("findDispatchWorkflow(release) should not match")
+	}
+}
@@ -0,0 +1,330 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// issueDraft is an issue parsed from /issue, kept while the user picks a repository
+type issueDraft struct {
+	UserID    int64
+	Title     string
+	Body      string
+	Labels    []string
+	Assignees []string
+	Milestone string
+	Repos     []string // Repository choices offered by the picker
+}
+
+var (
+	repoArgRe   = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+	issueMetaRe = regexp.MustCompile(`(?i)^\s*(labels?|assignees?|milestone)\s*:\s*(.*?)\s*$`)
+)
+
+// maxQuotedTitle is the length of a title taken from the first line of a quoted message
+const maxQuotedTitle = 80
+
+// parseIssueCommand splits "/issue [owner/repo] Title" and the lines after it into
+// the target repository and the issue draft. Lines starting with labels:, assignees:
+// or milestone: set those fields instead of being part of the body.
+func parseIssueCommand(text string) (string, issueDraft) {
+	var d issueDraft
+
+	head, rest, _ := strings.Cut(text, "\n")
+	_, head, _ = strings.Cut(strings.TrimSpace(head), " ")
+	head = strings.TrimSpace(head)
+
+	var repo string
+	if first, title, _ := strings.Cut(head, " "); repoArgRe.MatchString(first) {
+		repo, head = first, strings.TrimSpace(title)
+	}
+	d.Title = head
+
+	var body []string
+	for _, line := range strings.Split(rest, "\n") {
+		m := issueMetaRe.FindStringSubmatch(line)
+		if m == nil {
+			body = append(body, line)
+			continue
+		}
+
+		switch key := strings.ToLower(m[1]); {
+		case strings.HasPrefix(key, "label"):
+			for _, l := range strings.Split(m[2], ",") {
+				if l = strings.TrimSpace(l); l != "" {
+					d.Labels = append(d.Labels, l)
+				}
+			}
+		case strings.HasPrefix(key, "assignee"):
+			for _, a := range strings.FieldsFunc(m[2], func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+				d.Assignees = append(d.Assignees, strings.TrimPrefix(a, "@"))
+			}
+		default:
+			d.Milestone = m[2]
+		}
+	}
+	d.Body = strings.TrimSpace(strings.Join(body, "\n"))
+
+	return repo, d
+}
+
+// quoteMessage renders a Telegram message as a Markdown quote with a link back to it, if the chat has public links
+func quoteMessage(msg *gotgbot.Message) string {
+	text := msg.GetText()
+	if text == "" {
+		text = msg.Caption
+	}
+
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		b.WriteString("> " + line + "\n")
+	}
+
+	author := "Telegram"
+	if msg.From != nil {
+		author = strings.TrimSpace(msg.From.FirstName + " " + msg.From.LastName)
+	}
+	if link := messageLink(msg.Chat, msg.MessageId); link != "" {
+		fmt.Fprintf(&b, "\n— %s, [on Telegram](%s)", author, link)
+	} else {
+		fmt.Fprintf(&b, "\n— %s, on Telegram", author)
+	}
+	return b.String()
+}
+
+// messageLink returns the t.me link of a message, or "" for chats whose messages cannot be linked
+func messageLink(chat gotgbot.Chat, messageID int64) string {
+	if chat.Username != "" {
+		return fmt.Sprintf("https://t.me/%s/%d", chat.Username, messageID)
+	}
+	// Supergroup and channel IDs are -100 followed by the ID used in private links
+	if chat.Type == gotgbot.ChatTypeSupergroup || chat.Type == gotgbot.ChatTypeChannel {
+		if id := strings.TrimPrefix(strconv.FormatInt(chat.Id, 10), "-100"); id != strconv.FormatInt(chat.Id, 10) {
+			return fmt.Sprintf("https://t.me/c/%s/%d", id, messageID)
+		}
+	}
+	return ""
+}
+
+// Issue creates a GitHub issue from a message
+func (h *CommandHandler) Issue(b *gotgbot.Bot, ctx *ext.Context) error {
+	repo, draft := parseIssueCommand(ctx.EffectiveMessage.GetText())
+	draft.UserID = ctx.EffectiveUser.Id
+
+	if reply := ctx.EffectiveMessage.ReplyToMessage; reply != nil && (reply.GetText() != "" || reply.Caption != "") {
+		quote := quoteMessage(reply)
+		if draft.Body != "" {
+			draft.Body = quote + "\n\n" + draft.Body
+		} else {
+			draft.Body = quote
+		}
+		if draft.Title == "" {
+			draft.Title = quotedTitle(reply)
+		}
+	}
+
+	if draft.Title == "" {
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "issue.usage"), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	if repo == "" {
+		links, err := h.DB.GetChatLinks(context.Background(), ctx.EffectiveChat.Id)
+		links = repoLinks(links)
+		if err != nil || len(links) == 0 {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "issue.no_repo"), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		if len(links) > 1 {
+			return h.pickIssueRepo(b, ctx, draft, links)
+		}
+		repo = links[0].RepoFullName
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	text, issue := h.createIssue(b, ctx, client, repo, draft)
+	if text == "" {
+		return nil
+	}
+	sent, err := ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+	if err == nil && issue != nil {
+		h.storeIssueContext(ctx.EffectiveChat.Id, sent.MessageId, repo, issue)
+	}
+	return err
+}
+
+// repoLinks returns the links of single repositories. Organization links are named "org/*" and
+// cannot be offered where one repository is needed.
+func repoLinks(links []models.RepoLink) []models.RepoLink {
+	var repos []models.RepoLink
+	for _, l := range links {
+		if !l.Org {
+			repos = append(repos, l)
+		}
+	}
+	return repos
+}
+
+// quotedTitle derives an issue title from the first line of a quoted message
+func quotedTitle(msg *gotgbot.Message) string {
+	text := msg.GetText()
+	if text == "" {
+		text = msg.Caption
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	if r := []rune(line); len(r) > maxQuotedTitle {
+		line = string(r[:maxQuotedTitle-1]) + "…"
+	}
+	return line
+}
+
+func (h *CommandHandler) pickIssueRepo(b *gotgbot.Bot, ctx *ext.Context, draft issueDraft, links []models.RepoLink) error {
+	id, err := gh.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	for i, l := range links {
+		draft.Repos = append(draft.Repos, l.RepoFullName)
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: l.RepoFullName, CallbackData: fmt.Sprintf("ni:%s:%d", id, i)}})
+	}
+	h.issueDrafts.Set(id, draft, 15*time.Minute)
+
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "issue.pick_repo", html.EscapeString(draft.Title)), &gotgbot.SendMessageOpts{
+		ParseMode:   "HTML",
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+	})
+	return err
+}
+
+// IssueRepoCallback creates a pending issue in the repository picked from the inline keyboard (ni:<id>:<index>)
+func (h *CommandHandler) IssueRepoCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	draft, ok := h.issueDrafts.Get(parts[1])
+	idx, _ := strconv.Atoi(parts[2])
+	if !ok || idx < 0 || idx >= len(draft.Repos) {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "issue.expired"), ShowAlert: true})
+		return nil
+	}
+	if draft.UserID != ctx.EffectiveUser.Id {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "issue.not_yours"), ShowAlert: true})
+		return nil
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	repo := draft.Repos[idx]
+	text, issue := h.createIssue(b, ctx, client, repo, draft)
+	if text == "" {
+		return nil
+	}
+	h.issueDrafts.Delete(parts[1])
+
+	msg, _, err := ctx.EffectiveMessage.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+	if err == nil && issue != nil {
+		h.storeIssueContext(ctx.EffectiveChat.Id, msg.MessageId, repo, issue)
+	}
+	return err
+}
+
+// createIssue opens the issue and returns the confirmation or error text to show.
+// An empty text means the error was already reported.
+func (h *CommandHandler) createIssue(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, repoFullName string, draft issueDraft) (string, *github.Issue) {
+	owner, repo, _ := strings.Cut(repoFullName, "/")
+	req := &github.IssueRequest{Title: &draft.Title}
+	if draft.Body != "" {
+		req.Body = &draft.Body
+	}
+	if len(draft.Labels) > 0 {
+		req.Labels = &draft.Labels
+	}
+
+	if len(draft.Assignees) > 0 {
+		assignees := make([]string, 0, len(draft.Assignees))
+		for _, a := range draft.Assignees {
+			if strings.EqualFold(a, "me") {
+				user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
+				if err != nil {
+					continue
+				}
+				a = user.GitHubUsername
+			}
+			assignees = append(assignees, a)
+		}
+		req.Assignees = &assignees
+	}
+
+	if draft.Milestone != "" {
+		number, err := findMilestone(client, owner, repo, draft.Milestone)
+		if err != nil {
+			return h.t(ctx, "issue.milestone_not_found", html.EscapeString(draft.Milestone), html.EscapeString(repoFullName)), nil
+		}
+		req.Milestone = &number
+	}
+
+	issue, _, err := client.Issues.Create(context.Background(), owner, repo, req)
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return "", nil
+		}
+		return h.t(ctx, "issue.failed", html.EscapeString(err.Error())), nil
+	}
+
+	return h.t(ctx, "issue.created", html.EscapeString(issue.GetHTMLURL()), issue.GetNumber(), html.EscapeString(repoFullName), html.EscapeString(issue.GetTitle())), issue
+}
+
+// findMilestone resolves an open milestone's title, or a milestone number, to its number
+func findMilestone(client *github.Client, owner, repo, milestone string) (int, error) {
+	opts := &github.MilestoneListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 100}}
+	milestones, _, err := client.Issues.ListMilestones(context.Background(), owner, repo, opts)
+	if err != nil {
+		return 0, err
+	}
+	for _, m := range milestones {
+		if strings.EqualFold(m.GetTitle(), milestone) {
+			return m.GetNumber(), nil
+		}
+	}
+
+	if n, err := strconv.Atoi(strings.TrimPrefix(milestone, "#")); err == nil {
+		return n, nil
+	}
+	return 0, fmt.Errorf("milestone %q not found", milestone)
+}
+
+// storeIssueContext lets replies to the confirmation message comment on the new issue
+func (h *CommandHandler) storeIssueContext(chatID, messageID int64, repoFullName string, issue *github.Issue) {
+	owner, repo, _ := strings.Cut(repoFullName, "/")
+	h.ContextCache.Set(fmt.Sprintf("%d:%d", chatID, messageID), models.MessageContext{
+		Owner:       owner,
+		Repo:        repo,
+		IssueNumber: issue.GetNumber(),
+		Type:        "issue",
+	}, 48*time.Hour)
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+func TestParseIssueCommand(t *testing.T) {
+	text := "/issue@GithubBot octo/hello-world  Crash on  start\n" +
+		"Steps:\n" +
+		"1. open the app\n" +
+		"Labels: bug, good first issue\n" +
+		"assignees: @me octocat,hubot\n" +
+		"milestone: v1.0\n"
+
+	repo, d := parseIssueCommand(text)
+	if repo != "octo/hello-world" {
+		t.Errorf("repo = %q", repo)
+	}
+	if d.Title != "Crash on  start" {
+		t.Errorf("title = %q", d.Title)
+	}
+	if d.Body != "Steps:\n1. open the app" {
+		t.Errorf("body = %q", d.Body)
+	}
+	if want := []string{"bug", "good first issue"}; !reflect.DeepEqual(d.Labels, want) {
+		t.Errorf("labels = %v, want %v", d.Labels, want)
+	}
+	if want := []string{"me", "octocat", "hubot"}; !reflect.DeepEqual(d.Assignees, want) {
+		t.Errorf("assignees = %v, want %v", d.Assignees, want)
+	}
+	if d.Milestone != "v1.0" {
+		t.Errorf("milestone = %q", d.Milestone)
+	}
+
+	repo, d = parseIssueCommand("/issue Fix the docs")
+	if repo != "" || d.Title != "Fix the docs" || d.Body != "" {
+		t.Errorf("parseIssueCommand() = %q, %+v", repo, d)
+	}
+
+	repo, d = parseIssueCommand("/issue")
+	if repo != "" || d.Title != "" {
+		t.Errorf("parseIssueCommand() = %q, %+v", repo, d)
+	}
+}
+
+func TestQuoteMessage(t *testing.T) {
+	msg := &gotgbot.Message{
+		MessageId: 42,
+		Text:      "it crashes\nevery time",
+		From:      &gotgbot.User{FirstName: "Ada"},
+		Chat:      gotgbot.Chat{Id: -1001234567890, Type: gotgbot.ChatTypeSupergroup},
+	}
+
+	got := quoteMessage(msg)
+	for _, want := range []string{"> it crashes\n> every time\n", "— Ada, [on Telegram](https://t.me/c/1234567890/42)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("quoteMessage() missing %q in %q", want, got)
+		}
+	}
+
+	msg.Chat = gotgbot.Chat{Id: -42, Type: gotgbot.ChatTypeGroup}
+	if got := quoteMessage(msg); strings.Contains(got, "t.me") {
+		t.Errorf("basic group messages cannot be linked: %q", got)
+	}
+
+	msg.Chat = gotgbot.Chat{Id: -1001, Type: gotgbot.ChatTypeSupergroup, Username: "octochat"}
+	if got := messageLink(msg.Chat, 7); got != "https://t.me/octochat/7" {
+		t.Errorf("messageLink() = %q", got)
+	}
+}
+
+func TestRepoLinks(t *testing.T) {
+	links := []models.RepoLink{
+		{RepoFullName: "octo/app"},
+		{RepoFullName: "octo-org/*", Org: true},
+		{RepoFullName: "octo/api"},
+	}
+	got := repoLinks(links)
+	if len(got) != 2 || got[0].RepoFullName != "octo/app" || got[1].RepoFullName != "octo/api" {
+		t.Errorf("repoLinks() = %v, want octo/app and octo/api", got)
+	}
+}
@@ -0,0 +1,339 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-webhook/internal/bot/callbacks"
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// Kinds of /prs and /issues listings, as used by the search "is:" qualifier
+const (
+	listPRs    = "pr"
+	listIssues = "issue"
+)
+
+const (
+	// listPageSize matches the page size of the /addrepo repository list
+	listPageSize = 5
+	// listTTL is how long the buttons of a listing keep working
+	listTTL = 30 * time.Minute
+	// maxListTitle is the length of a title shown in a listing button
+	maxListTitle = 48
+	// maxDetailBody is the length of the description excerpt shown in the detail view
+	maxDetailBody = 400
+)
+
+// listSession is a /prs or /issues listing with the entries of the page on screen
+type listSession struct {
+	UserID  int64
+	Kind    string
+	Query   string
+	Filters string // Filters typed by the user, shown in the header
+	Page    int
+	Items   []models.MessageContext
+}
+
+// PRs lists open pull requests: /prs [owner/repo] [author:x] [label:x] [review:required] [is:draft]
+func (h *CommandHandler) PRs(b *gotgbot.Bot, ctx *ext.Context) error {
+	return h.startList(b, ctx, listPRs, "/prs")
+}
+
+// Issues lists open issues: /issues [owner/repo] [author:x] [label:x] [assignee:x]
+func (h *CommandHandler) Issues(b *gotgbot.Bot, ctx *ext.Context) error {
+	return h.startList(b, ctx, listIssues, "/issues")
+}
+
+func (h *CommandHandler) startList(b *gotgbot.Bot, ctx *ext.Context, kind, command string) error {
+	args := ctx.Args()[1:]
+
+	var scopes []string
+	if len(args) > 0 && repoArgRe.MatchString(args[0]) {
+		scopes, args = []string{"repo:" + args[0]}, args[1:]
+	} else {
+		links, err := h.DB.GetChatLinks(context.Background(), ctx.EffectiveChat.Id)
+		if err != nil || len(links) == 0 {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "list.no_repo", command), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		for _, l := range links {
+			scopes = append(scopes, searchScope(l))
+		}
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	id, err := gh.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+
+	s := listSession{
+		UserID:  ctx.EffectiveUser.Id,
+		Kind:    kind,
+		Query:   buildSearchQuery(kind, scopes, args),
+		Filters: strings.Join(args, " "),
+		Page:    1,
+	}
+	return h.showList(b, ctx, client, id, s, false)
+}
+
+// searchScope returns the search qualifier covering a link: org:<login> for an organization link,
+// repo:<owner/repo> otherwise
+func searchScope(l models.RepoLink) string {
+	if l.Org {
+		org, _, _ := strings.Cut(l.RepoFullName, "/")
+		return "org:" + org
+	}
+	return "repo:" + l.RepoFullName
+}
+
+// buildSearchQuery turns the repo: and org: scopes and the filters of /prs or /issues into an issue
+// search query. Filters are search qualifiers such as author:, label:, review:required and is:draft;
+// open items are listed unless a filter asks for another state.
+func buildSearchQuery(kind string, scopes []string, filters []string) string {
+	parts := []string{"is:" + kind}
+
+	state := true
+	for _, f := range filters {
+		switch lf := strings.ToLower(f); {
+		case lf == "is:open", lf == "is:closed", lf == "is:merged", lf == "is:unmerged", strings.HasPrefix(lf, "state:"):
+			state = false
+		}
+	}
+	if state {
+		parts = append(parts, "is:open")
+	}
+
+	parts = append(parts, scopes...)
+	parts = append(parts, filters...)
+	return strings.Join(parts, " ")
+}
+
+// showList runs the search for the session's page and shows it as buttons, with the same
+// pagination row as the /addrepo repository list
+func (h *CommandHandler) showList(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, id string, s listSession, edit bool) error {
+	opts := &github.SearchOptions{Sort: "updated", Order: "desc", ListOptions: github.ListOptions{PerPage: listPageSize, Page: s.Page}}
+	result, resp, err := client.Search.Issues(context.Background(), s.Query, opts)
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		return h.replyOrEdit(b, ctx, edit, h.t(ctx, "list.failed", html.EscapeString(mergeErrorMessage(err))), nil)
+	}
+
+	header := h.t(ctx, "list.header."+s.Kind, result.GetTotal(), s.Page)
+	if s.Filters != "" {
+		header += "\n<code>" + html.EscapeString(s.Filters) + "</code>"
+	}
+	if len(result.Issues) == 0 {
+		return h.replyOrEdit(b, ctx, edit, header+"\n\n"+h.t(ctx, "list.empty"), nil)
+	}
+
+	multiRepo := strings.Count(s.Query, "repo:") > 1 || strings.Contains(s.Query, "org:")
+	s.Items = nil
+	var kb [][]gotgbot.InlineKeyboardButton
+	for i, issue := range result.Issues {
+		item := searchResultContext(issue)
+		s.Items = append(s.Items, item)
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: listEntryText(issue, item, multiRepo), CallbackData: fmt.Sprintf("ls:%s:v:%d", id, i)}})
+	}
+	h.listSessions.Set(id, s, listTTL)
+
+	if nav := callbacks.PaginationRow(h.lang(ctx), resp, s.Page, func(page int) string { return fmt.Sprintf("ls:%s:pg:%d", id, page) }); len(nav) > 0 {
+		kb = append(kb, nav)
+	}
+	return h.replyOrEdit(b, ctx, edit, header, &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+}
+
+// searchResultContext returns the repository and number of a search result
+func searchResultContext(issue *github.Issue) models.MessageContext {
+	// repository_url is https://api.github.com/repos/<owner>/<repo>
+	parts := strings.Split(strings.TrimSuffix(issue.GetRepositoryURL(), "/"), "/")
+	var owner, repo string
+	if len(parts) >= 2 {
+		owner, repo = parts[len(parts)-2], parts[len(parts)-1]
+	}
+
+	t := "issue"
+	if issue.IsPullRequest() {
+		t = "pr"
+	}
+	return models.MessageContext{Owner: owner, Repo: repo, IssueNumber: issue.GetNumber(), Type: t}
+}
+
+// listEntryText is the button text of a listed PR or issue
+func listEntryText(issue *github.Issue, item models.MessageContext, withRepo bool) string {
+	title := issue.GetTitle()
+	if r := []rune(title); len(r) > maxListTitle {
+		title = string(r[:maxListTitle-1]) + "…"
+	}
+
+	text := fmt.Sprintf("#%d %s", item.IssueNumber, title)
+	if withRepo {
+		text = fmt.Sprintf("%s#%d %s", item.Repo, item.IssueNumber, title)
+	}
+	if issue.GetDraft() {
+		text = "📝 " + text
+	}
+	return text
+}
+
+// showListDetail shows one PR or issue with its actions
+func (h *CommandHandler) showListDetail(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, id string, s listSession, idx int) error {
+	item := s.Items[idx]
+	bg := context.Background()
+
+	issue, _, err := client.Issues.Get(bg, item.Owner, item.Repo, item.IssueNumber)
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		return h.replyOrEdit(b, ctx, true, h.t(ctx, "list.failed", html.EscapeString(mergeErrorMessage(err))), nil)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "<b>#%d %s</b>\n", issue.GetNumber(), html.EscapeString(issue.GetTitle()))
+	fmt.Fprintf(&msg, "%s/%s · @%s · %s\n", html.EscapeString(item.Owner), html.EscapeString(item.Repo),
+		html.EscapeString(issue.GetUser().GetLogin()), issue.GetCreatedAt().Format("2006-01-02"))
+
+	if item.Type == "pr" {
+		if pr, _, err := client.PullRequests.Get(bg, item.Owner, item.Repo, item.IssueNumber); err == nil {
+			fmt.Fprintf(&msg, "<code>%s</code> → <code>%s</code> · +%d −%d\n", html.EscapeString(pr.GetHead().GetRef()),
+				html.EscapeString(pr.GetBase().GetRef()), pr.GetAdditions(), pr.GetDeletions())
+			if pr.GetDraft() {
+				msg.WriteString(h.t(ctx, "list.draft") + "\n")
+			}
+		}
+	}
+
+	if len(issue.Labels) > 0 {
+		var labels []string
+		for _, l := range issue.Labels {
+			labels = append(labels, html.EscapeString(l.GetName()))
+		}
+		msg.WriteString(h.t(ctx, "list.labels", strings.Join(labels, ", ")) + "\n")
+	}
+	if len(issue.Assignees) > 0 {
+		var assignees []string
+		for _, a := range issue.Assignees {
+			assignees = append(assignees, "@"+html.EscapeString(a.GetLogin()))
+		}
+		msg.WriteString(h.t(ctx, "list.assignees", strings.Join(assignees, ", ")) + "\n")
+	}
+	msg.WriteString(h.t(ctx, "list.comments", issue.GetComments()) + "\n")
+
+	if body := strings.TrimSpace(issue.GetBody()); body != "" {
+		if r := []rune(body); len(r) > maxDetailBody {
+			body = string(r[:maxDetailBody-1]) + "…"
+		}
+		msg.WriteString("\n<blockquote>" + html.EscapeString(body) + "</blockquote>")
+	}
+
+	var actions []gotgbot.InlineKeyboardButton
+	if item.Type == "pr" {
+		actions = append(actions, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "list.approve"), CallbackData: fmt.Sprintf("ls:%s:a:%d", id, idx)})
+	}
+	if issue.GetState() == "open" {
+		actions = append(actions, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "list.close"), CallbackData: fmt.Sprintf("ls:%s:c:%d", id, idx)})
+	}
+	actions = append(actions, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "list.label"), CallbackData: fmt.Sprintf("ls:%s:l:%d", id, idx)})
+
+	kb := [][]gotgbot.InlineKeyboardButton{
+		actions,
+		{
+			{Text: h.t(ctx, "list.back"), CallbackData: fmt.Sprintf("ls:%s:pg:%d", id, s.Page)},
+			{Text: h.t(ctx, "list.open"), Url: issue.GetHTMLURL()},
+		},
+	}
+
+	_, _, err = ctx.EffectiveMessage.EditText(b, strings.TrimSpace(msg.String()), &gotgbot.EditMessageTextOpts{
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+		ReplyMarkup:        gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+	})
+	return err
+}
+
+// ListCallback handles the /prs and /issues buttons: ls:<id>:pg:<page> shows a page,
+// ls:<id>:v:<i> opens an entry, and ls:<id>:a|c|l:<i> approves, closes or labels it
+func (h *CommandHandler) ListCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 4 {
+		return nil
+	}
+
+	id := parts[1]
+	s, ok := h.listSessions.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.expired"), ShowAlert: true})
+		return nil
+	}
+	if s.UserID != ctx.EffectiveUser.Id {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.not_yours"), ShowAlert: true})
+		return nil
+	}
+
+	n, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return nil
+	}
+	if parts[2] != "pg" && (n < 0 || n >= len(s.Items)) {
+		return nil
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	switch parts[2] {
+	case "pg":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		s.Page = max(n, 1)
+		return h.showList(b, ctx, client, id, s, true)
+
+	case "v":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		return h.showListDetail(b, ctx, client, id, s, n)
+
+	case "l":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		return h.sendTriagePicker(b, ctx, client, s.Items[n], triageLabel)
+
+	case "a", "c":
+		item := s.Items[n]
+		var done string
+		if parts[2] == "a" {
+			_, _, err = client.PullRequests.CreateReview(context.Background(), item.Owner, item.Repo, item.IssueNumber, &github.PullRequestReviewRequest{Event: github.Ptr("APPROVE")})
+			done = h.t(ctx, "approve.done", item.IssueNumber)
+		} else {
+			_, _, err = client.Issues.Edit(context.Background(), item.Owner, item.Repo, item.IssueNumber, &github.IssueRequest{State: github.Ptr("closed")})
+			done = h.t(ctx, "state.closed", item.IssueNumber)
+		}
+		if err != nil {
+			if h.handleAuthError(b, ctx, err) {
+				return nil
+			}
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "err.failed", mergeErrorMessage(err)), ShowAlert: true})
+			return nil
+		}
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: done})
+		return h.showListDetail(b, ctx, client, id, s, n)
+	}
+	return nil
+}
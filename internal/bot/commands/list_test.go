@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestBuildSearchQuery(t *testing.T) {
+	tests := []struct {
+		kind    string
+		scopes  []string
+		filters []string
+		want    string
+	}{
+		{listPRs, []string{"repo:octo/app"}, nil, "is:pr is:open repo:octo/app"},
+		{listPRs, []string{"repo:octo/app", "repo:octo/api"}, []string{"author:octocat", "review:required", "is:draft"}, "is:pr is:open repo:octo/app repo:octo/api author:octocat review:required is:draft"},
+		{listIssues, []string{"repo:octo/app"}, []string{"label:bug", "is:closed"}, "is:issue repo:octo/app label:bug is:closed"},
+		{listPRs, []string{"repo:octo/app"}, []string{"is:merged"}, "is:pr repo:octo/app is:merged"},
+		{listIssues, []string{"repo:octo/app", "org:octo-org"}, nil, "is:issue is:open repo:octo/app org:octo-org"},
+	}
+
+	for _, tt := range tests {
+		if got := buildSearchQuery(tt.kind, tt.scopes, tt.filters); got != tt.want {
+			t.Errorf("buildSearchQuery(%s, %v, %v) = %q, want %q", tt.kind, tt.scopes, tt.filters, got, tt.want)
+		}
+	}
+}
+
+func TestSearchScope(t *testing.T) {
+	if got := searchScope(models.RepoLink{RepoFullName: "octo/app"}); got != "repo:octo/app" {
+		t.Errorf("searchScope(repo) = %q, want repo:octo/app", got)
+	}
+	if got := searchScope(models.RepoLink{RepoFullName: "octo-org/*", Org: true}); got != "org:octo-org" {
+		t.Errorf("searchScope(org) = %q, want org:octo-org", got)
+	}
+}
+
+func TestSearchResultContext(t *testing.T) {
+	pr := &github.Issue{
+		Number:           github.Ptr(7),
+		RepositoryURL:    github.Ptr("https://api.github.com/repos/octo/app"),
+		PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/octo/app/pulls/7")},
+		Title:            github.Ptr("A very long pull request title that does not fit in a Telegram button"),
+		Draft:            github.Ptr(true),
+	}
+
+	item := searchResultContext(pr)
+	want := models.MessageContext{Owner: "octo", Repo: "app", IssueNumber: 7, Type: "pr"}
+	if !reflect.DeepEqual(item, want) {
+		t.Errorf("searchResultContext() = %+v, want %+v", item, want)
+	}
+
+	text := listEntryText(pr, item, true)
+	if want := "📝 app#7 A very long pull request title that does not fi…"; text != want {
+		t.Errorf("listEntryText() = %q, want %q", text, want)
+	}
+
+	issue := &github.Issue{Number: github.Ptr(3), RepositoryURL: github.Ptr("https://ghe.example.com/api/v3/repos/team/site")}
+	if got := searchResultContext(issue); got.Owner != "team" || got.Repo != "site" || got.Type != "issue" {
+		t.Errorf("searchResultContext() = %+v", got)
+	}
+}
@@ -0,0 +1,261 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+	"time"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// mergeDraft is a pending merge confirmation
+type mergeDraft struct {
+	UserID int64
+	Target models.MessageContext
+	Method string
+	State  *gh.MergeState
+}
+
+// Merge shows a merge confirmation for the PR of the replied notification: /merge [merge|squash|rebase]
+func (h *CommandHandler) Merge(b *gotgbot.Bot, ctx *ext.Context) error {
+	target, ok := h.replyContext(b, ctx)
+	if !ok {
+		return nil
+	}
+	if !strings.HasPrefix(target.Type, "pr") {
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "reply.pr_only"), nil)
+		return err
+	}
+
+	var method string
+	if args := ctx.Args(); len(args) > 1 {
+		method = strings.ToLower(args[1])
+		if !slices.Contains(gh.MergeMethods, method) {
+			_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "merge.usage"), nil)
+			return err
+		}
+	}
+
+	return h.startMerge(b, ctx, target, method)
+}
+
+// startMerge loads the PR's merge state and replies with the confirmation
+func (h *CommandHandler) startMerge(b *gotgbot.Bot, ctx *ext.Context, target models.MessageContext, method string) error {
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	state, err := gh.FetchMergeState(context.Background(), client, target.Owner, target.Repo, target.IssueNumber)
+	if err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return nil
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.failed", err), nil)
+		return err
+	}
+
+	id, err := gh.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+
+	d := mergeDraft{UserID: ctx.EffectiveUser.Id, Target: target, Method: state.DefaultMethod(method), State: state}
+	h.mergeDrafts.Set(id, d, 15*time.Minute)
+
+	text, markup := h.mergeConfirmation(ctx, id, d)
+	_, err = ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{ParseMode: "HTML", ReplyMarkup: markup})
+	return err
+}
+
+// mergeConfirmation renders the merge state, blockers and the buttons that apply
+func (h *CommandHandler) mergeConfirmation(ctx *ext.Context, id string, d mergeDraft) (string, gotgbot.InlineKeyboardMarkup) {
+	s := d.State
+
+	var msg strings.Builder
+	msg.WriteString(h.t(ctx, "merge.title", s.Number, html.EscapeString(s.Title)) + "\n\n")
+	msg.WriteString(h.t(ctx, "merge.method", h.t(ctx, "merge.method."+d.Method)) + "\n")
+	if title := s.CommitTitle(d.Method); title != "" {
+		msg.WriteString(h.t(ctx, "merge.commit_title", html.EscapeString(title)) + "\n")
+	}
+
+	decision := "none"
+	if s.ReviewDecision != "" {
+		decision = strings.ToLower(s.ReviewDecision)
+	}
+	msg.WriteString(h.t(ctx, "merge.reviews", h.t(ctx, "merge.review."+decision)) + "\n")
+
+	passed, pending, failed := s.CheckCounts()
+	msg.WriteString(h.t(ctx, "merge.checks", passed, pending, failed) + "\n")
+
+	blockers := s.Blockers()
+	if len(blockers) > 0 {
+		msg.WriteString("\n" + h.t(ctx, "merge.blocked") + "\n")
+		for _, bl := range blockers {
+			key := "merge.blocker." + bl.Code
+			if len(bl.Checks) > 0 {
+				msg.WriteString("• " + h.t(ctx, key, html.EscapeString(strings.Join(bl.Checks, ", "))) + "\n")
+			} else {
+				msg.WriteString("• " + h.t(ctx, key) + "\n")
+			}
+		}
+		if s.AutoMerge {
+			msg.WriteString("\n" + h.t(ctx, "merge.auto_already"))
+		}
+	}
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	if len(s.Methods) > 1 {
+		var row []gotgbot.InlineKeyboardButton
+		for _, m := range s.Methods {
+			text := h.t(ctx, "merge.method."+m)
+			if m == d.Method {
+				text = "✅ " + text
+			}
+			row = append(row, gotgbot.InlineKeyboardButton{Text: text, CallbackData: fmt.Sprintf("mg:%s:m:%s", id, m)})
+		}
+		kb = append(kb, row)
+	}
+
+	var actions []gotgbot.InlineKeyboardButton
+	switch {
+	case len(blockers) == 0:
+		actions = append(actions, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "merge.do"), CallbackData: fmt.Sprintf("mg:%s:do", id)})
+	case gh.CanAutoMerge(blockers) && !s.AutoMerge:
+		actions = append(actions, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "merge.auto"), CallbackData: fmt.Sprintf("mg:%s:auto", id)})
+	}
+	if slices.ContainsFunc(blockers, func(bl gh.MergeBlocker) bool { return bl.Code == gh.BlockerBehind }) {
+		actions = append(actions, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "merge.update"), CallbackData: fmt.Sprintf("mg:%s:up", id)})
+	}
+	actions = append(actions, gotgbot.InlineKeyboardButton{Text: h.t(ctx, "merge.cancel"), CallbackData: fmt.Sprintf("mg:%s:x", id)})
+	kb = append(kb, actions)
+
+	return strings.TrimSpace(msg.String()), gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb}
+}
+
+// MergeCallback handles the Merge button on PR notifications (mg:start) and the confirmation
+// buttons: mg:<id>:m:<method> switches the method, mg:<id>:do merges, mg:<id>:auto enables
+// auto-merge, mg:<id>:up updates an out of date branch and mg:<id>:x cancels
+func (h *CommandHandler) MergeCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) == 2 && parts[1] == "start" {
+		key := fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, ctx.EffectiveMessage.MessageId)
+		target, ok := h.ContextCache.Get(key)
+		if !ok {
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "pr.action_expired"), ShowAlert: true})
+			return nil
+		}
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		return h.startMerge(b, ctx, target, "")
+	}
+	if len(parts) < 3 {
+		return nil
+	}
+
+	id := parts[1]
+	d, ok := h.mergeDrafts.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.expired"), ShowAlert: true})
+		return nil
+	}
+	if d.UserID != ctx.EffectiveUser.Id {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.not_yours"), ShowAlert: true})
+		return nil
+	}
+
+	switch parts[2] {
+	case "m":
+		if len(parts) != 4 || !slices.Contains(d.State.Methods, parts[3]) {
+			return nil
+		}
+		d.Method = parts[3]
+		h.mergeDrafts.Set(id, d, 15*time.Minute)
+		text, markup := h.mergeConfirmation(ctx, id, d)
+		_, _, err := ctx.EffectiveMessage.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML", ReplyMarkup: markup})
+		return err
+
+	case "x":
+		h.mergeDrafts.Delete(id)
+		_, _, err := ctx.EffectiveMessage.EditText(b, h.t(ctx, "merge.cancelled"), nil)
+		return err
+
+	case "do", "auto", "up":
+		client, err := h.getAuthenticatedClient(b, ctx)
+		if err != nil {
+			return nil
+		}
+
+		var text string
+		switch parts[2] {
+		case "do":
+			text, err = h.doMerge(ctx, client, d)
+		case "auto":
+			err = gh.EnableAutoMerge(context.Background(), client, d.State, d.Method)
+			text = h.t(ctx, "merge.auto_done", d.State.Number)
+		case "up":
+			err = updateBranch(client, d)
+			text = h.t(ctx, "merge.update_done", d.State.Number)
+		}
+		if err != nil {
+			if h.handleActionError(b, ctx, err) {
+				return nil
+			}
+			text = h.t(ctx, "merge.failed", html.EscapeString(mergeErrorMessage(err)))
+		} else {
+			h.mergeDrafts.Delete(id)
+		}
+
+		_, _, err = ctx.EffectiveMessage.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
+		return err
+	}
+	return nil
+}
+
+// doMerge merges the PR, refusing if its head moved since the confirmation was shown
+func (h *CommandHandler) doMerge(ctx *ext.Context, client *github.Client, d mergeDraft) (string, error) {
+	s := d.State
+	opts := &github.PullRequestOptions{
+		CommitTitle: s.CommitTitle(d.Method),
+		MergeMethod: d.Method,
+		SHA:         s.HeadSHA,
+	}
+	result, _, err := client.PullRequests.Merge(context.Background(), d.Target.Owner, d.Target.Repo, s.Number, "", opts)
+	if err != nil {
+		return "", err
+	}
+
+	sha := result.GetSHA()
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	return h.t(ctx, "merge.done", s.Number, h.t(ctx, "merge.method."+d.Method), sha), nil
+}
+
+// updateBranch merges the base branch into the PR's head, refusing if the head moved since the confirmation was shown.
+// GitHub updates the branch in the background, so an accepted request counts as done.
+func updateBranch(client *github.Client, d mergeDraft) error {
+	opts := &github.PullRequestBranchUpdateOptions{ExpectedHeadSHA: github.Ptr(d.State.HeadSHA)}
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), d.Target.Owner, d.Target.Repo, d.State.Number, opts)
+	if _, ok := errors.AsType[*github.AcceptedError](err); ok {
+		return nil
+	}
+	return err
+}
+
+// mergeErrorMessage extracts GitHub's explanation from a failed merge, such as conflicts or a moved head
+func mergeErrorMessage(err error) string {
+	if errResp, ok := errors.AsType[*github.ErrorResponse](err); ok && errResp.Message != "" {
+		return errResp.Message
+	}
+	return err.Error()
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+	"github-webhook/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// orgHookScope is the OAuth scope needed to create organization webhooks
+const orgHookScope = "admin:org_hook"
+
+// AddOrg links every repository of an organization, including ones created later, through a single
+// organization webhook: /addorg <org> [pattern...] [-pattern...]
+func (h *CommandHandler) AddOrg(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
+		_, err := msg.Reply(b, h.t(ctx, "admin.add_repo"), nil)
+		return err
+	}
+
+	args := ctx.Args()
+	if len(args) < 2 {
+		_, err := msg.Reply(b, h.t(ctx, "org.usage"), nil)
+		return err
+	}
+	include, exclude, ok := gh.ParseRepoPatterns(args[2:])
+	if !ok {
+		_, err := msg.Reply(b, h.t(ctx, "org.bad_pattern"), nil)
+		return err
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	bg := context.Background()
+	org, resp, err := client.Organizations.Get(bg, args[1])
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		if errResp, ok := errors.AsType[*github.ErrorResponse](err); ok && errResp.Response.StatusCode == http.StatusNotFound {
+			_, err = msg.Reply(b, h.t(ctx, "org.not_found", html.EscapeString(args[1])), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		_, err = msg.Reply(b, h.t(ctx, "repo.fetch_error", err), nil)
+		return err
+	}
+	login := org.GetLogin()
+
+	// Tokens from before the scope was requested lack it; only OAuth tokens report their scopes
+	if scopes := resp.Header.Get("X-OAuth-Scopes"); scopes != "" && !hasScope(scopes, orgHookScope) && !hasScope(scopes, "admin:org") {
+		_, err = msg.Reply(b, h.t(ctx, "org.scope_missing", orgHookScope), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	name := gh.OrgLinkName(login)
+	if _, err := h.DB.GetRepoLink(bg, ctx.EffectiveChat.Id, name); err == nil {
+		_, err = msg.Reply(b, h.t(ctx, "org.already_linked", html.EscapeString(login), html.EscapeString(name)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	config, err := h.webhookConfig(ctx)
+	if err != nil {
+		_, err = msg.Reply(b, h.t(ctx, "err.webhook_token"), nil)
+		return err
+	}
+
+	var events []string
+	for _, e := range gh.LinkEvents(true) {
+		events = append(events, e.Name)
+	}
+
+	hook, _, err := client.Organizations.CreateHook(bg, login, &github.Hook{
+		Name:   github.Ptr("web"),
+		Events: events,
+		Config: config,
+		Active: github.Ptr(true),
+	})
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		if errResp, ok := errors.AsType[*github.ErrorResponse](err); ok && errResp.Response.StatusCode == http.StatusNotFound {
+			_, err = msg.Reply(b, h.t(ctx, "org.no_permission", html.EscapeString(login)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		log.Printf("Organization webhook creation failed for %s: %v", login, err)
+		_, err = msg.Reply(b, h.t(ctx, "repo.webhook_failed"), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	link := models.RepoLink{
+		RepoFullName:  name,
+		WebhookID:     hook.GetID(),
+		Org:           true,
+		Include:       include,
+		Exclude:       exclude,
+		CreatedBy:     ctx.EffectiveUser.Id,
+		CreatedByName: ctx.EffectiveUser.FirstName,
+		CreatedAt:     time.Now(),
+	}
+	if err := h.DB.AddRepoLink(bg, ctx.EffectiveChat.Id, link); err != nil {
+		_, err = msg.Reply(b, h.t(ctx, "err.link_repo"), nil)
+		return err
+	}
+
+	text := h.t(ctx, "org.linked", html.EscapeString(login)) + h.patternSummary(ctx, link)
+	_, err = msg.Reply(b, text, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+	return err
+}
+
+// patternSummary describes the repository filters of an organization link, empty when it has none
+func (h *CommandHandler) patternSummary(ctx *ext.Context, link models.RepoLink) string {
+	var s string
+	if len(link.Include) > 0 {
+		s += "\n" + h.t(ctx, "org.include", html.EscapeString(strings.Join(link.Include, ", ")))
+	}
+	if len(link.Exclude) > 0 {
+		s += "\n" + h.t(ctx, "org.exclude", html.EscapeString(strings.Join(link.Exclude, ", ")))
+	}
+	return s
+}
+
+// hasScope reports whether a comma-separated X-OAuth-Scopes header grants a scope
+func hasScope(header, scope string) bool {
+	scopes := strings.Split(header, ",")
+	for i := range scopes {
+		scopes[i] = strings.TrimSpace(scopes[i])
+	}
+	return slices.Contains(scopes, scope)
+}
+
+// webhookConfig returns the configuration of a webhook delivering to the current chat and topic
+func (h *CommandHandler) webhookConfig(ctx *ext.Context) (*github.HookConfig, error) {
+	payload := fmt.Sprintf("%d", ctx.EffectiveChat.Id)
+	if ctx.EffectiveMessage.MessageThreadId != 0 {
+		payload = fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, ctx.EffectiveMessage.MessageThreadId)
+	}
+
+	token, err := utils.Encrypt(payload, h.EncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	return &github.HookConfig{
+		URL:         github.Ptr(fmt.Sprintf("%s/webhook/%s", h.Config.TelegramWebhookURL, token)),
+		ContentType: github.Ptr("json"),
+		Secret:      github.Ptr(h.Config.GitHubWebhookSecret),
+	}, nil
+}
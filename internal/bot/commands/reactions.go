@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	gh "github.com/google/go-github/v89/github"
+)
+
+// githubReactions maps Telegram reaction emoji to GitHub reaction content
+var githubReactions = map[string]string{
+	"👍":  "+1",
+	"👎":  "-1",
+	"❤":  "heart",
+	"❤️": "heart",
+	"🎉":  "hooray",
+	"👀":  "eyes",
+	"🚀":  "rocket",
+}
+
+// reactionTarget is the issue, pull request or comment a reaction is mirrored to
+type reactionTarget struct {
+	Owner     string
+	Repo      string
+	Number    int
+	CommentID int64
+	Review    bool // CommentID is a pull request review comment
+	Commit    bool // CommentID is a commit comment
+}
+
+// HandleReaction mirrors reactions on notifications, and on replies posted as comments, to GitHub.
+// Removing a reaction in Telegram removes the user's reaction on GitHub.
+func (h *ReplyHandler) HandleReaction(b *gotgbot.Bot, ctx *ext.Context) error {
+	r := ctx.MessageReaction
+	if r.User == nil {
+		return nil
+	}
+
+	target, ok := h.reactionTarget(r.Chat.Id, r.MessageId)
+	if !ok {
+		return nil
+	}
+
+	added, removed := reactionChanges(r.OldReaction, r.NewReaction)
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	// Reactions carry no message to answer, so users who are not connected are skipped silently
+	user, client, err := h.connectedClient(r.User.Id)
+	if err != nil {
+		return nil
+	}
+
+	for _, content := range added {
+		if err := addReaction(client, target, content); err != nil {
+			log.Printf("Failed to add %s reaction in %s/%s#%d: %v", content, target.Owner, target.Repo, target.Number, err)
+		}
+	}
+	for _, content := range removed {
+		if err := removeReaction(client, target, content, user); err != nil {
+			log.Printf("Failed to remove %s reaction in %s/%s#%d: %v", content, target.Owner, target.Repo, target.Number, err)
+		}
+	}
+	return nil
+}
+
+// reactionTarget finds what a reacted message stands for on GitHub
+func (h *ReplyHandler) reactionTarget(chatID, messageID int64) (reactionTarget, bool) {
+	key := fmt.Sprintf("%d:%d", chatID, messageID)
+	if mContext, ok := h.ContextCache.Get(key); ok {
+		switch {
+		case mContext.Type == "commit_comment":
+			return reactionTarget{Owner: mContext.Owner, Repo: mContext.Repo, CommentID: mContext.CommentID, Commit: true}, true
+		case mContext.IssueNumber != 0:
+			t := reactionTarget{Owner: mContext.Owner, Repo: mContext.Repo, Number: mContext.IssueNumber}
+			if mContext.CommentID != 0 {
+				t.CommentID, t.Review = mContext.CommentID, mContext.Type == "pr_review_comment"
+			}
+			return t, true
+		}
+	}
+	// Discussion comments are left out, their reactions need the GraphQL API
+	if posted, err := h.DB.GetPostedComment(context.Background(), chatID, messageID); err == nil && posted.NodeID == "" {
+		return reactionTarget{Owner: posted.Owner, Repo: posted.Repo, CommentID: posted.CommentID, Review: posted.Review, Commit: posted.Commit}, true
+	}
+	return reactionTarget{}, false
+}
+
+// reactionChanges returns the GitHub reactions added and removed by a Telegram reaction update.
+// Emoji without a GitHub counterpart are ignored.
+func reactionChanges(old, new []gotgbot.ReactionType) (added, removed []string) {
+	contents := func(reactions []gotgbot.ReactionType) map[string]bool {
+		m := map[string]bool{}
+		for _, r := range reactions {
+			if e, ok := r.(gotgbot.ReactionTypeEmoji); ok {
+				if c, ok := githubReactions[e.Emoji]; ok {
+					m[c] = true
+				}
+			}
+		}
+		return m
+	}
+
+	before, after := contents(old), contents(new)
+	for _, c := range sortedReactions {
+		if after[c] && !before[c] {
+			added = append(added, c)
+		}
+		if before[c] && !after[c] {
+			removed = append(removed, c)
+		}
+	}
+	return added, removed
+}
+
+// sortedReactions orders GitHub reaction content as GitHub shows it
+var sortedReactions = []string{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+func addReaction(client *gh.Client, t reactionTarget, content string) error {
+	bg := context.Background()
+	var err error
+	switch {
+	case t.Commit:
+		_, _, err = client.Reactions.CreateCommentReaction(bg, t.Owner, t.Repo, t.CommentID, content)
+	case t.Review:
+		_, _, err = client.Reactions.CreatePullRequestCommentReaction(bg, t.Owner, t.Repo, t.CommentID, content)
+	case t.CommentID != 0:
+		_, _, err = client.Reactions.CreateIssueCommentReaction(bg, t.Owner, t.Repo, t.CommentID, content)
+	default:
+		_, _, err = client.Reactions.CreateIssueReaction(bg, t.Owner, t.Repo, t.Number, content)
+	}
+	return err
+}
+
+// removeReaction deletes a GitHub user's reaction. Reaction IDs are not kept, so it is looked up
+// among the reactions of that kind.
+func removeReaction(client *gh.Client, t reactionTarget, content string, user *models.User) error {
+	bg := context.Background()
+	opts := &gh.ListReactionOptions{Content: content, ListOptions: gh.ListOptions{PerPage: 100}}
+	for {
+		var reactions []*gh.Reaction
+		var resp *gh.Response
+		var err error
+		switch {
+		case t.Commit:
+			reactions, resp, err = client.Reactions.ListCommentReactions(bg, t.Owner, t.Repo, t.CommentID, opts)
+		case t.Review:
+			reactions, resp, err = client.Reactions.ListPullRequestCommentReactions(bg, t.Owner, t.Repo, t.CommentID, opts)
+		case t.CommentID != 0:
+			reactions, resp, err = client.Reactions.ListIssueCommentReactions(bg, t.Owner, t.Repo, t.CommentID, opts)
+		default:
+			reactions, resp, err = client.Reactions.ListIssueReactions(bg, t.Owner, t.Repo, t.Number, opts)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, r := range reactions {
+			if r.GetUser().GetID() != user.GitHubUserID && !strings.EqualFold(r.GetUser().GetLogin(), user.GitHubUsername) {
+				continue
+			}
+			switch {
+			case t.Commit:
+				_, err = client.Reactions.DeleteCommentReaction(bg, t.Owner, t.Repo, t.CommentID, r.GetID())
+			case t.Review:
+				_, err = client.Reactions.DeletePullRequestCommentReaction(bg, t.Owner, t.Repo, t.CommentID, r.GetID())
+			case t.CommentID != 0:
+				_, err = client.Reactions.DeleteIssueCommentReaction(bg, t.Owner, t.Repo, t.CommentID, r.GetID())
+			default:
+				_, err = client.Reactions.DeleteIssueReaction(bg, t.Owner, t.Repo, t.Number, r.GetID())
+			}
+			return err
+		}
+
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
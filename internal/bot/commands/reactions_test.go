@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+func TestReactionChanges(t *testing.T) {
+	emoji := func(emojis ...string) []gotgbot.ReactionType {
+		var out []gotgbot.ReactionType
+		for _, e := range emojis {
+			out = append(out, gotgbot.ReactionTypeEmoji{Emoji: e})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name           string
+		old, new       []gotgbot.ReactionType
+		added, removed []string
+	}{
+		{name: "added", new: emoji("👍"), added: []string{"+1"}},
+		{name: "removed", old: emoji("🎉"), removed: []string{"hooray"}},
+		{name: "replaced", old: emoji("👍"), new: emoji("👎"), added: []string{"-1"}, removed: []string{"+1"}},
+		{name: "heart with and without variation selector", old: emoji("❤"), new: emoji("❤️", "👀"), added: []string{"eyes"}},
+		{name: "unmapped emoji are ignored", old: emoji("🔥"), new: emoji("🔥", "🤡")},
+		{name: "custom emoji are ignored", new: []gotgbot.ReactionType{gotgbot.ReactionTypeCustomEmoji{CustomEmojiId: "1"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := reactionChanges(tt.old, tt.new)
+			if !slices.Equal(added, tt.added) || !slices.Equal(removed, tt.removed) {
+				t.Errorf("reactionChanges() = %v, %v, want %v, %v", added, removed, tt.added, tt.removed)
+			}
+		})
+	}
+}
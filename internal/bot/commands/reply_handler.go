@@ -2,11 +2,18 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+	"time"
 
 	"github-webhook/internal/cache"
 	"github-webhook/internal/db"
 	"github-webhook/internal/github"
+	"github-webhook/internal/i18n"
 	"github-webhook/internal/models"
 	"github-webhook/internal/utils"
 
@@ -20,6 +27,8 @@ type ReplyHandler struct {
 	ClientFactory *github.ClientFactory
 	EncryptionKey string
 	ContextCache  *cache.Cache[string, models.MessageContext]
+	hinted        *cache.Cache[int64, bool]        // Users already told to connect GitHub
+	commitPicks   *cache.Cache[string, commitPick] // Replies to multi-commit pushes waiting for a commit
 }
 
 func NewReplyHandler(database *db.DB, factory *github.ClientFactory, key string, ctxCache *cache.Cache[string, models.MessageContext]) *ReplyHandler {
@@ -28,10 +37,18 @@ func NewReplyHandler(database *db.DB, factory *github.ClientFactory, key string,
 		ClientFactory: factory,
 		EncryptionKey: key,
 		ContextCache:  ctxCache,
+		hinted:        cache.New[int64, bool](),
+		commitPicks:   cache.New[string, commitPick](),
 	}
 }
 
+// HandleReply posts a reply to a notification as a GitHub comment. Edits of a posted reply
+// update the comment instead.
 func (h *ReplyHandler) HandleReply(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EditedMessage != nil {
+		return h.handleEdit(b, ctx)
+	}
+
 	msg := ctx.EffectiveMessage
 	if msg.ReplyToMessage == nil {
 		return nil
@@ -39,44 +56,297 @@ func (h *ReplyHandler) HandleReply(b *gotgbot.Bot, ctx *ext.Context) error {
 
 	key := fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, msg.ReplyToMessage.MessageId)
 	mContext, found := h.ContextCache.Get(key)
-	if !found {
+	// CI notifications have nothing to comment on
+	if !found || !commentable(mContext) {
 		return nil
 	}
 
-	commentBody := msg.Text
-	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
-	if err != nil || user.EncryptedOAuthToken == "" {
-		// _, _ = msg.Reply(b, "Please connect GitHub account via /connect first.", nil)
+	commentBody := h.commentBody(msg)
+	client, ok := h.userClient(b, ctx)
+	if !ok {
 		return nil
 	}
 
-	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
-	if err != nil {
-		_, _ = msg.Reply(b, "Auth error. Reconnect via /connect", nil)
-		return nil
+	if len(mContext.Commits) > 0 {
+		return h.pickCommit(b, ctx, mContext, commentBody)
 	}
 
-	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
+	posted, url, err := createComment(client, mContext, commentBody)
 	if err != nil {
-		fmt.Printf("Failed to create GitHub client: %v\n", err)
+		log.Printf("Failed to post comment to %s/%s: %v", mContext.Owner, mContext.Repo, err)
+		return h.reportError(b, ctx, err)
+	}
+	posted.ChatID, posted.MessageID, posted.UserID = ctx.EffectiveChat.Id, msg.MessageId, ctx.EffectiveUser.Id
+	if err := h.DB.SavePostedComment(context.Background(), posted); err != nil {
+		log.Printf("Failed to store posted comment %d in %s/%s: %v", posted.CommentID, posted.Owner, posted.Repo, err)
+	}
+
+	if !h.confirmations(ctx) {
 		return nil
 	}
+	_, err = msg.Reply(b, h.t(ctx, "comment.posted", url), &gotgbot.SendMessageOpts{
+		ParseMode:           "HTML",
+		DisableNotification: true,
+		LinkPreviewOptions:  &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	})
+	return err
+}
 
-	if mContext.Type == "pr_review_comment" && mContext.CommentID != 0 {
+// commentable reports whether replies to a notification can be posted as comments
+func commentable(m models.MessageContext) bool {
+	return m.IssueNumber != 0 || m.DiscussionID != "" || m.CommitSHA != "" || len(m.Commits) > 0
+}
+
+// createComment posts a comment on what a notification is about and returns it with its URL
+func createComment(client *gh.Client, mContext models.MessageContext, body string) (models.PostedComment, string, error) {
+	bg := context.Background()
+	posted := models.PostedComment{Owner: mContext.Owner, Repo: mContext.Repo}
+	var url string
+	var err error
+	switch {
+	case mContext.DiscussionID != "":
+		// Replies to a discussion comment are threaded under it
+		var created *github.DiscussionComment
+		created, err = github.AddDiscussionComment(bg, client, mContext.DiscussionID, mContext.CommentNodeID, body)
+		if created != nil {
+			posted.NodeID, url = created.ID, created.URL
+		}
+	case mContext.CommitSHA != "":
+		var created *gh.RepositoryComment
+		created, _, err = client.Repositories.CreateComment(bg, mContext.Owner, mContext.Repo, mContext.CommitSHA, &gh.RepositoryComment{Body: &body})
+		posted.CommentID, posted.Commit, url = created.GetID(), true, created.GetHTMLURL()
+	case mContext.Type == "pr_review_comment" && mContext.CommentID != 0:
 		comment := &gh.PullRequestComment{
-			Body:      &commentBody,
+			Body:      &body,
 			InReplyTo: &mContext.CommentID,
 		}
-		_, _, err = client.PullRequests.CreateComment(context.Background(), mContext.Owner, mContext.Repo, mContext.IssueNumber, comment)
+		var created *gh.PullRequestComment
+		created, _, err = client.PullRequests.CreateComment(bg, mContext.Owner, mContext.Repo, mContext.IssueNumber, comment)
+		posted.CommentID, posted.Review, url = created.GetID(), true, created.GetHTMLURL()
+	default:
+		comment := &gh.IssueComment{Body: &body}
+		var created *gh.IssueComment
+		created, _, err = client.Issues.CreateComment(bg, mContext.Owner, mContext.Repo, mContext.IssueNumber, comment)
+		posted.CommentID, url = created.GetID(), created.GetHTMLURL()
+	}
+	return posted, url, err
+}
+
+// confirmations reports whether the chat wants posted comments confirmed
+func (h *ReplyHandler) confirmations(ctx *ext.Context) bool {
+	settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	return !settings.NoReplyConfirm
+}
+
+// handleEdit updates the GitHub comment created from an edited Telegram reply
+func (h *ReplyHandler) handleEdit(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EditedMessage
+	posted, err := h.DB.GetPostedComment(context.Background(), ctx.EffectiveChat.Id, msg.MessageId)
+	if err != nil || posted.UserID != ctx.EffectiveUser.Id {
+		return nil
+	}
+
+	client, ok := h.userClient(b, ctx)
+	if !ok {
+		return nil
+	}
+
+	body := h.commentBody(msg)
+	if posted.NodeID != "" {
+		err = github.UpdateDiscussionComment(context.Background(), client, posted.NodeID, body)
+	} else if posted.Commit {
+		_, _, err = client.Repositories.UpdateComment(context.Background(), posted.Owner, posted.Repo, posted.CommentID, &gh.RepositoryComment{Body: &body})
+	} else if posted.Review {
+		_, _, err = client.PullRequests.EditComment(context.Background(), posted.Owner, posted.Repo, posted.CommentID, &gh.PullRequestComment{Body: &body})
 	} else {
-		comment := &gh.IssueComment{Body: &commentBody}
-		_, _, err = client.Issues.CreateComment(context.Background(), mContext.Owner, mContext.Repo, mContext.IssueNumber, comment)
+		_, _, err = client.Issues.EditComment(context.Background(), posted.Owner, posted.Repo, posted.CommentID, &gh.IssueComment{Body: &body})
+	}
+	if err != nil {
+		log.Printf("Failed to edit comment %d in %s/%s: %v", posted.CommentID, posted.Owner, posted.Repo, err)
+		return h.reportError(b, ctx, err)
+	}
+	return nil
+}
+
+// Delete removes the GitHub comment created from the replied Telegram message
+func (h *ReplyHandler) Delete(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	if msg.ReplyToMessage == nil {
+		_, err := msg.Reply(b, h.t(ctx, "comment.delete_usage"), nil)
+		return err
 	}
 
+	posted, err := h.DB.GetPostedComment(context.Background(), ctx.EffectiveChat.Id, msg.ReplyToMessage.MessageId)
 	if err != nil {
-		fmt.Printf("Failed to post comment to %s/%s#%d: %v\n", mContext.Owner, mContext.Repo, mContext.IssueNumber, err)
+		_, err := msg.Reply(b, h.t(ctx, "comment.not_posted"), nil)
+		return err
+	}
+	if posted.UserID != ctx.EffectiveUser.Id {
+		_, err := msg.Reply(b, h.t(ctx, "comment.not_yours"), nil)
+		return err
+	}
+
+	client, ok := h.userClient(b, ctx)
+	if !ok {
 		return nil
 	}
 
-	return nil
+	if posted.NodeID != "" {
+		err = github.DeleteDiscussionComment(context.Background(), client, posted.NodeID)
+	} else if posted.Commit {
+		_, err = client.Repositories.DeleteComment(context.Background(), posted.Owner, posted.Repo, posted.CommentID)
+	} else if posted.Review {
+		_, err = client.PullRequests.DeleteComment(context.Background(), posted.Owner, posted.Repo, posted.CommentID)
+	} else {
+		_, err = client.Issues.DeleteComment(context.Background(), posted.Owner, posted.Repo, posted.CommentID)
+	}
+	if err != nil {
+		_, err = msg.Reply(b, h.t(ctx, "comment.delete_failed", html.EscapeString(mergeErrorMessage(err))), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	if err := h.DB.DeletePostedComment(context.Background(), ctx.EffectiveChat.Id, msg.ReplyToMessage.MessageId); err != nil {
+		log.Printf("Failed to forget deleted comment %d in %s/%s: %v", posted.CommentID, posted.Owner, posted.Repo, err)
+	}
+	_, err = msg.Reply(b, h.t(ctx, "comment.deleted"), nil)
+	return err
+}
+
+// commentBody converts a Telegram message to GitHub Markdown. Mentions of users who connected
+// GitHub become @login mentions.
+func (h *ReplyHandler) commentBody(msg *gotgbot.Message) string {
+	var ids []int64
+	var usernames []string
+	for _, e := range msg.ParseEntityTypes(map[string]struct{}{"mention": {}, "text_mention": {}}) {
+		if e.Type == "text_mention" && e.User != nil {
+			ids = append(ids, e.User.Id)
+		} else if e.Type == "mention" {
+			usernames = append(usernames, strings.TrimPrefix(e.Text, "@"))
+		}
+	}
+
+	byID := map[int64]string{}
+	byUsername := map[string]string{}
+	if len(ids) > 0 || len(usernames) > 0 {
+		users, err := h.DB.FindTelegramUsers(context.Background(), ids, usernames)
+		if err != nil {
+			log.Printf("Failed to resolve mentions: %v", err)
+		}
+		for _, u := range users {
+			byID[u.ID] = u.GitHubUsername
+			if u.TelegramUsername != "" {
+				byUsername[strings.ToLower(u.TelegramUsername)] = u.GitHubUsername
+			}
+		}
+	}
+
+	return github.EntitiesToMarkdown(msg.Text, msg.Entities, func(e gotgbot.MessageEntity, text string) string {
+		if e.Type == "text_mention" && e.User != nil {
+			return byID[e.User.Id]
+		}
+		return byUsername[strings.ToLower(strings.TrimPrefix(text, "@"))]
+	})
+}
+
+// userClient returns a GitHub client with the sender's token, or false if they are not connected
+func (h *ReplyHandler) userClient(b *gotgbot.Bot, ctx *ext.Context) (*gh.Client, bool) {
+	_, client, err := h.connectedClient(ctx.EffectiveUser.Id)
+	switch {
+	case err == nil:
+		return client, true
+	case errors.Is(err, errNotConnected):
+		// Tell each user only once, replies are also used for plain conversation
+		if _, seen := h.hinted.Get(ctx.EffectiveUser.Id); !seen {
+			h.hinted.Set(ctx.EffectiveUser.Id, true, 30*24*time.Hour)
+			_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "comment.connect_hint"), &gotgbot.SendMessageOpts{DisableNotification: true})
+		}
+	case errors.Is(err, errBadToken):
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.auth_reconnect"), nil)
+	default:
+		log.Printf("Failed to create GitHub client: %v", err)
+	}
+	return nil, false
+}
+
+var (
+	errNotConnected = errors.New("github account not connected")
+	errBadToken     = errors.New("stored token cannot be decrypted")
+)
+
+// connectedClient returns a Telegram user's account and a GitHub client with their token
+func (h *ReplyHandler) connectedClient(telegramID int64) (*models.User, *gh.Client, error) {
+	user, err := h.DB.GetUserByTelegramID(context.Background(), telegramID)
+	if err != nil || user.EncryptedOAuthToken == "" {
+		return nil, nil, errNotConnected
+	}
+
+	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
+	if err != nil {
+		return nil, nil, errBadToken
+	}
+
+	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, client, nil
+}
+
+// reportError tells the user why GitHub rejected a comment
+func (h *ReplyHandler) reportError(b *gotgbot.Bot, ctx *ext.Context, err error) error {
+	key, args := commentError(err)
+	if key == "err.auth_failed" {
+		_ = h.DB.ClearUserToken(context.Background(), ctx.EffectiveUser.Id)
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, key, args...), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+	return err
+}
+
+// commentError returns the message key and arguments explaining a failed comment request
+func commentError(err error) (string, []interface{}) {
+	if _, ok := errors.AsType[*gh.RateLimitError](err); ok {
+		return "comment.err.rate_limited", nil
+	}
+	if _, ok := errors.AsType[*gh.AbuseRateLimitError](err); ok {
+		return "comment.err.rate_limited", nil
+	}
+
+	if gqlErr, ok := errors.AsType[*github.GraphQLError](err); ok {
+		msg := strings.ToLower(strings.Join(gqlErr.Messages, " "))
+		switch {
+		case strings.Contains(msg, "archived"):
+			return "comment.err.archived", nil
+		case strings.Contains(msg, "locked"):
+			return "comment.err.locked", nil
+		case strings.Contains(msg, "permission"):
+			return "comment.err.no_permission", nil
+		}
+	}
+
+	if errResp, ok := errors.AsType[*gh.ErrorResponse](err); ok {
+		msg := strings.ToLower(errResp.Message)
+		var status int
+		if errResp.Response != nil {
+			status = errResp.Response.StatusCode
+		}
+		switch {
+		case strings.Contains(msg, "archived"):
+			return "comment.err.archived", nil
+		case strings.Contains(msg, "locked"):
+			return "comment.err.locked", nil
+		case status == http.StatusUnauthorized:
+			return "err.auth_failed", nil
+		case status == http.StatusTooManyRequests || strings.Contains(msg, "rate limit"):
+			return "comment.err.rate_limited", nil
+		case status == http.StatusForbidden || status == http.StatusNotFound:
+			return "comment.err.no_permission", nil
+		}
+	}
+	return "comment.err.failed", []interface{}{html.EscapeString(mergeErrorMessage(err))}
+}
+
+// t translates a message into the language of the current chat
+func (h *ReplyHandler) t(ctx *ext.Context, key string, args ...interface{}) string {
+	settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	return i18n.T(i18n.Pick(settings.Language, ctx.EffectiveUser.LanguageCode), key, args...)
 }
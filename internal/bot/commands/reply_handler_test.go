@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestCommentError(t *testing.T) {
+	response := func(status int, message string) error {
+		return &github.ErrorResponse{Response: &http.Response{StatusCode: status}, Message: message}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"locked", response(http.StatusForbidden, "Unable to create comment because issue is locked."), "comment.err.locked"},
+		{"archived", response(http.StatusForbidden, "Repository was archived so is read-only."), "comment.err.archived"},
+		{"forbidden", response(http.StatusForbidden, "Resource not accessible by integration"), "comment.err.no_permission"},
+		{"not found", response(http.StatusNotFound, "Not Found"), "comment.err.no_permission"},
+		{"unauthorized", response(http.StatusUnauthorized, "Bad credentials"), "err.auth_failed"},
+		{"rate limit", &github.RateLimitError{Message: "API rate limit exceeded"}, "comment.err.rate_limited"},
+		{"secondary rate limit", fmt.Errorf("post: %w", &github.AbuseRateLimitError{Message: "slow down"}), "comment.err.rate_limited"},
+		{"too many requests", response(http.StatusTooManyRequests, "Too Many Requests"), "comment.err.rate_limited"},
+		{"locked discussion", &gh.GraphQLError{Messages: []string{"Discussion is locked"}}, "comment.err.locked"},
+		{"validation", response(http.StatusUnprocessableEntity, "Body is too long"), "comment.err.failed"},
+		{"network", errors.New("connection reset"), "comment.err.failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := commentError(tt.err); got != tt.want {
+				t.Errorf("commentError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, args := commentError(response(http.StatusUnprocessableEntity, "Body <too> long")); len(args) != 1 || args[0] != "Body &lt;too&gt; long" {
+		t.Errorf("commentError() args = %v, want the escaped GitHub message", args)
+	}
+}
+
+func TestCommitPickerButtons(t *testing.T) {
+	commits := []models.CommitRef{
+		{SHA: "aaaaaaaaaa", Title: "First"},
+		{SHA: "bbbbbbbbbb", Title: "A commit title that is much too long for one Telegram button"},
+	}
+
+	kb := commitPickerButtons("id", commits, "Cancel")
+	if len(kb) != 3 {
+		t.Fatalf("got %d rows, want 3", len(kb))
+	}
+	if b := kb[0][0]; b.Text != "bbbbbbb A commit title that is much too long fo…" || b.CallbackData != "cc:id:1" {
+		t.Errorf("newest commit button = %+v", b)
+	}
+	if b := kb[1][0]; b.Text != "aaaaaaa First" || b.CallbackData != "cc:id:0" {
+		t.Errorf("oldest commit button = %+v", b)
+	}
+	if b := kb[2][0]; b.CallbackData != "cc:id:x" {
+		t.Errorf("cancel button = %+v", b)
+	}
+}
@@ -0,0 +1,372 @@
+package commands
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github-webhook/internal/bot/callbacks"
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+	"github-webhook/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+const (
+	// repoPickerPageSize is the number of search results shown per page
+	repoPickerPageSize = 8
+	// maxRepoPickerPages caps how many pages of 100 repositories a search goes through
+	maxRepoPickerPages = 10
+)
+
+var (
+	errWebhookToken = errors.New("failed to encrypt the webhook token")
+	errSaveLink     = errors.New("failed to save the repository link")
+)
+
+// repoPicker is an /addrepo search with the matching repositories the user can admin
+type repoPicker struct {
+	UserID   int64
+	Query    string
+	Repos    []string // Full names of every match, in display order
+	Page     int
+	Multi    bool            // Taps select repositories instead of linking them
+	Selected map[string]bool // Repositories selected in multi-select mode
+	// Truncated marks a search that stopped at maxRepoPickerPages before going through every repository
+	Truncated bool
+}
+
+// searchRepos shows the repositories the user can admin whose name contains the query: /addrepo <name>
+func (h *CommandHandler) searchRepos(b *gotgbot.Bot, ctx *ext.Context, query string) error {
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	var repos []*github.Repository
+	truncated := false
+	opts := &github.RepositoryListByAuthenticatedUserOptions{
+		Affiliation: "owner,collaborator,organization_member",
+		Sort:        "updated",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for i := range maxRepoPickerPages {
+		page, resp, err := client.Repositories.ListByAuthenticatedUser(context.Background(), opts)
+		if err != nil {
+			if h.handleAuthError(b, ctx, err) {
+				return nil
+			}
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repo.fetch_failed"), nil)
+			return err
+		}
+		repos = append(repos, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		if i == maxRepoPickerPages-1 {
+			truncated = true
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	matches := matchRepos(repos, query)
+	if len(matches) == 0 {
+		text := h.t(ctx, "repo.search_empty", html.EscapeString(query))
+		if truncated {
+			text += "\n" + h.t(ctx, "repo.search_truncated", maxRepoPickerPages*100)
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	id, err := gh.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+
+	s := repoPicker{
+		UserID:    ctx.EffectiveUser.Id,
+		Query:     query,
+		Repos:     matches,
+		Page:      1,
+		Truncated: truncated,
+	}
+	return h.showRepoPicker(b, ctx, id, s, false, "")
+}
+
+// matchRepos returns the full names of the repositories the user can admin whose full name contains
+// the query, case-insensitively. Exact name matches come first, then names starting with the query;
+// the rest keep their order.
+func matchRepos(repos []*github.Repository, query string) []string {
+	q := strings.ToLower(query)
+	rank := func(r *github.Repository) int {
+		switch name := strings.ToLower(r.GetName()); {
+		case name == q:
+			return 0
+		case strings.HasPrefix(name, q):
+			return 1
+		}
+		return 2
+	}
+
+	var matches []*github.Repository
+	for _, r := range repos {
+		if r.GetPermissions().GetAdmin() && strings.Contains(strings.ToLower(r.GetFullName()), q) {
+			matches = append(matches, r)
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b *github.Repository) int { return cmp.Compare(rank(a), rank(b)) })
+
+	names := make([]string, len(matches))
+	for i, r := range matches {
+		names[i] = r.GetFullName()
+	}
+	return names
+}
+
+// showRepoPicker shows a page of search results, marking the repositories already linked to the chat
+// and those an organization link of the chat already covers.
+// A notice, such as the outcome of linking, is shown above the results.
+func (h *CommandHandler) showRepoPicker(b *gotgbot.Bot, ctx *ext.Context, id string, s repoPicker, edit bool, notice string) error {
+	linked := make(map[string]bool)
+	links, _ := h.DB.GetChatLinks(context.Background(), ctx.EffectiveChat.Id)
+	for _, l := range links {
+		linked[strings.ToLower(l.RepoFullName)] = true
+	}
+
+	pages := (len(s.Repos) + repoPickerPageSize - 1) / repoPickerPageSize
+	s.Page = min(max(s.Page, 1), pages)
+
+	text := h.t(ctx, "repo.search_header", html.EscapeString(s.Query), len(s.Repos), s.Page, pages)
+	if s.Truncated {
+		text += "\n" + h.t(ctx, "repo.search_truncated", maxRepoPickerPages*100)
+	}
+	if s.Multi {
+		text += "\n" + h.t(ctx, "repo.search_multi_hint")
+	}
+	if notice != "" {
+		text = notice + "\n\n" + text
+	}
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	start := (s.Page - 1) * repoPickerPageSize
+	for i := start; i < min(start+repoPickerPageSize, len(s.Repos)); i++ {
+		name := s.Repos[i]
+		switch {
+		case linked[strings.ToLower(name)]:
+			name = "✅ " + name
+		case orgCovers(links, name):
+			name = "🏢 " + name
+		case s.Multi && s.Selected[name]:
+			name = "☑️ " + name
+		case s.Multi:
+			name = "⬜ " + name
+		}
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: name, CallbackData: fmt.Sprintf("rp:%s:a:%d", id, i)}})
+	}
+
+	// The results are already in memory, so the pagination row gets a response describing them
+	resp := &github.Response{FirstPage: 1, LastPage: pages}
+	if s.Page > 1 {
+		resp.PrevPage = s.Page - 1
+	}
+	if s.Page < pages {
+		resp.NextPage = s.Page + 1
+	}
+	if nav := callbacks.PaginationRow(h.lang(ctx), resp, s.Page, func(page int) string { return fmt.Sprintf("rp:%s:pg:%d", id, page) }); len(nav) > 0 {
+		kb = append(kb, nav)
+	}
+
+	if s.Multi {
+		kb = append(kb, []gotgbot.InlineKeyboardButton{
+			{Text: h.t(ctx, "repo.search_link_selected", len(s.Selected)), CallbackData: fmt.Sprintf("rp:%s:go:0", id)},
+			{Text: h.t(ctx, "repo.search_single"), CallbackData: fmt.Sprintf("rp:%s:m:0", id)},
+		})
+	} else {
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "repo.search_select"), CallbackData: fmt.Sprintf("rp:%s:m:0", id)}})
+	}
+
+	h.repoPickers.Set(id, s, listTTL)
+	return h.replyOrEdit(b, ctx, edit, text, &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+}
+
+// orgCovers reports whether one of the chat's organization links already delivers the events of a
+// repository: it belongs to the organization and passes the link's patterns
+func orgCovers(links []models.RepoLink, fullName string) bool {
+	owner, repo, _ := strings.Cut(fullName, "/")
+	for _, l := range links {
+		org, _, _ := strings.Cut(l.RepoFullName, "/")
+		if l.Org && strings.EqualFold(org, owner) && gh.MatchesRepoPatterns(repo, l.Include, l.Exclude) {
+			return true
+		}
+	}
+	return false
+}
+
+// RepoPickerCallback handles the /addrepo search results: rp:<id>:<op>:<n>
+func (h *CommandHandler) RepoPickerCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 4 {
+		return nil
+	}
+
+	id := parts[1]
+	s, ok := h.repoPickers.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.expired"), ShowAlert: true})
+		return nil
+	}
+	if s.UserID != ctx.EffectiveUser.Id {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.not_yours"), ShowAlert: true})
+		return nil
+	}
+	// The user may have lost their admin rights since sending the command
+	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "admin.add_repo"), ShowAlert: true})
+		return nil
+	}
+
+	n, err := strconv.Atoi(parts[3])
+	if err != nil {
+		return nil
+	}
+
+	switch parts[2] {
+	case "pg":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		s.Page = n
+		return h.showRepoPicker(b, ctx, id, s, true, "")
+
+	case "m":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		s.Multi = !s.Multi
+		s.Selected = nil
+		return h.showRepoPicker(b, ctx, id, s, true, "")
+
+	case "a":
+		if n < 0 || n >= len(s.Repos) {
+			return nil
+		}
+		name := s.Repos[n]
+		if _, err := h.DB.GetRepoLink(context.Background(), ctx.EffectiveChat.Id, name); err == nil {
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "repo.search_already_linked", name), ShowAlert: true})
+			return nil
+		}
+		if s.Multi {
+			_, _ = ctx.CallbackQuery.Answer(b, nil)
+			if s.Selected[name] {
+				delete(s.Selected, name)
+			} else {
+				if s.Selected == nil {
+					s.Selected = make(map[string]bool)
+				}
+				s.Selected[name] = true
+			}
+			return h.showRepoPicker(b, ctx, id, s, true, "")
+		}
+		return h.linkPicked(b, ctx, id, s, []string{name})
+
+	case "go":
+		if len(s.Selected) == 0 {
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "repo.search_none_selected"), ShowAlert: true})
+			return nil
+		}
+		var names []string
+		for _, name := range s.Repos {
+			if s.Selected[name] {
+				names = append(names, name)
+			}
+		}
+		s.Multi, s.Selected = false, nil
+		return h.linkPicked(b, ctx, id, s, names)
+	}
+	return nil
+}
+
+// linkPicked links the repositories picked from the search results and shows the outcome of each
+// above the results
+func (h *CommandHandler) linkPicked(b *gotgbot.Bot, ctx *ext.Context, id string, s repoPicker, names []string) error {
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+	_, _ = ctx.CallbackQuery.Answer(b, nil)
+
+	var lines []string
+	for _, name := range names {
+		// Someone else may have linked it since the results were shown
+		if _, err := h.DB.GetRepoLink(context.Background(), ctx.EffectiveChat.Id, name); err == nil {
+			lines = append(lines, h.t(ctx, "repo.search_already_linked", html.EscapeString(name)))
+			continue
+		}
+
+		owner, repo, _ := strings.Cut(name, "/")
+		err := h.linkRepo(ctx, client, owner, repo)
+		if err == nil {
+			lines = append(lines, h.t(ctx, "repo.search_linked", html.EscapeString(name)))
+			continue
+		}
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+
+		reason := mergeErrorMessage(err)
+		if errResp, ok := errors.AsType[*github.ErrorResponse](err); ok && errResp.Response.StatusCode == http.StatusNotFound {
+			reason = h.t(ctx, "repo.search_no_admin")
+		} else {
+			log.Printf("Webhook creation failed for %s: %v", name, err)
+		}
+		lines = append(lines, h.t(ctx, "repo.search_failed", html.EscapeString(name), html.EscapeString(reason)))
+	}
+	return h.showRepoPicker(b, ctx, id, s, true, strings.Join(lines, "\n"))
+}
+
+// linkRepo creates a webhook delivering every supported event to the current chat and topic,
+// and links the repository to the chat
+func (h *CommandHandler) linkRepo(ctx *ext.Context, client *github.Client, owner, repo string) error {
+	config, err := h.webhookConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errWebhookToken, err)
+	}
+
+	var events []string
+	for _, e := range gh.SupportedEvents {
+		events = append(events, e.Name)
+	}
+
+	bg := context.Background()
+	hook, _, err := client.Repositories.CreateHook(bg, owner, repo, &github.Hook{
+		Name:   github.Ptr("web"),
+		Events: events,
+		Config: config,
+		Active: github.Ptr(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := models.RepoLink{
+		RepoFullName:  owner + "/" + repo,
+		WebhookID:     hook.GetID(),
+		CreatedBy:     ctx.EffectiveUser.Id,
+		CreatedByName: ctx.EffectiveUser.FirstName,
+		CreatedAt:     time.Now(),
+	}
+	if err := h.DB.AddRepoLink(bg, ctx.EffectiveChat.Id, link); err != nil {
+		return fmt.Errorf("%w: %v", errSaveLink, err)
+	}
+	return nil
+}
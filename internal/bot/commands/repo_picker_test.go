@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestMatchRepos(t *testing.T) {
+	repo := func(fullName string, admin bool) *github.Repository {
+		name := fullName[len("octo/"):]
+		return &github.Repository{
+			Name:        github.Ptr(name),
+			FullName:    github.Ptr(fullName),
+			Permissions: &github.RepositoryPermissions{Admin: github.Ptr(admin)},
+		}
+	}
+	repos := []*github.Repository{
+		repo("octo/web-api", true),
+		repo("octo/api-docs", true),
+		repo("octo/API", true),
+		repo("octo/api-client", false),
+		repo("octo/website", true),
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"api", []string{"octo/API", "octo/api-docs", "octo/web-api"}},
+		{"WEB", []string{"octo/web-api", "octo/website"}},
+		{"octo/", []string{"octo/web-api", "octo/api-docs", "octo/API", "octo/website"}},
+		{"missing", []string{}},
+	}
+
+	for _, tt := range tests {
+		if got := matchRepos(repos, tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchRepos(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestOrgCovers(t *testing.T) {
+	links := []models.RepoLink{
+		{RepoFullName: "octo/web-api"},
+		{RepoFullName: "Octo-Org/*", Org: true, Exclude: []string{"sandbox-*"}},
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"octo-org/api", true},
+		{"octo-org/sandbox-1", false},
+		{"octo/web-api", false},
+		{"other/api", false},
+	}
+
+	for _, tt := range tests {
+		if got := orgCovers(links, tt.name); got != tt.want {
+			t.Errorf("orgCovers(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
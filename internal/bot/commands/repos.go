@@ -0,0 +1,306 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github-webhook/internal/bot/callbacks"
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/i18n"
+	"github-webhook/internal/models"
+	"github-webhook/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// maxReposMessage keeps a /repos message under Telegram's 4096 character limit
+const maxReposMessage = 4000
+
+// removePicker is a /removerepo list with the links of the chat when it was sent
+type removePicker struct {
+	UserID int64
+	Names  []string
+}
+
+// linkHook is the webhook of a link as GitHub reports it
+type linkHook struct {
+	Hook *github.Hook
+	Err  error
+}
+
+// Repos lists the links of the chat with their topic, events, webhook health, creator and delivery mode
+func (h *CommandHandler) Repos(b *gotgbot.Bot, ctx *ext.Context) error {
+	bg := context.Background()
+	links, err := h.DB.GetChatLinks(bg, ctx.EffectiveChat.Id)
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repos.none"), nil)
+		return err
+	}
+
+	hooks := h.fetchLinkHooks(ctx, links)
+	settings, _ := h.DB.GetChatSettings(bg, ctx.EffectiveChat.Id)
+
+	text := h.t(ctx, "repos.title")
+	if digest := h.digestSummary(ctx, settings); digest != "" {
+		text += digest + "\n"
+	}
+
+	opts := &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}}
+	for i, l := range links {
+		entry := "\n" + h.linkOverview(ctx, l, hooks, settings) + "\n"
+		if i > 0 && len(text)+len(entry) > maxReposMessage {
+			if _, err := ctx.EffectiveMessage.Reply(b, text, opts); err != nil {
+				return err
+			}
+			text = ""
+		}
+		text += entry
+	}
+
+	_, err = ctx.EffectiveMessage.Reply(b, text, opts)
+	return err
+}
+
+// fetchLinkHooks fetches the webhooks of the links with the user's token, all at once.
+// Without a connected account the map is empty and the overview leaves the webhook details out.
+func (h *CommandHandler) fetchLinkHooks(ctx *ext.Context, links []models.RepoLink) map[string]linkHook {
+	hooks := make(map[string]linkHook)
+
+	user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id)
+	if err != nil || user.EncryptedOAuthToken == "" {
+		return hooks
+	}
+	token, err := utils.Decrypt(user.EncryptedOAuthToken, h.EncryptionKey)
+	if err != nil {
+		return hooks
+	}
+	client, err := h.ClientFactory.GetUserClient(context.Background(), token)
+	if err != nil {
+		return hooks
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, l := range links {
+		if l.WebhookID == 0 {
+			continue
+		}
+		wg.Go(func() {
+			hook, err := gh.GetLinkHook(context.Background(), client, l)
+			mu.Lock()
+			hooks[l.RepoFullName] = linkHook{Hook: hook, Err: err}
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return hooks
+}
+
+// linkOverview describes one link of the /repos overview
+func (h *CommandHandler) linkOverview(ctx *ext.Context, l models.RepoLink, hooks map[string]linkHook, settings models.ChatSettings) string {
+	icon := "📦"
+	if l.Org {
+		icon = "🏢"
+	}
+	lines := []string{fmt.Sprintf("%s <b>%s</b>", icon, html.EscapeString(l.RepoFullName))}
+
+	lh, fetched := hooks[l.RepoFullName]
+	switch {
+	case !fetched:
+		lines = append(lines, h.t(ctx, "repos.hook_connect"))
+	case lh.Err != nil:
+		if errResp, ok := errors.AsType[*github.ErrorResponse](lh.Err); ok && errResp.Response.StatusCode == http.StatusNotFound {
+			lines = append(lines, h.t(ctx, "repos.hook_missing"))
+		} else {
+			lines = append(lines, h.t(ctx, "repos.hook_unknown", html.EscapeString(mergeErrorMessage(lh.Err))))
+		}
+	default:
+		if topic, ok := gh.HookTopic(lh.Hook, h.EncryptionKey); ok && (topic != 0 || ctx.EffectiveChat.IsForum) {
+			if topic == 0 {
+				lines = append(lines, h.t(ctx, "repos.main_chat"))
+			} else {
+				lines = append(lines, h.t(ctx, "repos.topic", topic))
+			}
+		}
+		enabled, total := countLinkEvents(lh.Hook.Events, l.Org)
+		lines = append(lines, h.t(ctx, "repos.events", enabled, total)+" · "+h.hookHealth(ctx, lh.Hook))
+	}
+
+	if !l.CreatedAt.IsZero() {
+		date := l.CreatedAt
+		if loc, err := time.LoadLocation(settings.QuietHours.Timezone); err == nil {
+			date = date.In(loc)
+		}
+		lines = append(lines, h.t(ctx, "repos.linked_by", html.EscapeString(l.CreatedByName), date.Format("2006-01-02")))
+	}
+	if patterns := h.patternSummary(ctx, l); patterns != "" {
+		lines = append(lines, strings.Split(strings.TrimPrefix(patterns, "\n"), "\n")...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// countLinkEvents counts the events a link supports and how many of them its webhook delivers;
+// events the bot does not handle are left out
+func countLinkEvents(hookEvents []string, org bool) (enabled, total int) {
+	events := gh.LinkEvents(org)
+	if len(hookEvents) == 1 && hookEvents[0] == "*" {
+		return len(events), len(events)
+	}
+	for _, e := range events {
+		if slices.Contains(hookEvents, e.Name) {
+			enabled++
+		}
+	}
+	return enabled, len(events)
+}
+
+// hookHealth describes whether a webhook is active and how its last delivery went
+func (h *CommandHandler) hookHealth(ctx *ext.Context, hook *github.Hook) string {
+	if !hook.GetActive() {
+		return h.t(ctx, "repos.hook_inactive")
+	}
+	code, message, ok := gh.HookLastDelivery(hook)
+	switch {
+	case !ok:
+		return h.t(ctx, "repos.delivery_none")
+	case code == 0 || code >= 400:
+		detail := strings.TrimSpace(message)
+		if code != 0 {
+			detail = strings.TrimSpace(fmt.Sprintf("%d %s", code, message))
+		}
+		return h.t(ctx, "repos.delivery_failed", html.EscapeString(detail))
+	}
+	return h.t(ctx, "repos.delivery_ok", code)
+}
+
+// digestSummary describes the chat's CI grouping and push merging, which apply to every link,
+// empty when both are off
+func (h *CommandHandler) digestSummary(ctx *ext.Context, settings models.ChatSettings) string {
+	lang := h.lang(ctx)
+	var modes []string
+	if settings.CIAggregate {
+		modes = append(modes, i18n.T(lang, "notify.ci", i18n.T(lang, "notify.on")))
+		if settings.CIFinalOnly {
+			modes = append(modes, i18n.T(lang, "notify.ci_final", i18n.T(lang, "notify.on")))
+		}
+	}
+	if settings.PushWindow > 0 {
+		modes = append(modes, i18n.T(lang, "notify.push_window", callbacks.FormatWindow(lang, settings.PushWindow)))
+	}
+	if len(modes) == 0 {
+		return ""
+	}
+	return i18n.T(lang, "repos.digest") + "\n" + strings.Join(modes, "\n")
+}
+
+// sendRemovePicker lists the links of the chat as buttons: /removerepo without arguments
+func (h *CommandHandler) sendRemovePicker(b *gotgbot.Bot, ctx *ext.Context) error {
+	links, err := h.DB.GetChatLinks(context.Background(), ctx.EffectiveChat.Id)
+	if err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "repos.none"), nil)
+		return err
+	}
+
+	id, err := gh.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+
+	s := removePicker{UserID: ctx.EffectiveUser.Id}
+	for _, l := range links {
+		s.Names = append(s.Names, l.RepoFullName)
+	}
+	h.removePickers.Set(id, s, listTTL)
+	return h.showRemovePicker(b, ctx, id, s, false)
+}
+
+func (h *CommandHandler) showRemovePicker(b *gotgbot.Bot, ctx *ext.Context, id string, s removePicker, edit bool) error {
+	var kb [][]gotgbot.InlineKeyboardButton
+	for i, name := range s.Names {
+		kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: name, CallbackData: fmt.Sprintf("rm:%s:s:%d", id, i)}})
+	}
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: h.t(ctx, "remove.cancel"), CallbackData: fmt.Sprintf("rm:%s:x:0", id)}})
+	return h.replyOrEdit(b, ctx, edit, h.t(ctx, "remove.pick"), &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+}
+
+// RemovePickerCallback handles the /removerepo buttons: rm:<id>:<op>:<n>
+func (h *CommandHandler) RemovePickerCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 4 {
+		return nil
+	}
+
+	id := parts[1]
+	s, ok := h.removePickers.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.expired"), ShowAlert: true})
+		return nil
+	}
+	if s.UserID != ctx.EffectiveUser.Id {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.not_yours"), ShowAlert: true})
+		return nil
+	}
+	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "admin.remove_repo"), ShowAlert: true})
+		return nil
+	}
+
+	n, err := strconv.Atoi(parts[3])
+	if err != nil || n < 0 || n >= len(s.Names) {
+		return nil
+	}
+	name := s.Names[n]
+
+	switch parts[2] {
+	case "s":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		kb := [][]gotgbot.InlineKeyboardButton{{
+			{Text: h.t(ctx, "remove.confirm_yes"), CallbackData: fmt.Sprintf("rm:%s:y:%d", id, n)},
+			{Text: h.t(ctx, "remove.back"), CallbackData: fmt.Sprintf("rm:%s:b:0", id)},
+		}}
+		return h.replyOrEdit(b, ctx, true, h.t(ctx, "remove.confirm", html.EscapeString(name)), &gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb})
+
+	case "b":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		return h.showRemovePicker(b, ctx, id, s, true)
+
+	case "x":
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		h.removePickers.Delete(id)
+		return h.replyOrEdit(b, ctx, true, h.t(ctx, "remove.cancelled"), nil)
+
+	case "y":
+		link, err := h.DB.GetRepoLink(context.Background(), ctx.EffectiveChat.Id, name)
+		if err != nil {
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "remove.not_found"), ShowAlert: true})
+			return nil
+		}
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		h.removePickers.Delete(id)
+
+		warning, err := h.unlinkRepo(b, ctx, link)
+		if err != nil {
+			return h.replyOrEdit(b, ctx, true, h.t(ctx, "remove.db_error"), nil)
+		}
+		return h.replyOrEdit(b, ctx, true, h.t(ctx, "remove.done", html.EscapeString(name), warning), nil)
+	}
+	return nil
+}
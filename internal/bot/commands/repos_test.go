@@ -0,0 +1,30 @@
+package commands
+
+import "testing"
+
+func TestCountLinkEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		events      []string
+		org         bool
+		wantEnabled int
+	}{
+		{"wildcard", []string{"*"}, false, -1},
+		{"known", []string{"push", "issues"}, false, 2},
+		{"unknown ignored", []string{"push", "watch", "label", "check_run"}, false, 1},
+		{"org only on repo", []string{"push", "organization"}, false, 1},
+		{"org only on org", []string{"push", "organization"}, true, 2},
+		{"none", nil, false, 0},
+	}
+
+	for _, tt := range tests {
+		enabled, total := countLinkEvents(tt.events, tt.org)
+		want := tt.wantEnabled
+		if want < 0 {
+			want = total
+		}
+		if enabled != want {
+			t.Errorf("%s: enabled = %d, want %d", tt.name, enabled, want)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// Rerun re-runs the workflow run of the replied CI notification: /rerun [failed|all]
+func (h *CommandHandler) Rerun(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	target, ok := h.replyContext(b, ctx)
+	if !ok {
+		return nil
+	}
+	if target.RunID == 0 || target.Type != "workflow_run" {
+		_, err := msg.Reply(b, h.t(ctx, "rerun.not_run"), nil)
+		return err
+	}
+
+	action := gh.RunRerunFailed
+	if args := ctx.Args(); len(args) > 1 {
+		switch strings.ToLower(args[1]) {
+		case "failed":
+		case "all":
+			action = gh.RunRerunAll
+		default:
+			_, err := msg.Reply(b, h.t(ctx, "rerun.usage"), nil)
+			return err
+		}
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	run := models.RunActionContext{Owner: target.Owner, Repo: target.Repo, RunID: target.RunID, Attempt: target.RunAttempt}
+	if err := runAction(client, run, action); err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return nil
+		}
+		_, err = msg.Reply(b, h.t(ctx, "rerun.failed", mergeErrorMessage(err)), nil)
+		return err
+	}
+
+	h.markRunAction(b, ctx, msg.ReplyToMessage, run, action)
+	_, err = msg.Reply(b, h.t(ctx, "rerun.done."+action), nil)
+	return err
+}
+
+// RunCallback handles the buttons on failed workflow notifications: run:<action>:<id>
+func (h *CommandHandler) RunCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	action, id := parts[1], parts[2]
+	run, ok := h.RunActions.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "pr.action_expired"), ShowAlert: true})
+		return nil
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	if err := runAction(client, run, action); err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return nil
+		}
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "rerun.failed", mergeErrorMessage(err)), ShowAlert: true})
+		return nil
+	}
+	h.RunActions.Delete(id)
+
+	_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "rerun.done."+action)})
+	h.markRunAction(b, ctx, ctx.EffectiveMessage, run, action)
+	return nil
+}
+
+// runAction re-runs or cancels a workflow run with the user's token
+func runAction(client *github.Client, run models.RunActionContext, action string) error {
+	bg := context.Background()
+	var err error
+	switch action {
+	case gh.RunRerunFailed:
+		_, err = client.Actions.RerunFailedJobsByID(bg, run.Owner, run.Repo, run.RunID)
+	case gh.RunRerunAll:
+		_, err = client.Actions.RerunWorkflowByID(bg, run.Owner, run.Repo, run.RunID)
+	case gh.RunCancel:
+		_, err = client.Actions.CancelWorkflowRunByID(bg, run.Owner, run.Repo, run.RunID)
+	default:
+		err = fmt.Errorf("unknown run action %q", action)
+	}
+	return err
+}
+
+// markRunAction appends who re-ran or cancelled the run, and the new attempt number, to the notification.
+// The action buttons are dropped so the same attempt is not re-run twice; link buttons are kept.
+func (h *CommandHandler) markRunAction(b *gotgbot.Bot, ctx *ext.Context, notification *gotgbot.Message, run models.RunActionContext, action string) {
+	var line string
+	if action == gh.RunCancel {
+		line = h.t(ctx, "rerun.marked.c", ctx.EffectiveUser.FirstName)
+	} else {
+		line = h.t(ctx, "rerun.marked."+action, ctx.EffectiveUser.FirstName, max(run.Attempt, 1)+1)
+	}
+	markNotification(b, notification, line, true)
+}
+
+// markNotification appends a line to a notification. With dropActions set only its link buttons are kept.
+func markNotification(b *gotgbot.Bot, notification *gotgbot.Message, line string, dropActions bool) {
+	if notification == nil {
+		return
+	}
+	var kb [][]gotgbot.InlineKeyboardButton
+	if notification.ReplyMarkup != nil {
+		kb = notification.ReplyMarkup.InlineKeyboard
+		if dropActions {
+			kb = linkRows(notification.ReplyMarkup)
+		}
+	}
+	editNotification(b, notification, line, kb)
+}
+
+// editNotification appends a line to a notification and replaces its buttons
+func editNotification(b *gotgbot.Bot, notification *gotgbot.Message, line string, kb [][]gotgbot.InlineKeyboardButton) {
+	if notification == nil || notification.Text == "" {
+		return
+	}
+
+	// Entities are reused as is: the line is appended after them, so their UTF-16 offsets stay valid
+	_, _, err := notification.EditText(b, notification.Text+"\n\n"+line, &gotgbot.EditMessageTextOpts{
+		Entities:           notification.Entities,
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+		ReplyMarkup:        gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+	})
+	if err != nil {
+		log.Printf("Failed to update notification %d: %v", notification.MessageId, err)
+	}
+}
+
+// linkRows returns the link buttons of a notification, without its action buttons
+func linkRows(markup *gotgbot.InlineKeyboardMarkup) [][]gotgbot.InlineKeyboardButton {
+	if markup == nil {
+		return nil
+	}
+	var kb [][]gotgbot.InlineKeyboardButton
+	for _, row := range markup.InlineKeyboard {
+		var kept []gotgbot.InlineKeyboardButton
+		for _, btn := range row {
+			if btn.Url != "" {
+				kept = append(kept, btn)
+			}
+		}
+		if len(kept) > 0 {
+			kb = append(kb, kept)
+		}
+	}
+	return kb
+}
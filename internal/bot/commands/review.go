@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// reviewEvents maps the /review kinds to the review events of the REST API
+var reviewEvents = map[string]string{
+	"approve": "APPROVE",
+	"changes": "REQUEST_CHANGES",
+	"comment": "COMMENT",
+}
+
+// parseCommandBody splits "/cmd first rest..." into the lowercased first word and the
+// text after it, which may span several lines
+func parseCommandBody(text string) (string, string) {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return "", ""
+	}
+	rest := strings.TrimSpace(strings.TrimSpace(text)[len(fields[0]):])
+	return strings.ToLower(fields[1]), strings.TrimSpace(rest[len(fields[1]):])
+}
+
+// Review submits a review on the PR of the replied notification: /review approve|changes|comment <body>
+func (h *CommandHandler) Review(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	kind, body := parseCommandBody(msg.GetText())
+	event, ok := reviewEvents[kind]
+	// GitHub requires a body for change requests and comment reviews
+	if !ok || (event != "APPROVE" && body == "") {
+		_, err := msg.Reply(b, h.t(ctx, "review.usage"), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+	}
+
+	target, ok := h.replyContext(b, ctx)
+	if !ok {
+		return nil
+	}
+	if !strings.HasPrefix(target.Type, "pr") {
+		_, err := msg.Reply(b, h.t(ctx, "reply.pr_only"), nil)
+		return err
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	req := &github.PullRequestReviewRequest{Event: github.Ptr(event)}
+	if body != "" {
+		req.Body = github.Ptr(body)
+	}
+	review, _, err := client.PullRequests.CreateReview(context.Background(), target.Owner, target.Repo, target.IssueNumber, req)
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		_, _ = msg.Reply(b, h.t(ctx, "review.failed", html.EscapeString(mergeErrorMessage(err))), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return nil
+	}
+
+	_, err = msg.Reply(b, h.t(ctx, "review.done."+kind, html.EscapeString(review.GetHTMLURL()), target.IssueNumber), &gotgbot.SendMessageOpts{
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	})
+	return err
+}
+
+// Dismiss dismisses the caller's latest approval or change request on the PR of the replied notification: /dismiss <reason>
+func (h *CommandHandler) Dismiss(b *gotgbot.Bot, ctx *ext.Context) error {
+	msg := ctx.EffectiveMessage
+	_, reason, _ := strings.Cut(strings.TrimSpace(msg.GetText()), " ")
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		_, err := msg.Reply(b, h.t(ctx, "dismiss.usage"), nil)
+		return err
+	}
+
+	target, ok := h.replyContext(b, ctx)
+	if !ok {
+		return nil
+	}
+	if !strings.HasPrefix(target.Type, "pr") {
+		_, err := msg.Reply(b, h.t(ctx, "reply.pr_only"), nil)
+		return err
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	bg := context.Background()
+	me, _, err := client.Users.Get(bg, "")
+	if err != nil {
+		if h.handleAuthError(b, ctx, err) {
+			return nil
+		}
+		_, _ = msg.Reply(b, h.t(ctx, "dismiss.failed", html.EscapeString(err.Error())), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return nil
+	}
+
+	reviews, _, err := client.PullRequests.ListReviews(bg, target.Owner, target.Repo, target.IssueNumber, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		_, _ = msg.Reply(b, h.t(ctx, "dismiss.failed", html.EscapeString(mergeErrorMessage(err))), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return nil
+	}
+
+	review := latestDismissableReview(reviews, me.GetLogin())
+	if review == nil {
+		_, err = msg.Reply(b, h.t(ctx, "dismiss.none", target.IssueNumber), nil)
+		return err
+	}
+
+	req := &github.PullRequestReviewDismissalRequest{Message: github.Ptr(reason)}
+	dismissed, _, err := client.PullRequests.DismissReview(bg, target.Owner, target.Repo, target.IssueNumber, review.GetID(), req)
+	if err != nil {
+		_, _ = msg.Reply(b, h.t(ctx, "dismiss.failed", html.EscapeString(mergeErrorMessage(err))), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return nil
+	}
+
+	_, err = msg.Reply(b, h.t(ctx, "dismiss.done", html.EscapeString(dismissed.GetHTMLURL()), target.IssueNumber), &gotgbot.SendMessageOpts{
+		ParseMode:          "HTML",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+	})
+	return err
+}
+
+// latestDismissableReview returns the user's most recent approval or change request.
+// Comment reviews cannot be dismissed.
+func latestDismissableReview(reviews []*github.PullRequestReview, login string) *github.PullRequestReview {
+	for i := len(reviews) - 1; i >= 0; i-- {
+		r := reviews[i]
+		if !strings.EqualFold(r.GetUser().GetLogin(), login) {
+			continue
+		}
+		switch r.GetState() {
+		case "APPROVED", "CHANGES_REQUESTED":
+			return r
+		}
+	}
+	return nil
+}
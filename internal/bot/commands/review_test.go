@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestParseCommandBody(t *testing.T) {
+	tests := []struct {
+		text       string
+		kind, body string
+	}{
+		{"/review approve", "approve", ""},
+		{"/review@GithubBot Changes please fix the tests", "changes", "please fix the tests"},
+		{"/review comment\nfirst line\n\nsecond", "comment", "first line\n\nsecond"},
+		{"/review\napprove LGTM", "approve", "LGTM"},
+		{"/review", "", ""},
+	}
+
+	for _, tt := range tests {
+		kind, body := parseCommandBody(tt.text)
+		if kind != tt.kind || body != tt.body {
+			t.Errorf("parseCommandBody(%q) = %q, %q, want %q, %q", tt.text, kind, body, tt.kind, tt.body)
+		}
+	}
+}
+
+func TestLatestDismissableReview(t *testing.T) {
+	review := func(id int64, login, state string) *github.PullRequestReview {
+		return &github.PullRequestReview{ID: github.Ptr(id), User: &github.User{Login: github.Ptr(login)}, State: github.Ptr(state)}
+	}
+	reviews := []*github.PullRequestReview{
+		review(1, "octocat", "CHANGES_REQUESTED"),
+		review(2, "hubot", "APPROVED"),
+		review(3, "octocat", "APPROVED"),
+		review(4, "octocat", "COMMENTED"),
+	}
+
+	if got := latestDismissableReview(reviews, "OctoCat"); got.GetID() != 3 {
+		t.Errorf("latestDismissableReview() = %d, want 3", got.GetID())
+	}
+	if got := latestDismissableReview(reviews, "monalisa"); got != nil {
+		t.Errorf("latestDismissableReview() = %d, want nil", got.GetID())
+	}
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/i18n"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// AlertCallback handles the triage buttons on security alert notifications: sa:<op>:<id>
+func (h *CommandHandler) AlertCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	op, id := parts[1], parts[2]
+	alert, ok := h.AlertActions.Get(id)
+	if !ok {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "pr.action_expired"), ShowAlert: true})
+		return nil
+	}
+
+	notification := ctx.EffectiveMessage
+	switch op {
+	case gh.AlertDismissMenu:
+		h.setAlertButtons(b, ctx, notification, gh.AlertDismissButtons(id))
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		return nil
+	case gh.AlertBack:
+		h.setAlertButtons(b, ctx, notification, gh.AlertButtons(id, alert))
+		_, _ = ctx.CallbackQuery.Answer(b, nil)
+		return nil
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	// An issue in a public repository tells everyone about the alert before it is fixed
+	if op == gh.AlertTrack {
+		repo, _, err := client.Repositories.Get(context.Background(), alert.Owner, alert.Repo)
+		if err != nil {
+			if h.handleActionError(b, ctx, err) {
+				return nil
+			}
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "alert.failed", mergeErrorMessage(err)), ShowAlert: true})
+			return nil
+		}
+		if !repo.GetPrivate() {
+			h.setAlertButtons(b, ctx, notification, gh.AlertTrackPublicButtons(id))
+			_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "alert.public_warning"), ShowAlert: true})
+			return nil
+		}
+	}
+
+	line, err := h.triageAlert(ctx, client, &alert, op)
+	if err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return nil
+		}
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "alert.failed", mergeErrorMessage(err)), ShowAlert: true})
+		return nil
+	}
+	h.AlertActions.Set(id, alert, gh.AlertActionTTL)
+
+	_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: line})
+	editNotification(b, notification, line, h.alertRows(ctx, notification, gh.AlertButtons(id, alert)))
+	return nil
+}
+
+// triageAlert performs a triage operation with the user's token, updates the stored alert
+// and returns the line that records it on the notification
+func (h *CommandHandler) triageAlert(ctx *ext.Context, client *github.Client, alert *models.AlertActionContext, op string) (string, error) {
+	bg := context.Background()
+	name := ctx.EffectiveUser.FirstName
+
+	switch {
+	case op == gh.AlertTrack || op == gh.AlertTrackPublic:
+		issue, assignee, err := h.openTrackingIssue(ctx, client, *alert)
+		if err != nil {
+			return "", err
+		}
+		alert.Issue = issue.GetNumber()
+		if assignee != "" {
+			return h.t(ctx, "alert.tracked_assigned", name, issue.GetHTMLURL(), assignee), nil
+		}
+		return h.t(ctx, "alert.tracked", name, issue.GetHTMLURL()), nil
+	case op == gh.AlertReopen && alert.Kind == gh.AlertDependabot:
+		if _, _, err := client.Dependabot.UpdateAlert(bg, alert.Owner, alert.Repo, alert.Number, &github.DependabotAlertState{State: "open"}); err != nil {
+			return "", err
+		}
+	case op == gh.AlertReopen && alert.Kind == gh.AlertSecretScanning:
+		opts := &github.SecretScanningAlertUpdateOptions{State: "open"}
+		if _, _, err := client.SecretScanning.UpdateAlert(bg, alert.Owner, alert.Repo, int64(alert.Number), opts); err != nil {
+			return "", err
+		}
+	case alert.Kind == gh.AlertDependabot && gh.DependabotDismissReasons[op] != "":
+		state := &github.DependabotAlertState{State: "dismissed", DismissedReason: github.Ptr(gh.DependabotDismissReasons[op])}
+		if _, _, err := client.Dependabot.UpdateAlert(bg, alert.Owner, alert.Repo, alert.Number, state); err != nil {
+			return "", err
+		}
+		alert.Open = false
+		return h.t(ctx, "alert.dismissed."+op, name), nil
+	case alert.Kind == gh.AlertSecretScanning && gh.SecretScanningResolutions[op] != "":
+		opts := &github.SecretScanningAlertUpdateOptions{State: "resolved", Resolution: github.Ptr(gh.SecretScanningResolutions[op])}
+		if _, _, err := client.SecretScanning.UpdateAlert(bg, alert.Owner, alert.Repo, int64(alert.Number), opts); err != nil {
+			return "", err
+		}
+		alert.Open = false
+		return h.t(ctx, "alert.resolved."+op, name), nil
+	default:
+		return "", fmt.Errorf("unknown alert action %q", op)
+	}
+
+	alert.Open = true
+	return h.t(ctx, "alert.reopened", name), nil
+}
+
+// openTrackingIssue opens an issue that links to the alert, assigned to the user who asked for it.
+// The alert itself is assigned to them too where the API supports it; assignee is their login then.
+func (h *CommandHandler) openTrackingIssue(ctx *ext.Context, client *github.Client, alert models.AlertActionContext) (issue *github.Issue, assignee string, err error) {
+	req := &github.IssueRequest{
+		Title: github.Ptr(alert.Title),
+		Body:  github.Ptr(fmt.Sprintf("Tracks %s", alert.URL)),
+	}
+	var login string
+	if user, err := h.DB.GetUserByTelegramID(context.Background(), ctx.EffectiveUser.Id); err == nil && user.GitHubUsername != "" {
+		login = user.GitHubUsername
+		req.Assignees = &[]string{login}
+	}
+
+	issue, _, err = client.Issues.Create(context.Background(), alert.Owner, alert.Repo, req)
+	if err != nil || login == "" {
+		return issue, "", err
+	}
+
+	// The issue is open either way, so a failed assignment is only logged
+	assigned, err := gh.AssignAlert(context.Background(), client, alert, login)
+	if err != nil {
+		log.Printf("Failed to assign %s alert %d in %s/%s: %v", alert.Kind, alert.Number, alert.Owner, alert.Repo, err)
+	}
+	if !assigned {
+		return issue, "", nil
+	}
+	return issue, login, nil
+}
+
+// setAlertButtons swaps the triage buttons of an alert notification, keeping its link buttons
+func (h *CommandHandler) setAlertButtons(b *gotgbot.Bot, ctx *ext.Context, notification *gotgbot.Message, actions [][]gotgbot.InlineKeyboardButton) {
+	_, _, err := notification.EditReplyMarkup(b, &gotgbot.EditMessageReplyMarkupOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: h.alertRows(ctx, notification, actions)},
+	})
+	if err != nil {
+		log.Printf("Failed to update alert notification %d: %v", notification.MessageId, err)
+	}
+}
+
+// alertRows returns the link buttons of a notification followed by triage buttons,
+// translated like the notification itself
+func (h *CommandHandler) alertRows(ctx *ext.Context, notification *gotgbot.Message, actions [][]gotgbot.InlineKeyboardButton) [][]gotgbot.InlineKeyboardButton {
+	markup := &gotgbot.InlineKeyboardMarkup{InlineKeyboard: actions}
+	settings, _ := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	i18n.Localize(settings.Language, "", markup)
+	return append(linkRows(notification.ReplyMarkup), markup.InlineKeyboard...)
+}
@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+// Template manages the chat's custom notification templates
+func (h *CommandHandler) Template(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate && !utils.IsAdmin(b, ctx.EffectiveChat.Id, ctx.EffectiveUser.Id, h.AdminCache) {
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "admin.template"), nil)
+		return err
+	}
+
+	// The first line holds the subcommand and key, the rest is the template itself
+	head, body, _ := strings.Cut(ctx.EffectiveMessage.GetText(), "\n")
+	body = strings.TrimSpace(body)
+	args := strings.Fields(head)
+
+	if len(args) < 2 {
+		return h.listTemplates(b, ctx)
+	}
+
+	sub := strings.ToLower(args[1])
+	if len(args) < 3 {
+		_, err := ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.usage"), &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+		return err
+	}
+	key := strings.ToLower(args[2])
+
+	settings, err := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.load_settings"), nil)
+		return err
+	}
+
+	switch sub {
+	case "show":
+		tmpl, ok := settings.Templates[key]
+		if !ok {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.none", html.EscapeString(key)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.show", html.EscapeString(key), html.EscapeString(tmpl)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+
+	case "set":
+		if body == "" {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.body_missing"), nil)
+			return err
+		}
+		if _, err := previewTemplate(key, body); err != nil {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.invalid", html.EscapeString(err.Error())), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		if settings.Templates == nil {
+			settings.Templates = map[string]string{}
+		}
+		settings.Templates[key] = body
+		if err := h.DB.SetChatSettings(context.Background(), ctx.EffectiveChat.Id, settings); err != nil {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.save_failed"), nil)
+			return err
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.saved", html.EscapeString(key), html.EscapeString(key)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		return err
+
+	case "preview":
+		tmpl := body
+		if tmpl == "" {
+			var ok bool
+			if tmpl, ok = settings.Templates[key]; !ok {
+				_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.none", html.EscapeString(key)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+				return err
+			}
+		}
+		msg, err := previewTemplate(key, tmpl)
+		if err != nil {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.invalid", html.EscapeString(err.Error())), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+			return err
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "MarkdownV2", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+		if err != nil {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.rejected", html.EscapeString(err.Error())), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+		}
+		return err
+
+	case "reset":
+		if key == "all" {
+			settings.Templates = nil
+		} else {
+			if _, ok := settings.Templates[key]; !ok {
+				_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.none", html.EscapeString(key)), &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+				return err
+			}
+			delete(settings.Templates, key)
+		}
+		if err := h.DB.SetChatSettings(context.Background(), ctx.EffectiveChat.Id, settings); err != nil {
+			_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.save_settings"), nil)
+			return err
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.reset_done"), nil)
+		return err
+	}
+
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "template.usage"), &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+	return err
+}
+
+func (h *CommandHandler) listTemplates(b *gotgbot.Bot, ctx *ext.Context) error {
+	settings, err := h.DB.GetChatSettings(context.Background(), ctx.EffectiveChat.Id)
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.load_settings"), nil)
+		return err
+	}
+
+	msg := h.t(ctx, "template.usage")
+	if len(settings.Templates) > 0 {
+		keys := make([]string, 0, len(settings.Templates))
+		for k := range settings.Templates {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		msg += "\n\n" + h.t(ctx, "template.custom") + "\n"
+		for _, k := range keys {
+			msg += fmt.Sprintf("• <code>%s</code>\n", html.EscapeString(k))
+		}
+	}
+	msg += "\n\n" + h.t(ctx, "template.samples", "<code>"+strings.Join(gh.TemplateEvents, "</code>, <code>")+"</code>")
+
+	_, err = ctx.EffectiveMessage.Reply(b, msg, &gotgbot.SendMessageOpts{ParseMode: "HTML", LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true}})
+	return err
+}
+
+// previewTemplate renders a template against the sample payload for its event
+func previewTemplate(key string, tmpl string) (string, error) {
+	eventType, action := gh.ParseTemplateKey(key)
+	sample := gh.SampleEvent(eventType)
+	if sample == nil {
+		return "", fmt.Errorf("unknown event %q, expected one of: %s", eventType, strings.Join(gh.TemplateEvents, ", "))
+	}
+
+	data := gh.NewTemplateData(eventType, sample)
+	if action != "" {
+		data.Action = action
+	}
+	return gh.RenderTemplate(tmpl, data)
+}
@@ -0,0 +1,360 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	gh "github-webhook/internal/github"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/google/go-github/v89/github"
+)
+
+// Triage actions, also used in picker callback data
+const (
+	triageLabel     = "label"
+	triageUnlabel   = "unlabel"
+	triageAssign    = "assign"
+	triageUnassign  = "unassign"
+	triageMilestone = "milestone"
+)
+
+// maxPickerOptions caps the number of buttons in a triage picker
+const maxPickerOptions = 40
+
+// noMilestone is the argument that clears an issue's milestone
+const noMilestone = "none"
+
+// triagePicker is an inline keyboard of labels, users or milestones offered when a triage command has no argument
+type triagePicker struct {
+	UserID  int64
+	Target  models.MessageContext
+	Action  string
+	Options []pickerOption
+}
+
+type pickerOption struct {
+	Text  string
+	Value string
+}
+
+// Label adds labels to the issue or PR of the replied notification: /label bug,p1
+func (h *CommandHandler) Label(b *gotgbot.Bot, ctx *ext.Context) error {
+	return h.handleTriage(b, ctx, triageLabel)
+}
+
+// Unlabel removes labels from the issue or PR of the replied notification
+func (h *CommandHandler) Unlabel(b *gotgbot.Bot, ctx *ext.Context) error {
+	return h.handleTriage(b, ctx, triageUnlabel)
+}
+
+// Assign adds assignees to the issue or PR of the replied notification: /assign @user|me
+func (h *CommandHandler) Assign(b *gotgbot.Bot, ctx *ext.Context) error {
+	return h.handleTriage(b, ctx, triageAssign)
+}
+
+// Unassign removes assignees from the issue or PR of the replied notification
+func (h *CommandHandler) Unassign(b *gotgbot.Bot, ctx *ext.Context) error {
+	return h.handleTriage(b, ctx, triageUnassign)
+}
+
+// Milestone sets or clears the milestone of the issue or PR of the replied notification: /milestone v2.0|none
+func (h *CommandHandler) Milestone(b *gotgbot.Bot, ctx *ext.Context) error {
+	return h.handleTriage(b, ctx, triageMilestone)
+}
+
+// triageArgs splits a triage command's argument into values. Labels and milestones may
+// contain spaces and are separated by commas; users may also be separated by spaces.
+func triageArgs(action, text string) []string {
+	_, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return nil
+	}
+
+	switch action {
+	case triageMilestone:
+		return []string{arg}
+	case triageAssign, triageUnassign:
+		var users []string
+		for _, u := range strings.FieldsFunc(arg, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+			users = append(users, strings.TrimPrefix(u, "@"))
+		}
+		return users
+	}
+
+	var labels []string
+	for _, l := range strings.Split(arg, ",") {
+		if l = strings.TrimSpace(l); l != "" {
+			labels = append(labels, l)
+		}
+	}
+	return labels
+}
+
+func (h *CommandHandler) handleTriage(b *gotgbot.Bot, ctx *ext.Context, action string) error {
+	target, ok := h.issueReplyContext(b, ctx)
+	if !ok {
+		return nil
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	values := triageArgs(action, ctx.EffectiveMessage.GetText())
+	if len(values) == 0 {
+		return h.sendTriagePicker(b, ctx, client, target, action)
+	}
+
+	text := h.applyTriage(b, ctx, client, target, action, values)
+	if text == "" {
+		return nil
+	}
+	_, err = ctx.EffectiveMessage.Reply(b, text, &gotgbot.SendMessageOpts{ParseMode: "HTML"})
+	return err
+}
+
+// replyContext returns the GitHub context of the notification the command replies to,
+// telling the user when there is none
+func (h *CommandHandler) replyContext(b *gotgbot.Bot, ctx *ext.Context) (models.MessageContext, bool) {
+	msg := ctx.EffectiveMessage
+	if msg.ReplyToMessage == nil {
+		_, _ = msg.Reply(b, h.t(ctx, "reply.need_reply"), nil)
+		return models.MessageContext{}, false
+	}
+
+	key := fmt.Sprintf("%d:%d", ctx.EffectiveChat.Id, msg.ReplyToMessage.MessageId)
+	mContext, found := h.ContextCache.Get(key)
+	if !found {
+		_, _ = msg.Reply(b, h.t(ctx, "reply.no_context"), nil)
+		return models.MessageContext{}, false
+	}
+	return mContext, true
+}
+
+// isIssueContext reports whether a message context is about an issue or pull request. Push, commit
+// comment, discussion and workflow notifications have no issue number.
+func isIssueContext(c models.MessageContext) bool {
+	switch c.Type {
+	case "issue", "issue_comment", "pr", "pr_review", "pr_review_comment":
+		return c.IssueNumber != 0
+	}
+	return false
+}
+
+// issueReplyContext is replyContext for commands acting on an issue or pull request,
+// telling the user when the notification is about something else
+func (h *CommandHandler) issueReplyContext(b *gotgbot.Bot, ctx *ext.Context) (models.MessageContext, bool) {
+	target, ok := h.replyContext(b, ctx)
+	if !ok {
+		return target, false
+	}
+	if !isIssueContext(target) {
+		_, _ = ctx.EffectiveMessage.Reply(b, h.t(ctx, "reply.issue_only"), nil)
+		return models.MessageContext{}, false
+	}
+	return target, true
+}
+
+// applyTriage performs a triage action and returns the text to show.
+// An empty text means the error was already reported.
+func (h *CommandHandler) applyTriage(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, target models.MessageContext, action string, values []string) string {
+	bg := context.Background()
+	owner, repo, number := target.Owner, target.Repo, target.IssueNumber
+
+	var (
+		err  error
+		done string
+	)
+	switch action {
+	case triageLabel:
+		_, _, err = client.Issues.AddLabelsToIssue(bg, owner, repo, number, values)
+		done = h.t(ctx, "triage.labeled", html.EscapeString(strings.Join(values, ", ")), number)
+	case triageUnlabel:
+		for _, l := range values {
+			if _, err = client.Issues.RemoveLabelForIssue(bg, owner, repo, number, l); err != nil {
+				break
+			}
+		}
+		done = h.t(ctx, "triage.unlabeled", html.EscapeString(strings.Join(values, ", ")), number)
+	case triageAssign, triageUnassign:
+		users := make([]string, len(values))
+		for i, u := range values {
+			users[i] = u
+			if strings.EqualFold(u, "me") {
+				if caller, uErr := h.DB.GetUserByTelegramID(bg, ctx.EffectiveUser.Id); uErr == nil {
+					users[i] = caller.GitHubUsername
+				}
+			}
+		}
+		if action == triageAssign {
+			_, _, err = client.Issues.AddAssignees(bg, owner, repo, number, users)
+			done = h.t(ctx, "triage.assigned", html.EscapeString(strings.Join(users, ", ")), number)
+		} else {
+			_, _, err = client.Issues.RemoveAssignees(bg, owner, repo, number, users)
+			done = h.t(ctx, "triage.unassigned", html.EscapeString(strings.Join(users, ", ")), number)
+		}
+	case triageMilestone:
+		if strings.EqualFold(values[0], noMilestone) {
+			_, _, err = client.Issues.RemoveMilestone(bg, owner, repo, number)
+			done = h.t(ctx, "triage.milestone_cleared", number)
+			break
+		}
+		milestone, mErr := findMilestone(client, owner, repo, values[0])
+		if mErr != nil {
+			return h.t(ctx, "issue.milestone_not_found", html.EscapeString(values[0]), html.EscapeString(owner+"/"+repo))
+		}
+		_, _, err = client.Issues.Edit(bg, owner, repo, number, &github.IssueRequest{Milestone: &milestone})
+		done = h.t(ctx, "triage.milestoned", number, html.EscapeString(values[0]))
+	}
+
+	if err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return ""
+		}
+		return h.t(ctx, "err.failed", html.EscapeString(err.Error()))
+	}
+	return done
+}
+
+// triageOptions lists the choices for a triage picker
+func triageOptions(client *github.Client, target models.MessageContext, action string) ([]pickerOption, error) {
+	bg := context.Background()
+	owner, repo, number := target.Owner, target.Repo, target.IssueNumber
+	list := &github.ListOptions{PerPage: maxPickerOptions}
+
+	var options []pickerOption
+	switch action {
+	case triageLabel:
+		labels, _, err := client.Issues.ListLabels(bg, owner, repo, list)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range labels {
+			options = append(options, pickerOption{Text: l.GetName(), Value: l.GetName()})
+		}
+	case triageUnlabel:
+		labels, _, err := client.Issues.ListLabelsByIssue(bg, owner, repo, number, list)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range labels {
+			options = append(options, pickerOption{Text: "✖️ " + l.GetName(), Value: l.GetName()})
+		}
+	case triageAssign:
+		users, _, err := client.Repositories.ListCollaborators(bg, owner, repo, &github.ListCollaboratorsOptions{ListOptions: *list})
+		if err != nil {
+			// Listing collaborators needs push access; anyone who can triage can list assignable users
+			if users, _, err = client.Issues.ListAssignees(bg, owner, repo, list); err != nil {
+				return nil, err
+			}
+		}
+		for _, u := range users {
+			options = append(options, pickerOption{Text: u.GetLogin(), Value: u.GetLogin()})
+		}
+	case triageUnassign:
+		issue, _, err := client.Issues.Get(bg, owner, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range issue.Assignees {
+			options = append(options, pickerOption{Text: "✖️ " + u.GetLogin(), Value: u.GetLogin()})
+		}
+	case triageMilestone:
+		milestones, _, err := client.Issues.ListMilestones(bg, owner, repo, &github.MilestoneListOptions{State: "open", ListOptions: *list})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range milestones {
+			options = append(options, pickerOption{Text: m.GetTitle(), Value: m.GetTitle()})
+		}
+		if len(options) > 0 {
+			options = append(options, pickerOption{Text: "🚫", Value: noMilestone})
+		}
+	}
+
+	if len(options) > maxPickerOptions {
+		options = options[:maxPickerOptions]
+	}
+	return options, nil
+}
+
+func (h *CommandHandler) sendTriagePicker(b *gotgbot.Bot, ctx *ext.Context, client *github.Client, target models.MessageContext, action string) error {
+	options, err := triageOptions(client, target, action)
+	if err != nil {
+		if h.handleActionError(b, ctx, err) {
+			return nil
+		}
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "err.failed", err), nil)
+		return err
+	}
+	if len(options) == 0 {
+		_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "triage.nothing"), nil)
+		return err
+	}
+
+	id, err := gh.GenerateState()
+	if err != nil {
+		return err
+	}
+	id = id[:12]
+	h.triagePickers.Set(id, triagePicker{UserID: ctx.EffectiveUser.Id, Target: target, Action: action, Options: options}, 15*time.Minute)
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	for i, o := range options {
+		btn := gotgbot.InlineKeyboardButton{Text: o.Text, CallbackData: fmt.Sprintf("tr:%s:%d", id, i)}
+		if i%2 == 0 {
+			kb = append(kb, []gotgbot.InlineKeyboardButton{btn})
+		} else {
+			kb[len(kb)-1] = append(kb[len(kb)-1], btn)
+		}
+	}
+
+	_, err = ctx.EffectiveMessage.Reply(b, h.t(ctx, "triage.pick_"+action, target.IssueNumber), &gotgbot.SendMessageOpts{
+		ReplyMarkup: gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+	})
+	return err
+}
+
+// TriageCallback applies the option picked from a triage picker (tr:<id>:<index>)
+func (h *CommandHandler) TriageCallback(b *gotgbot.Bot, ctx *ext.Context) error {
+	parts := strings.Split(ctx.CallbackQuery.Data, ":")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	picker, ok := h.triagePickers.Get(parts[1])
+	idx, _ := strconv.Atoi(parts[2])
+	if !ok || idx < 0 || idx >= len(picker.Options) {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.expired"), ShowAlert: true})
+		return nil
+	}
+	if picker.UserID != ctx.EffectiveUser.Id {
+		_, _ = ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{Text: h.t(ctx, "picker.not_yours"), ShowAlert: true})
+		return nil
+	}
+
+	client, err := h.getAuthenticatedClient(b, ctx)
+	if err != nil {
+		return nil
+	}
+
+	text := h.applyTriage(b, ctx, client, picker.Target, picker.Action, []string{picker.Options[idx].Value})
+	if text == "" {
+		return nil
+	}
+	h.triagePickers.Delete(parts[1])
+
+	_, _ = ctx.CallbackQuery.Answer(b, nil)
+	_, _, err = ctx.EffectiveMessage.EditText(b, text, &gotgbot.EditMessageTextOpts{ParseMode: "HTML"})
+	return err
+}
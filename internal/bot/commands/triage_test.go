@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github-webhook/internal/models"
+)
+
+func TestTriageArgs(t *testing.T) {
+	tests := []struct {
+		action string
+		text   string
+		want   []string
+	}{
+		{triageLabel, "/label bug, p1 ,good first issue", []string{"bug", "p1", "good first issue"}},
+		{triageUnlabel, "/unlabel@GithubBot bug", []string{"bug"}},
+		{triageAssign, "/assign @octocat me,hubot", []string{"octocat", "me", "hubot"}},
+		{triageMilestone, "/milestone v2.0 beta", []string{"v2.0 beta"}},
+		{triageLabel, "/label", nil},
+		{triageAssign, "/assign   ", nil},
+	}
+
+	for _, tt := range tests {
+		if got := triageArgs(tt.action, tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("triageArgs(%q, %q) = %v, want %v", tt.action, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIsIssueContext(t *testing.T) {
+	tests := []struct {
+		ctx  models.MessageContext
+		want bool
+	}{
+		{models.MessageContext{Type: "issue", IssueNumber: 1}, true},
+		{models.MessageContext{Type: "pr_review_comment", IssueNumber: 2}, true},
+		{models.MessageContext{Type: "push"}, false},
+		{models.MessageContext{Type: "workflow_run", RunID: 9}, false},
+		{models.MessageContext{Type: "discussion", DiscussionID: "D_1"}, false},
+		{models.MessageContext{Type: "issue"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isIssueContext(tt.ctx); got != tt.want {
+			t.Errorf("isIssueContext(%+v) = %v, want %v", tt.ctx, got, tt.want)
+		}
+	}
+}
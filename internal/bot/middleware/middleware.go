@@ -2,7 +2,9 @@ package middleware
 
 import (
 	"context"
+	"time"
 
+	"github-webhook/internal/cache"
 	"github-webhook/internal/db"
 	"github-webhook/internal/models"
 
@@ -10,7 +12,12 @@ import (
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 )
 
+// usernameTTL is how long a user's last written @username is trusted. It expires so users who
+// connect GitHub after their username was seen still get it stored.
+const usernameTTL = time.Hour
+
 func TrackUserAndChat(database *db.DB) func(b *gotgbot.Bot, ctx *ext.Context) error {
+	usernames := cache.New[int64, string]() // Key: Telegram user ID
 	return func(b *gotgbot.Bot, ctx *ext.Context) error {
 		if ctx.EffectiveChat != nil {
 			chatType := ctx.EffectiveChat.Type
@@ -27,6 +34,16 @@ func TrackUserAndChat(database *db.DB) func(b *gotgbot.Bot, ctx *ext.Context) er
 				_ = database.UpsertChat(context.Background(), dbChat)
 			}()
 		}
+
+		// Keep the @username of connected users current so mentions of them can be resolved
+		if user := ctx.EffectiveUser; user != nil && !user.IsBot {
+			if known, ok := usernames.Get(user.Id); !ok || known != user.Username {
+				usernames.Set(user.Id, user.Username, usernameTTL)
+				go func() {
+					_ = database.SetTelegramUsername(context.Background(), user.Id, user.Username)
+				}()
+			}
+		}
 		return nil
 	}
 }
@@ -16,13 +16,19 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
+// PostedCommentTTL is how long replies posted as comments can still be edited or deleted from Telegram
+const PostedCommentTTL = 30 * 24 * time.Hour
+
 type DB struct {
 	Client   *mongo.Client
 	Database *mongo.Database
 	Users    *mongo.Collection
 	Chats    *mongo.Collection
+	Deferred *mongo.Collection // Notifications held back by quiet hours
+	Comments *mongo.Collection // GitHub comments posted from Telegram replies
 
-	ChatReposCache *cache.Cache[int64, []models.RepoLink]
+	ChatReposCache    *cache.Cache[int64, []models.RepoLink]
+	ChatSettingsCache *cache.Cache[int64, models.ChatSettings]
 }
 
 func Connect(cfg *config.Config) (*DB, error) {
@@ -35,11 +41,14 @@ func Connect(cfg *config.Config) (*DB, error) {
 	db := client.Database(cfg.DatabaseName)
 
 	d := &DB{
-		Client:         client,
-		Database:       db,
-		Users:          db.Collection("users"),
-		Chats:          db.Collection("chats"),
-		ChatReposCache: cache.New[int64, []models.RepoLink](),
+		Client:            client,
+		Database:          db,
+		Users:             db.Collection("users"),
+		Chats:             db.Collection("chats"),
+		Deferred:          db.Collection("deferred_messages"),
+		Comments:          db.Collection("posted_comments"),
+		ChatReposCache:    cache.New[int64, []models.RepoLink](),
+		ChatSettingsCache: cache.New[int64, models.ChatSettings](),
 	}
 
 	if err := d.createIndexes(); err != nil {
@@ -61,6 +70,27 @@ func (d *DB) createIndexes() error {
 		return err
 	}
 
+	_, err = d.Deferred.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "chat_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = d.Comments.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "chat_id", Value: 1}, {Key: "message_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "created_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(PostedCommentTTL.Seconds())),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -73,10 +103,16 @@ func (d *DB) GetUserByTelegramID(ctx context.Context, telegramID int64) (*models
 	return &user, nil
 }
 
+// UpsertUser stores the GitHub account and token of a user.
+// Only the account fields are written, so preferences such as no_mentions survive a reconnect.
 func (d *DB) UpsertUser(ctx context.Context, user *models.User) error {
 	opts := options.UpdateOne().SetUpsert(true)
 	filter := bson.M{"_id": user.ID}
-	update := bson.M{"$set": user}
+	update := bson.M{"$set": bson.M{
+		"github_user_id":        user.GitHubUserID,
+		"github_username":       user.GitHubUsername,
+		"encrypted_oauth_token": user.EncryptedOAuthToken,
+	}}
 	_, err := d.Users.UpdateOne(ctx, filter, update, opts)
 	return err
 }
@@ -88,6 +124,86 @@ func (d *DB) ClearUserToken(ctx context.Context, userID int64) error {
 	return err
 }
 
+// FindGitHubUsers returns the connected users with any of the given GitHub user IDs or logins.
+// Logins are compared case-insensitively.
+func (d *DB) FindGitHubUsers(ctx context.Context, ids []int64, logins []string) ([]models.User, error) {
+	var or bson.A
+	if len(ids) > 0 {
+		or = append(or, bson.M{"github_user_id": bson.M{"$in": ids}})
+	}
+	if len(logins) > 0 {
+		or = append(or, bson.M{"github_username": bson.M{"$in": logins}})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+
+	// Logged out users keep their record but must not be pinged or resolved
+	filter := bson.M{"$or": or, "encrypted_oauth_token": bson.M{"$ne": ""}}
+	opts := options.Find().SetCollation(&options.Collation{Locale: "en", Strength: 2})
+	cursor, err := d.Users.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SetUserMentions enables or disables Telegram mentions for a user
+func (d *DB) SetUserMentions(ctx context.Context, userID int64, enabled bool) error {
+	filter := bson.M{"_id": userID}
+	update := bson.M{"$set": bson.M{"no_mentions": !enabled}}
+	_, err := d.Users.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// SetTelegramUsername records the current Telegram @username of a connected user
+func (d *DB) SetTelegramUsername(ctx context.Context, userID int64, username string) error {
+	filter := bson.M{"_id": userID, "telegram_username": bson.M{"$ne": username}}
+	update := bson.M{"$set": bson.M{"telegram_username": username}}
+	_, err := d.Users.UpdateOne(ctx, filter, update)
+	return err
+}
+
+// FindTelegramUsers returns the connected users with any of the given Telegram IDs or @usernames.
+// Usernames are compared case-insensitively; users who logged out are left out.
+func (d *DB) FindTelegramUsers(ctx context.Context, ids []int64, usernames []string) ([]models.User, error) {
+	var or bson.A
+	if len(ids) > 0 {
+		or = append(or, bson.M{"_id": bson.M{"$in": ids}})
+	}
+	if len(usernames) > 0 {
+		or = append(or, bson.M{"telegram_username": bson.M{"$in": usernames}})
+	}
+	if len(or) == 0 {
+		return nil, nil
+	}
+
+	opts := options.Find().SetCollation(&options.Collation{Locale: "en", Strength: 2})
+	cursor, err := d.Users.Find(ctx, bson.M{"$or": or, "encrypted_oauth_token": bson.M{"$ne": ""}}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []models.User
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SetUserDMOff stores the personal notification reasons a user has turned off
+func (d *DB) SetUserDMOff(ctx context.Context, userID int64, reasons []string) error {
+	filter := bson.M{"_id": userID}
+	update := bson.M{"$set": bson.M{"dm_off": reasons}}
+	_, err := d.Users.UpdateOne(ctx, filter, update)
+	return err
+}
+
 func (d *DB) GetChat(ctx context.Context, chatID int64) (*models.Chat, error) {
 	var chat models.Chat
 	err := d.Chats.FindOne(ctx, bson.M{"_id": chatID}).Decode(&chat)
@@ -111,6 +227,35 @@ func (d *DB) UpsertChat(ctx context.Context, chat *models.Chat) error {
 	return err
 }
 
+// GetChatSettings returns the notification settings for a chat
+func (d *DB) GetChatSettings(ctx context.Context, chatID int64) (models.ChatSettings, error) {
+	if cached, ok := d.ChatSettingsCache.Get(chatID); ok {
+		return cached, nil
+	}
+
+	chat, err := d.GetChat(ctx, chatID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.ChatSettings{}, nil
+		}
+		return models.ChatSettings{}, err
+	}
+
+	d.ChatSettingsCache.Set(chatID, chat.Settings, 30*time.Minute)
+	return chat.Settings, nil
+}
+
+// SetChatSettings replaces the notification settings for a chat
+func (d *DB) SetChatSettings(ctx context.Context, chatID int64, settings models.ChatSettings) error {
+	opts := options.UpdateOne().SetUpsert(true)
+	filter := bson.M{"_id": chatID}
+	update := bson.M{"$set": bson.M{"settings": settings}}
+	_, err := d.Chats.UpdateOne(ctx, filter, update, opts)
+
+	d.ChatSettingsCache.Delete(chatID)
+	return err
+}
+
 // AddRepoLink adds a repository link to a chat
 func (d *DB) AddRepoLink(ctx context.Context, chatID int64, link models.RepoLink) error {
 	filter := bson.M{"_id": chatID}
@@ -229,3 +374,81 @@ func (d *DB) UpdateRepoLinkName(ctx context.Context, chatID int64, webhookID int
 
 	return nil
 }
+
+// AddDeferredMessage stores a notification held back by quiet hours
+func (d *DB) AddDeferredMessage(ctx context.Context, m models.DeferredMessage) error {
+	_, err := d.Deferred.InsertOne(ctx, m)
+	return err
+}
+
+// TakeDeferredMessages removes and returns the held back notifications of a chat, oldest first
+func (d *DB) TakeDeferredMessages(ctx context.Context, chatID int64) ([]models.DeferredMessage, error) {
+	cursor, err := d.Deferred.Find(ctx, bson.M{"chat_id": chatID}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []models.DeferredMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]bson.ObjectID, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+	}
+	if _, err := d.Deferred.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// DeferredChats returns the chats with held back notifications and when the earliest of them is due
+func (d *DB) DeferredChats(ctx context.Context) (map[int64]time.Time, error) {
+	cursor, err := d.Deferred.Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"chat_id": 1, "until": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []models.DeferredMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+
+	due := make(map[int64]time.Time)
+	for _, m := range messages {
+		if t, ok := due[m.ChatID]; !ok || m.Until.Before(t) {
+			due[m.ChatID] = m.Until
+		}
+	}
+	return due, nil
+}
+
+// SavePostedComment stores the GitHub comment created from a Telegram reply, replacing an earlier one for the same message
+func (d *DB) SavePostedComment(ctx context.Context, c models.PostedComment) error {
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	filter := bson.M{"chat_id": c.ChatID, "message_id": c.MessageID}
+	_, err := d.Comments.ReplaceOne(ctx, filter, c, options.Replace().SetUpsert(true))
+	return err
+}
+
+// GetPostedComment returns the GitHub comment created from a Telegram reply
+func (d *DB) GetPostedComment(ctx context.Context, chatID, messageID int64) (*models.PostedComment, error) {
+	var c models.PostedComment
+	err := d.Comments.FindOne(ctx, bson.M{"chat_id": chatID, "message_id": messageID}).Decode(&c)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// DeletePostedComment forgets the GitHub comment created from a Telegram reply
+func (d *DB) DeletePostedComment(ctx context.Context, chatID, messageID int64) error {
+	_, err := d.Comments.DeleteOne(ctx, bson.M{"chat_id": chatID, "message_id": messageID})
+	return err
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"time"
+
+	"github-webhook/internal/i18n"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/google/go-github/v89/github"
+)
+
+// Actions offered on failed workflow notifications, also used in callback data (run:<action>:<id>)
+const (
+	RunRerunFailed = "f"
+	RunRerunAll    = "a"
+	RunCancel      = "c"
+)
+
+// Decisions on deployments waiting for review, also used in callback data (dep:<state>:<id>).
+// They are the states the pending deployments API takes.
+const (
+	DeployApprove = "approved"
+	DeployReject  = "rejected"
+)
+
+// runActionTTL is how long the buttons on a CI notification keep working
+const runActionTTL = 48 * time.Hour
+
+// maxCIRunActions is the number of failed workflow runs offered for re-running on an aggregated CI message
+const maxCIRunActions = 3
+
+// FailedRun returns the workflow run behind a failed workflow_run or workflow_job notification.
+// running reports whether the run may still be in progress, which is the case for failed jobs.
+func FailedRun(event interface{}) (run models.RunActionContext, running bool, ok bool) {
+	switch e := event.(type) {
+	case *github.WorkflowRunEvent:
+		r := e.GetWorkflowRun()
+		if r.GetStatus() != "completed" || !ciFailed(r.GetConclusion()) {
+			return run, false, false
+		}
+		return models.RunActionContext{
+			Owner:   e.GetRepo().GetOwner().GetLogin(),
+			Repo:    e.GetRepo().GetName(),
+			RunID:   r.GetID(),
+			Attempt: r.GetRunAttempt(),
+		}, false, true
+	case *github.WorkflowJobEvent:
+		j := e.GetWorkflowJob()
+		if j.GetStatus() != "completed" || !ciFailed(j.GetConclusion()) || j.GetRunID() == 0 {
+			return run, false, false
+		}
+		return models.RunActionContext{
+			Owner:   e.GetRepo().GetOwner().GetLogin(),
+			Repo:    e.GetRepo().GetName(),
+			RunID:   j.GetRunID(),
+			Attempt: int(j.GetRunAttempt()),
+		}, true, true
+	}
+	return run, false, false
+}
+
+// RunActionButtons returns the re-run and cancel buttons for a stored run.
+// Cancel is only offered while the run may still be in progress.
+func RunActionButtons(id string, running bool) []gotgbot.InlineKeyboardButton {
+	row := []gotgbot.InlineKeyboardButton{
+		{Text: "🔁 Re-run failed", CallbackData: "run:" + RunRerunFailed + ":" + id},
+		{Text: "🔁 Re-run all", CallbackData: "run:" + RunRerunAll + ":" + id},
+	}
+	if running {
+		row = append(row, gotgbot.InlineKeyboardButton{Text: "⛔ Cancel", CallbackData: "run:" + RunCancel + ":" + id})
+	}
+	return row
+}
+
+// WaitingDeployment returns the workflow run behind a notification waiting for a deployment approval:
+// a requested deployment review, or a workflow run or job held by its environment's protection rules
+func WaitingDeployment(event interface{}) (models.RunActionContext, bool) {
+	switch e := event.(type) {
+	case *github.DeploymentReviewEvent:
+		if e.GetAction() != "requested" || e.GetWorkflowRun().GetID() == 0 {
+			break
+		}
+		return models.RunActionContext{
+			Owner:   e.GetRepo().GetOwner().GetLogin(),
+			Repo:    e.GetRepo().GetName(),
+			RunID:   e.GetWorkflowRun().GetID(),
+			Attempt: e.GetWorkflowRun().GetRunAttempt(),
+		}, true
+	case *github.WorkflowRunEvent:
+		r := e.GetWorkflowRun()
+		if r.GetStatus() != "waiting" || r.GetID() == 0 {
+			break
+		}
+		return models.RunActionContext{
+			Owner:   e.GetRepo().GetOwner().GetLogin(),
+			Repo:    e.GetRepo().GetName(),
+			RunID:   r.GetID(),
+			Attempt: r.GetRunAttempt(),
+		}, true
+	case *github.WorkflowJobEvent:
+		j := e.GetWorkflowJob()
+		if j.GetStatus() != "waiting" || j.GetRunID() == 0 {
+			break
+		}
+		return models.RunActionContext{
+			Owner:   e.GetRepo().GetOwner().GetLogin(),
+			Repo:    e.GetRepo().GetName(),
+			RunID:   j.GetRunID(),
+			Attempt: int(j.GetRunAttempt()),
+		}, true
+	}
+	return models.RunActionContext{}, false
+}
+
+// DeployButtons returns the approve and reject buttons for a stored run waiting on a deployment review
+func DeployButtons(id string) []gotgbot.InlineKeyboardButton {
+	return []gotgbot.InlineKeyboardButton{
+		{Text: "✅ Approve deploy", CallbackData: "dep:" + DeployApprove + ":" + id},
+		{Text: "❌ Reject", CallbackData: "dep:" + DeployReject + ":" + id},
+	}
+}
+
+// addRunActions stores the run of a failed workflow or pending deployment notification under a short ID
+// and adds its buttons, since run IDs and repository names do not fit in callback data
+func (s *WebhookServer) addRunActions(event interface{}, markup *gotgbot.InlineKeyboardMarkup) *gotgbot.InlineKeyboardMarkup {
+	run, running, failed := FailedRun(event)
+	if !failed {
+		var waiting bool
+		if run, waiting = WaitingDeployment(event); !waiting {
+			return markup
+		}
+	}
+
+	id, err := GenerateState()
+	if err != nil {
+		return markup
+	}
+	id = id[:12]
+	s.RunActions.Set(id, run, runActionTTL)
+
+	row := DeployButtons(id)
+	if failed {
+		row = RunActionButtons(id, running)
+	}
+	if markup == nil {
+		markup = &gotgbot.InlineKeyboardMarkup{}
+	}
+	markup.InlineKeyboard = append(markup.InlineKeyboard, row)
+	return markup
+}
+
+// addCIRunActions adds the re-run and cancel buttons of the failed workflow runs, and the deployment review
+// buttons of the waiting ones, to an aggregated CI message. The runs keep their short IDs across edits;
+// with several runs each row starts with its workflow's name.
+func (s *WebhookServer) addCIRunActions(c *ciCommit, done bool, lang string, markup *gotgbot.InlineKeyboardMarkup) *gotgbot.InlineKeyboardMarkup {
+	runs := c.actionRuns()
+	if len(runs) > maxCIRunActions {
+		runs = runs[:maxCIRunActions]
+	}
+
+	for _, r := range runs {
+		if r.ID == "" {
+			id, err := GenerateState()
+			if err != nil {
+				continue
+			}
+			r.ID = id[:12]
+		}
+		s.RunActions.Set(r.ID, r.Run, runActionTTL)
+
+		buttons := RunActionButtons(r.ID, r.Running && !done)
+		if r.Waiting {
+			buttons = DeployButtons(r.ID)
+		}
+		row := &gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{buttons}}
+		i18n.Localize(lang, "", row)
+		if len(runs) > 1 && r.Workflow != "" {
+			row.InlineKeyboard[0][0].Text = r.Workflow + ": " + row.InlineKeyboard[0][0].Text
+		}
+		if markup == nil {
+			markup = &gotgbot.InlineKeyboardMarkup{}
+		}
+		markup.InlineKeyboard = append(markup.InlineKeyboard, row.InlineKeyboard...)
+	}
+	return markup
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestFailedRun(t *testing.T) {
+	repo := &github.Repository{Name: github.Ptr("hello-world"), Owner: &github.User{Login: github.Ptr("octo-org")}}
+	runEvent := func(status, conclusion string) *github.WorkflowRunEvent {
+		return &github.WorkflowRunEvent{Repo: repo, WorkflowRun: &github.WorkflowRun{
+			ID: github.Ptr(int64(123456789012)), Status: github.Ptr(status), Conclusion: github.Ptr(conclusion), RunAttempt: github.Ptr(2),
+		}}
+	}
+
+	run, running, ok := FailedRun(runEvent("completed", "failure"))
+	if !ok || running || run.Owner != "octo-org" || run.Repo != "hello-world" || run.RunID != 123456789012 || run.Attempt != 2 {
+		t.Errorf("FailedRun(failed run) = %+v, %v, %v", run, running, ok)
+	}
+	for _, e := range []*github.WorkflowRunEvent{runEvent("completed", "success"), runEvent("in_progress", "")} {
+		if _, _, ok := FailedRun(e); ok {
+			t.Errorf("FailedRun(%s/%s) should not offer actions", e.WorkflowRun.GetStatus(), e.WorkflowRun.GetConclusion())
+		}
+	}
+
+	job := &github.WorkflowJobEvent{Repo: repo, WorkflowJob: &github.WorkflowJob{
+		RunID: github.Ptr(int64(42)), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), RunAttempt: github.Ptr(int64(1)),
+	}}
+	if run, running, ok := FailedRun(job); !ok || !running || run.RunID != 42 || run.Attempt != 1 {
+		t.Errorf("FailedRun(failed job) = %+v, %v, %v", run, running, ok)
+	}
+}
+
+func TestRunActionButtons(t *testing.T) {
+	id := strings.Repeat("f", 12)
+	if got := RunActionButtons(id, false); len(got) != 2 {
+		t.Errorf("finished runs should not offer Cancel: %+v", got)
+	}
+
+	buttons := RunActionButtons(id, true)
+	if len(buttons) != 3 || buttons[2].CallbackData != "run:"+RunCancel+":"+id {
+		t.Fatalf("RunActionButtons(running) = %+v", buttons)
+	}
+	for _, b := range buttons {
+		if len(b.CallbackData) > 64 {
+			t.Errorf("callback data %q exceeds Telegram's 64 bytes", b.CallbackData)
+		}
+	}
+}
+
+func TestWaitingDeployment(t *testing.T) {
+	repo := &github.Repository{Name: github.Ptr("hello-world"), Owner: &github.User{Login: github.Ptr("octo-org")}}
+	review := func(action string) *github.DeploymentReviewEvent {
+		return &github.DeploymentReviewEvent{Action: github.Ptr(action), Repo: repo, Environment: github.Ptr("production"),
+			WorkflowRun: &github.WorkflowRun{ID: github.Ptr(int64(77)), RunAttempt: github.Ptr(1)}}
+	}
+
+	run, ok := WaitingDeployment(review("requested"))
+	if !ok || run.Owner != "octo-org" || run.Repo != "hello-world" || run.RunID != 77 || run.Attempt != 1 {
+		t.Errorf("WaitingDeployment(requested) = %+v, %v", run, ok)
+	}
+	for _, action := range []string{"approved", "rejected"} {
+		if _, ok := WaitingDeployment(review(action)); ok {
+			t.Errorf("WaitingDeployment(%s) should not offer review buttons", action)
+		}
+	}
+
+	waitingRun := &github.WorkflowRunEvent{Repo: repo, WorkflowRun: &github.WorkflowRun{ID: github.Ptr(int64(78)), RunAttempt: github.Ptr(2), Status: github.Ptr("waiting")}}
+	if run, ok := WaitingDeployment(waitingRun); !ok || run.RunID != 78 || run.Attempt != 2 {
+		t.Errorf("WaitingDeployment(waiting run) = %+v, %v", run, ok)
+	}
+	waitingJob := &github.WorkflowJobEvent{Repo: repo, WorkflowJob: &github.WorkflowJob{ID: github.Ptr(int64(5)), RunID: github.Ptr(int64(79)), RunAttempt: github.Ptr(int64(1)), Status: github.Ptr("waiting")}}
+	if run, ok := WaitingDeployment(waitingJob); !ok || run.RunID != 79 {
+		t.Errorf("WaitingDeployment(waiting job) = %+v, %v", run, ok)
+	}
+	waitingJob.WorkflowJob.Status = github.Ptr("in_progress")
+	if _, ok := WaitingDeployment(waitingJob); ok {
+		t.Errorf("WaitingDeployment(running job) should not offer review buttons")
+	}
+
+	for _, b := range DeployButtons(strings.Repeat("f", 12)) {
+		if len(b.CallbackData) > 64 {
+			t.Errorf("callback data %q exceeds Telegram's 64 bytes", b.CallbackData)
+		}
+	}
+}
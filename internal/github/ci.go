@@ -0,0 +1,395 @@
+package github
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github-webhook/internal/cache"
+	"github-webhook/internal/i18n"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/google/go-github/v89/github"
+)
+
+// ciCheck is a single workflow, job, check or commit status tracked for a commit
+type ciCheck struct {
+	Name       string
+	Workflow   string // Parent workflow name for jobs, empty otherwise
+	Status     string // queued, in_progress, completed
+	Conclusion string
+	URL        string
+}
+
+// ciCommit collects every CI signal reported for one head SHA in one chat
+type ciCommit struct {
+	mu      sync.Mutex
+	Repo    string
+	RepoURL string
+	SHA     string
+	Branch  string
+	// DefaultBranch is the repository's default branch, where failed CI is reported as CIFailureEvent
+	DefaultBranch string
+	Title         string
+	MessageID     int64
+	// FailureAlerted is set once the commit's failure on the default branch was announced with sound
+	FailureAlerted bool
+	Checks         map[string]*ciCheck // Key: "run:<id>", "check:<id>", "suite:<id>" or "status:<context>"
+	Suites         map[int64]string    // Check suite ID -> status, as reported by check_suite and workflow_run events
+	Runs           map[int64]*ciRun    // Workflow run ID -> failed or deployment-waiting run, offered for action on the message
+}
+
+// ciRun is a failed or deployment-waiting workflow run of an aggregated commit
+type ciRun struct {
+	Workflow string
+	Run      models.RunActionContext
+	Running  bool   // Whether the run may still be in progress, as FailedRun reports it
+	Waiting  bool   // Waiting for a deployment review rather than failed
+	Job      int64  // Job waiting for the review, 0 when the run reported it
+	ID       string // Short ID of the run in RunActions, set once its buttons are shown
+}
+
+// CIAggregator merges CI events into a single live message per commit
+type CIAggregator struct {
+	mu      sync.Mutex
+	commits *cache.Cache[string, *ciCommit] // Key: "chat_id:repo:sha"
+}
+
+func NewCIAggregator() *CIAggregator {
+	return &CIAggregator{commits: cache.New[string, *ciCommit]()}
+}
+
+// isCIEvent reports whether the event is handled by the CI aggregator
+func isCIEvent(event interface{}) bool {
+	switch event.(type) {
+	case *github.WorkflowRunEvent, *github.WorkflowJobEvent, *github.CheckRunEvent, *github.CheckSuiteEvent, *github.StatusEvent:
+		return true
+	}
+	return false
+}
+
+// commitFor returns the locked commit state for an event, creating it if needed.
+// The caller must unlock the returned commit. Nil is returned for events that carry nothing to track.
+func (a *CIAggregator) commitFor(chatID int64, event interface{}) *ciCommit {
+	key, check, c := ciCheckFromEvent(event)
+	if c == nil || c.SHA == "" {
+		return nil
+	}
+	suiteID, suiteStatus := ciSuiteFromEvent(event)
+	if check == nil && suiteID == 0 {
+		return nil
+	}
+
+	cacheKey := fmt.Sprintf("%d:%s:%s", chatID, c.Repo, c.SHA)
+
+	a.mu.Lock()
+	commit, ok := a.commits.Get(cacheKey)
+	if !ok {
+		commit = c
+		commit.Checks = make(map[string]*ciCheck)
+		commit.Suites = make(map[int64]string)
+		commit.Runs = make(map[int64]*ciRun)
+	}
+	a.commits.Set(cacheKey, commit, 6*time.Hour)
+	a.mu.Unlock()
+
+	commit.mu.Lock()
+	if commit.Branch == "" {
+		commit.Branch = c.Branch
+	}
+	if commit.DefaultBranch == "" {
+		commit.DefaultBranch = c.DefaultBranch
+	}
+	if commit.Title == "" {
+		commit.Title = c.Title
+	}
+	if suiteID != 0 {
+		commit.Suites[suiteID] = suiteStatus
+	}
+	commit.trackRun(event)
+	if check == nil {
+		return commit
+	}
+	if prev, ok := commit.Checks[key]; ok && check.Workflow == "" {
+		check.Workflow = prev.Workflow
+	}
+	commit.Checks[key] = check
+	return commit
+}
+
+// trackRun records the failed or deployment-waiting workflow run behind an event, and forgets a run once it moves on:
+// a workflow_run event that is neither, or the waiting job starting after its deployment was reviewed
+func (c *ciCommit) trackRun(event interface{}) {
+	run, running, failed := FailedRun(event)
+	waiting := false
+	if !failed {
+		run, waiting = WaitingDeployment(event)
+	}
+
+	var job int64
+	switch e := event.(type) {
+	case *github.WorkflowRunEvent:
+		if !failed && !waiting {
+			delete(c.Runs, e.GetWorkflowRun().GetID())
+			return
+		}
+	case *github.WorkflowJobEvent:
+		job = e.GetWorkflowJob().GetID()
+		if !failed && !waiting {
+			if prev, ok := c.Runs[e.GetWorkflowJob().GetRunID()]; ok && prev.Waiting && prev.Job == job {
+				delete(c.Runs, e.GetWorkflowJob().GetRunID())
+			}
+			return
+		}
+	default:
+		return
+	}
+
+	if prev, ok := c.Runs[run.RunID]; ok && prev.Run.Attempt == run.Attempt && !prev.Waiting && !waiting {
+		// A job failing after its run was reported completed does not make the run running again
+		prev.Running = prev.Running && running
+		return
+	}
+	workflow := ""
+	switch e := event.(type) {
+	case *github.WorkflowRunEvent:
+		workflow = e.GetWorkflow().GetName()
+	case *github.WorkflowJobEvent:
+		workflow = e.GetWorkflowJob().GetWorkflowName()
+	}
+	r := &ciRun{Workflow: workflow, Run: run, Running: running, Waiting: waiting}
+	if waiting {
+		r.Job = job
+	}
+	if prev, ok := c.Runs[run.RunID]; ok && prev.Waiting == waiting {
+		r.ID = prev.ID
+	}
+	c.Runs[run.RunID] = r
+}
+
+// actionRuns returns the failed and deployment-waiting workflow runs of the commit, by workflow name
+func (c *ciCommit) actionRuns() []*ciRun {
+	runs := make([]*ciRun, 0, len(c.Runs))
+	for _, r := range c.Runs {
+		runs = append(runs, r)
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		if runs[i].Workflow != runs[j].Workflow {
+			return runs[i].Workflow < runs[j].Workflow
+		}
+		return runs[i].Run.RunID < runs[j].Run.RunID
+	})
+	return runs
+}
+
+// ciCheckFromEvent extracts the tracked check and its commit from a CI event.
+func ciCheckFromEvent(event interface{}) (string, *ciCheck, *ciCommit) {
+	switch e := event.(type) {
+	case *github.WorkflowRunEvent:
+		run := e.GetWorkflowRun()
+		return fmt.Sprintf("run:%d", run.GetID()),
+			&ciCheck{Name: e.GetWorkflow().GetName(), Status: run.GetStatus(), Conclusion: run.GetConclusion(), URL: run.GetHTMLURL()},
+			&ciCommit{Repo: e.GetRepo().GetFullName(), RepoURL: e.GetRepo().GetHTMLURL(), DefaultBranch: e.GetRepo().GetDefaultBranch(), SHA: run.GetHeadSHA(), Branch: run.GetHeadBranch(), Title: run.GetDisplayTitle()}
+	case *github.WorkflowJobEvent:
+		job := e.GetWorkflowJob()
+		// Actions jobs are also reported as check runs with the same ID
+		return fmt.Sprintf("check:%d", job.GetID()),
+			&ciCheck{Name: job.GetName(), Workflow: job.GetWorkflowName(), Status: job.GetStatus(), Conclusion: job.GetConclusion(), URL: job.GetHTMLURL()},
+			&ciCommit{Repo: e.GetRepo().GetFullName(), RepoURL: e.GetRepo().GetHTMLURL(), DefaultBranch: e.GetRepo().GetDefaultBranch(), SHA: job.GetHeadSHA(), Branch: job.GetHeadBranch()}
+	case *github.CheckRunEvent:
+		check := e.GetCheckRun()
+		return fmt.Sprintf("check:%d", check.GetID()),
+			&ciCheck{Name: check.GetName(), Status: check.GetStatus(), Conclusion: check.GetConclusion(), URL: check.GetHTMLURL()},
+			&ciCommit{Repo: e.GetRepo().GetFullName(), RepoURL: e.GetRepo().GetHTMLURL(), DefaultBranch: e.GetRepo().GetDefaultBranch(), SHA: check.GetHeadSHA(), Branch: check.GetCheckSuite().GetHeadBranch()}
+	case *github.CheckSuiteEvent:
+		suite := e.GetCheckSuite()
+		c := &ciCommit{Repo: e.GetRepo().GetFullName(), RepoURL: e.GetRepo().GetHTMLURL(), DefaultBranch: e.GetRepo().GetDefaultBranch(), SHA: suite.GetHeadSHA(), Branch: suite.GetHeadBranch()}
+		// GitHub Actions suites mirror workflow runs, which are tracked on their own; only their status counts
+		if suite.GetApp().GetSlug() == "github-actions" {
+			return "", nil, c
+		}
+		return fmt.Sprintf("suite:%d", suite.GetID()),
+			&ciCheck{Name: suite.GetApp().GetName(), Status: suite.GetStatus(), Conclusion: suite.GetConclusion(), URL: CheckSuiteURL(e.GetRepo(), suite)},
+			c
+	case *github.StatusEvent:
+		status, conclusion := "completed", e.GetState()
+		if conclusion == "pending" {
+			status, conclusion = "in_progress", ""
+		}
+		var branch string
+		if len(e.Branches) == 1 {
+			branch = e.Branches[0].GetName()
+		}
+		return "status:" + e.GetContext(),
+			&ciCheck{Name: e.GetContext(), Status: status, Conclusion: conclusion, URL: e.GetTargetURL()},
+			&ciCommit{Repo: e.GetRepo().GetFullName(), RepoURL: e.GetRepo().GetHTMLURL(), DefaultBranch: e.GetRepo().GetDefaultBranch(), SHA: e.GetSHA(), Branch: branch}
+	}
+	return "", nil, nil
+}
+
+// ciSuiteFromEvent returns the check suite an event reports the status of.
+// Check runs and jobs only carry their own status, so they report none.
+func ciSuiteFromEvent(event interface{}) (int64, string) {
+	switch e := event.(type) {
+	case *github.WorkflowRunEvent:
+		// Every workflow run is its own check suite
+		return e.GetWorkflowRun().GetCheckSuiteID(), e.GetWorkflowRun().GetStatus()
+	case *github.CheckSuiteEvent:
+		return e.GetCheckSuite().GetID(), e.GetCheckSuite().GetStatus()
+	}
+	return 0, ""
+}
+
+// CheckSuiteURL links to the checks page of a suite on GitHub; the suite itself only has an API URL
+func CheckSuiteURL(repo *github.Repository, suite *github.CheckSuite) string {
+	return fmt.Sprintf("%s/commit/%s/checks?check_suite_id=%d", repo.GetHTMLURL(), suite.GetHeadSHA(), suite.GetID())
+}
+
+// ciFailed reports whether a completed check should count as a failure
+func ciFailed(conclusion string) bool {
+	switch conclusion {
+	case "failure", "error", "timed_out", "cancelled", "action_required", "startup_failure":
+		return true
+	}
+	return false
+}
+
+func ciEmoji(c *ciCheck) string {
+	if c.Status != "completed" {
+		if c.Status == "queued" || c.Status == "waiting" || c.Status == "pending" || c.Status == "requested" {
+			return "🔄"
+		}
+		return "⏳"
+	}
+	switch {
+	case c.Conclusion == "success":
+		return "✅"
+	case c.Conclusion == "skipped" || c.Conclusion == "neutral":
+		return "⏭️"
+	case c.Conclusion == "cancelled":
+		return "⛔"
+	case ciFailed(c.Conclusion):
+		return "❌"
+	}
+	return "🏁"
+}
+
+// overall reports whether all checks are done and whether any of them failed.
+// A commit is only done once every known check suite completed, since a suite may still start checks
+// after the ones seen so far have finished.
+func (c *ciCommit) overall() (done bool, failed bool) {
+	done = true
+	for _, check := range c.Checks {
+		if check.Status != "completed" {
+			done = false
+		} else if ciFailed(check.Conclusion) {
+			failed = true
+		}
+	}
+	for _, status := range c.Suites {
+		if status != "completed" {
+			done = false
+		}
+	}
+	return done, failed
+}
+
+// failsDefaultBranch reports whether the commit is on the repository's default branch and its CI failed,
+// which makes it a CIFailureEvent whatever event triggered the update
+func (c *ciCommit) failsDefaultBranch() bool {
+	_, failed := c.overall()
+	return failed && c.Branch != "" && c.Branch == c.DefaultBranch
+}
+
+// render formats the aggregated CI message for a commit in the chat's language
+func (c *ciCommit) render(lang string) (string, *gotgbot.InlineKeyboardMarkup) {
+	shortSHA := c.SHA
+	if len(shortSHA) > 7 {
+		shortSHA = shortSHA[:7]
+	}
+	commitURL := fmt.Sprintf("%s/commit/%s", c.RepoURL, c.SHA)
+
+	msg := i18n.T(lang, "ci.title", EscapeMarkdownV2(shortSHA), EscapeMarkdownV2URL(commitURL))
+	if c.Branch != "" {
+		msg += i18n.T(lang, "ci.branch", EscapeMarkdownV2(c.Branch))
+	}
+	msg += "\n\n" + i18n.T(lang, "ci.repository", FormatRepo(c.Repo)) + "\n"
+	if c.Title != "" {
+		msg += i18n.T(lang, "ci.commit", EscapeMarkdownV2(c.Title)) + "\n"
+	}
+	msg += "\n"
+
+	// Group jobs under their workflow; everything else is listed at top level
+	jobs := map[string][]*ciCheck{}
+	var top []*ciCheck
+	for _, check := range c.Checks {
+		if check.Workflow != "" {
+			jobs[check.Workflow] = append(jobs[check.Workflow], check)
+		} else {
+			top = append(top, check)
+		}
+	}
+	for wf := range jobs {
+		found := false
+		for _, check := range top {
+			if check.Name == wf {
+				found = true
+				break
+			}
+		}
+		if !found {
+			// No workflow_run event seen yet, derive the workflow state from its jobs
+			group := &ciCommit{Checks: map[string]*ciCheck{}}
+			for i, job := range jobs[wf] {
+				group.Checks[fmt.Sprint(i)] = job
+			}
+			placeholder := &ciCheck{Name: wf, Status: "in_progress"}
+			if done, failed := group.overall(); done {
+				placeholder.Status, placeholder.Conclusion = "completed", "success"
+				if failed {
+					placeholder.Conclusion = "failure"
+				}
+			}
+			top = append(top, placeholder)
+		}
+	}
+
+	sort.Slice(top, func(i, j int) bool { return top[i].Name < top[j].Name })
+	var lines []string
+	for _, check := range top {
+		lines = append(lines, fmt.Sprintf("%s *%s*", ciEmoji(check), formatCheckName(check)))
+		group := jobs[check.Name]
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
+		for _, job := range group {
+			lines = append(lines, fmt.Sprintf("    %s %s", ciEmoji(job), formatCheckName(job)))
+		}
+	}
+
+	for i, line := range lines {
+		if len(msg)+len(line) > 3800 {
+			msg += i18n.T(lang, "ci.more", len(lines)-i) + "\n"
+			break
+		}
+		msg += line + "\n"
+	}
+
+	overall := "ci.passed"
+	switch done, failed := c.overall(); {
+	case !done:
+		overall = "ci.running"
+	case failed:
+		overall = "ci.failed"
+	}
+	msg += "\n" + i18n.T(lang, "ci.overall", i18n.T(lang, overall))
+
+	return FormatMessageWithButton(msg, i18n.T(lang, "ci.view_checks"), commitURL+"/checks")
+}
+
+func formatCheckName(c *ciCheck) string {
+	if c.URL == "" {
+		return EscapeMarkdownV2(c.Name)
+	}
+	return fmt.Sprintf("[%s](%s)", EscapeMarkdownV2(c.Name), EscapeMarkdownV2URL(c.URL))
+}
@@ -0,0 +1,248 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github-webhook/internal/cache"
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestCIAggregator(t *testing.T) {
+	repo := &github.Repository{FullName: github.Ptr("owner/repo"), HTMLURL: github.Ptr("https://github.com/owner/repo")}
+	sha := "0123456789abcdef"
+
+	job := func(id int64, name, status, conclusion string) *github.WorkflowJobEvent {
+		return &github.WorkflowJobEvent{
+			Repo: repo,
+			WorkflowJob: &github.WorkflowJob{
+				ID: github.Ptr(id), Name: github.Ptr(name), WorkflowName: github.Ptr("Build"),
+				HeadSHA: github.Ptr(sha), HeadBranch: github.Ptr("main"),
+				Status: github.Ptr(status), Conclusion: github.Ptr(conclusion),
+			},
+		}
+	}
+
+	a := NewCIAggregator()
+	events := []interface{}{
+		job(1, "lint", "in_progress", ""),
+		job(2, "test", "queued", ""),
+		job(1, "lint", "completed", "success"),
+	}
+	for _, e := range events {
+		c := a.commitFor(42, e)
+		if c == nil {
+			t.Fatalf("commitFor() returned nil")
+		}
+		c.mu.Unlock()
+	}
+
+	c := a.commitFor(42, job(2, "test", "completed", "failure"))
+	defer c.mu.Unlock()
+
+	if len(c.Checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(c.Checks))
+	}
+
+	done, failed := c.overall()
+	if !done || !failed {
+		t.Errorf("overall() = (%v, %v), want (true, true)", done, failed)
+	}
+
+	msg, markup := c.render("en")
+	for _, want := range []string{"`0123456`", "*on* `main`", "❌ *Build*", "✅ lint", "❌ test", "*Overall:* ❌ Failed"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("render() missing %q in:\n%s", want, msg)
+		}
+	}
+	if markup == nil {
+		t.Errorf("render() expected a View Checks button")
+	}
+
+	msg, markup = c.render("ru")
+	for _, want := range []string{"*Репозиторий:* ", "*Итог:* ❌"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("render(ru) missing %q in:\n%s", want, msg)
+		}
+	}
+	if markup == nil || markup.InlineKeyboard[0][0].Text != "Открыть проверки" {
+		t.Errorf("render(ru) expected a localized View Checks button")
+	}
+
+	if other := a.commitFor(43, job(3, "lint", "queued", "")); other == c {
+		t.Errorf("commits must be tracked per chat")
+	} else {
+		other.mu.Unlock()
+	}
+}
+
+func TestCIAggregatorWaitsForSuites(t *testing.T) {
+	repo := &github.Repository{FullName: github.Ptr("owner/repo"), HTMLURL: github.Ptr("https://github.com/owner/repo")}
+	sha := "0123456789abcdef"
+
+	suite := func(status string) *github.CheckSuiteEvent {
+		return &github.CheckSuiteEvent{
+			Repo: repo,
+			CheckSuite: &github.CheckSuite{
+				ID: github.Ptr(int64(7)), HeadSHA: github.Ptr(sha), Status: github.Ptr(status),
+				App: &github.App{Slug: github.Ptr("github-actions")},
+			},
+		}
+	}
+	job := &github.WorkflowJobEvent{
+		Repo: repo,
+		WorkflowJob: &github.WorkflowJob{
+			ID: github.Ptr(int64(1)), Name: github.Ptr("lint"), WorkflowName: github.Ptr("Build"),
+			HeadSHA: github.Ptr(sha), Status: github.Ptr("completed"), Conclusion: github.Ptr("success"),
+		},
+	}
+
+	a := NewCIAggregator()
+	c := a.commitFor(42, suite("in_progress"))
+	if c == nil {
+		t.Fatalf("commitFor() returned nil for a GitHub Actions suite")
+	}
+	if len(c.Checks) != 0 {
+		t.Errorf("GitHub Actions suites must not be listed as checks")
+	}
+	c.mu.Unlock()
+
+	// The first job finished, but the suite may still start more
+	c = a.commitFor(42, job)
+	if done, _ := c.overall(); done {
+		t.Errorf("overall() done while the check suite is still in progress")
+	}
+	c.mu.Unlock()
+
+	c = a.commitFor(42, suite("completed"))
+	defer c.mu.Unlock()
+	if done, _ := c.overall(); !done {
+		t.Errorf("overall() not done after the check suite completed")
+	}
+}
+
+func TestCIFailsDefaultBranch(t *testing.T) {
+	repo := &github.Repository{FullName: github.Ptr("owner/repo"), HTMLURL: github.Ptr("https://github.com/owner/repo"), DefaultBranch: github.Ptr("main")}
+	job := func(branch, status, conclusion string) *github.WorkflowJobEvent {
+		return &github.WorkflowJobEvent{
+			Repo: repo,
+			WorkflowJob: &github.WorkflowJob{
+				ID: github.Ptr(int64(1)), Name: github.Ptr("test"), WorkflowName: github.Ptr("Build"),
+				HeadSHA: github.Ptr("0123456789abcdef"), HeadBranch: github.Ptr(branch),
+				Status: github.Ptr(status), Conclusion: github.Ptr(conclusion),
+			},
+		}
+	}
+
+	a := NewCIAggregator()
+	c := a.commitFor(42, job("main", "queued", ""))
+	if c.failsDefaultBranch() {
+		t.Errorf("failsDefaultBranch() = true for a queued job")
+	}
+	c.mu.Unlock()
+
+	c = a.commitFor(42, job("main", "completed", "failure"))
+	if !c.failsDefaultBranch() {
+		t.Errorf("failsDefaultBranch() = false for a failed job on the default branch")
+	}
+	c.mu.Unlock()
+
+	c = a.commitFor(43, job("feature", "completed", "failure"))
+	defer c.mu.Unlock()
+	if c.failsDefaultBranch() {
+		t.Errorf("failsDefaultBranch() = true for a failed job on another branch")
+	}
+}
+
+func TestCIRunActions(t *testing.T) {
+	repo := &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo"), Owner: &github.User{Login: github.Ptr("owner")}}
+	sha := "0123456789abcdef"
+
+	job := func(runID int64, workflow, status, conclusion string) *github.WorkflowJobEvent {
+		return &github.WorkflowJobEvent{
+			Repo: repo,
+			WorkflowJob: &github.WorkflowJob{
+				ID: github.Ptr(runID * 10), RunID: github.Ptr(runID), RunAttempt: github.Ptr(int64(1)),
+				Name: github.Ptr("test"), WorkflowName: github.Ptr(workflow), HeadSHA: github.Ptr(sha),
+				Status: github.Ptr(status), Conclusion: github.Ptr(conclusion),
+			},
+		}
+	}
+
+	s := &WebhookServer{RunActions: cache.New[string, models.RunActionContext]()}
+	a := NewCIAggregator()
+
+	c := a.commitFor(42, job(1, "Build", "completed", "failure"))
+	markup := s.addCIRunActions(c, false, "en", nil)
+	if markup == nil || len(markup.InlineKeyboard) != 1 || len(markup.InlineKeyboard[0]) != 3 {
+		t.Fatalf("expected re-run and cancel buttons for a failed job of a running workflow, got %+v", markup)
+	}
+	id := strings.TrimPrefix(markup.InlineKeyboard[0][0].CallbackData, "run:"+RunRerunFailed+":")
+	if run, ok := s.RunActions.Get(id); !ok || run.RunID != 1 {
+		t.Errorf("run not stored under %q: %+v", id, run)
+	}
+	c.mu.Unlock()
+
+	c = a.commitFor(42, job(2, "Lint", "completed", "failure"))
+	markup = s.addCIRunActions(c, true, "es", nil)
+	if len(markup.InlineKeyboard) != 2 {
+		t.Fatalf("expected a row per failed run, got %+v", markup.InlineKeyboard)
+	}
+	if got := markup.InlineKeyboard[0]; len(got) != 2 || got[0].Text != "Build: 🔁 Reejecutar fallidos" || !strings.HasSuffix(got[0].CallbackData, id) {
+		t.Errorf("first row = %+v, want the Build run without cancel, under its first ID", got)
+	}
+	c.mu.Unlock()
+
+	rerun := &github.WorkflowRunEvent{
+		Repo:        repo,
+		Workflow:    &github.Workflow{Name: github.Ptr("Build")},
+		WorkflowRun: &github.WorkflowRun{ID: github.Ptr(int64(1)), HeadSHA: github.Ptr(sha), Status: github.Ptr("in_progress")},
+	}
+	c = a.commitFor(42, rerun)
+	defer c.mu.Unlock()
+	if runs := c.actionRuns(); len(runs) != 1 || runs[0].Workflow != "Lint" {
+		t.Errorf("re-run workflow should be forgotten, got %+v", runs)
+	}
+}
+
+func TestCIDeployActions(t *testing.T) {
+	repo := &github.Repository{Name: github.Ptr("repo"), FullName: github.Ptr("owner/repo"), Owner: &github.User{Login: github.Ptr("owner")}}
+	job := func(status string) *github.WorkflowJobEvent {
+		return &github.WorkflowJobEvent{
+			Repo: repo,
+			WorkflowJob: &github.WorkflowJob{
+				ID: github.Ptr(int64(5)), RunID: github.Ptr(int64(9)), RunAttempt: github.Ptr(int64(1)),
+				Name: github.Ptr("deploy"), WorkflowName: github.Ptr("Release"), HeadSHA: github.Ptr("0123456789abcdef"),
+				Status: github.Ptr(status),
+			},
+		}
+	}
+
+	s := &WebhookServer{RunActions: cache.New[string, models.RunActionContext]()}
+	a := NewCIAggregator()
+
+	c := a.commitFor(42, job("waiting"))
+	markup := s.addCIRunActions(c, false, "en", nil)
+	if markup == nil || len(markup.InlineKeyboard) != 1 || !strings.HasPrefix(markup.InlineKeyboard[0][0].CallbackData, "dep:"+DeployApprove+":") {
+		t.Fatalf("expected deployment review buttons for a waiting job, got %+v", markup)
+	}
+	c.mu.Unlock()
+
+	c = a.commitFor(42, job("in_progress"))
+	defer c.mu.Unlock()
+	if runs := c.actionRuns(); len(runs) != 0 {
+		t.Errorf("run should be forgotten once the waiting job starts, got %+v", runs)
+	}
+}
+
+func TestCheckSuiteURL(t *testing.T) {
+	repo := &github.Repository{HTMLURL: github.Ptr("https://github.com/owner/repo")}
+	suite := &github.CheckSuite{ID: github.Ptr(int64(7)), HeadSHA: github.Ptr("abc"), URL: github.Ptr("https://api.github.com/repos/owner/repo/check-suites/7")}
+
+	want := "https://github.com/owner/repo/commit/abc/checks?check_suite_id=7"
+	if got := CheckSuiteURL(repo, suite); got != want {
+		t.Errorf("CheckSuiteURL() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// DiscussionComment is a comment created through the GraphQL API
+type DiscussionComment struct {
+	ID  string // GraphQL node ID
+	URL string
+}
+
+const discussionThreadQuery = `query($id: ID!) {
+  node(id: $id) {
+    ... on DiscussionComment {
+      replyTo { id }
+    }
+  }
+}`
+
+const addDiscussionCommentMutation = `mutation($discussionId: ID!, $replyToId: ID, $body: String!) {
+  addDiscussionComment(input: {discussionId: $discussionId, replyToId: $replyToId, body: $body}) {
+    comment { id url }
+  }
+}`
+
+const updateDiscussionCommentMutation = `mutation($id: ID!, $body: String!) {
+  updateDiscussionComment(input: {commentId: $id, body: $body}) {
+    comment { id }
+  }
+}`
+
+const deleteDiscussionCommentMutation = `mutation($id: ID!) {
+  deleteDiscussionComment(input: {id: $id}) {
+    comment { id }
+  }
+}`
+
+const markDiscussionAnswerMutation = `mutation($id: ID!) {
+  markDiscussionCommentAsAnswer(input: {id: $id}) {
+    discussion { id }
+  }
+}`
+
+// AddDiscussionComment posts a comment on a discussion. With replyTo set it is threaded under that
+// comment; discussions only nest one level, so a reply to a reply goes to the same thread.
+func AddDiscussionComment(ctx context.Context, client *github.Client, discussionID, replyTo, body string) (*DiscussionComment, error) {
+	vars := map[string]interface{}{"discussionId": discussionID, "replyToId": nil, "body": body}
+	if replyTo != "" {
+		var thread struct {
+			Node struct {
+				ReplyTo *struct {
+					ID string `json:"id"`
+				} `json:"replyTo"`
+			} `json:"node"`
+		}
+		if err := GraphQL(ctx, client, discussionThreadQuery, map[string]interface{}{"id": replyTo}, &thread); err != nil {
+			return nil, err
+		}
+		if thread.Node.ReplyTo != nil {
+			replyTo = thread.Node.ReplyTo.ID
+		}
+		vars["replyToId"] = replyTo
+	}
+
+	var resp struct {
+		AddDiscussionComment struct {
+			Comment DiscussionComment `json:"comment"`
+		} `json:"addDiscussionComment"`
+	}
+	if err := GraphQL(ctx, client, addDiscussionCommentMutation, vars, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.AddDiscussionComment.Comment, nil
+}
+
+// UpdateDiscussionComment replaces the body of a discussion comment
+func UpdateDiscussionComment(ctx context.Context, client *github.Client, commentID, body string) error {
+	return GraphQL(ctx, client, updateDiscussionCommentMutation, map[string]interface{}{"id": commentID, "body": body}, nil)
+}
+
+// DeleteDiscussionComment deletes a discussion comment
+func DeleteDiscussionComment(ctx context.Context, client *github.Client, commentID string) error {
+	return GraphQL(ctx, client, deleteDiscussionCommentMutation, map[string]interface{}{"id": commentID}, nil)
+}
+
+// MarkDiscussionAnswer marks a comment as the answer of its discussion.
+// This only works in categories that accept answers.
+func MarkDiscussionAnswer(ctx context.Context, client *github.Client, commentID string) error {
+	return GraphQL(ctx, client, markDiscussionAnswerMutation, map[string]interface{}{"id": commentID}, nil)
+}
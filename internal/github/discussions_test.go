@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAddDiscussionComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		replyTo     string
+		parent      string // replyTo of the replied comment, as GitHub reports it
+		wantReplyTo interface{}
+	}{
+		{name: "top level", wantReplyTo: nil},
+		{name: "reply to a top-level comment", replyTo: "DC_1", wantReplyTo: "DC_1"},
+		{name: "reply to a reply joins its thread", replyTo: "DC_2", parent: "DC_1", wantReplyTo: "DC_1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutations int
+			client := newGraphQLTestClient(t, func(query string, vars map[string]interface{}) string {
+				if strings.Contains(query, "node(id: $id)") {
+					if vars["id"] != tt.replyTo {
+						t.Errorf("thread lookup for %v, want %s", vars["id"], tt.replyTo)
+					}
+					if tt.parent == "" {
+						return `{"data":{"node":{"replyTo":null}}}`
+					}
+					return `{"data":{"node":{"replyTo":{"id":"` + tt.parent + `"}}}}`
+				}
+
+				mutations++
+				if !strings.Contains(query, "addDiscussionComment") {
+					t.Errorf("unexpected query %s", query)
+				}
+				if vars["discussionId"] != "D_1" || vars["body"] != "Thanks!" || vars["replyToId"] != tt.wantReplyTo {
+					t.Errorf("unexpected variables %v", vars)
+				}
+				return `{"data":{"addDiscussionComment":{"comment":{"id":"DC_9","url":"https://github.com/octo/app/discussions/3#discussioncomment-9"}}}}`
+			})
+
+			c, err := AddDiscussionComment(context.Background(), client, "D_1", tt.replyTo, "Thanks!")
+			if err != nil {
+				t.Fatalf("AddDiscussionComment() error = %v", err)
+			}
+			if mutations != 1 || c.ID != "DC_9" || c.URL != "https://github.com/octo/app/discussions/3#discussioncomment-9" {
+				t.Errorf("AddDiscussionComment() = %+v after %d mutations", c, mutations)
+			}
+		})
+	}
+}
+
+func TestMarkDiscussionAnswer(t *testing.T) {
+	client := newGraphQLTestClient(t, func(query string, vars map[string]interface{}) string {
+		if !strings.Contains(query, "markDiscussionCommentAsAnswer") || vars["id"] != "DC_1" {
+			t.Errorf("unexpected request %s %v", query, vars)
+		}
+		return `{"data":null,"errors":[{"message":"Discussion category does not accept answers"}]}`
+	})
+
+	err := MarkDiscussionAnswer(context.Background(), client, "DC_1")
+	if gqlErr, ok := err.(*GraphQLError); !ok || gqlErr.Messages[0] != "Discussion category does not accept answers" {
+		t.Errorf("MarkDiscussionAnswer() error = %v, want GraphQLError", err)
+	}
+}
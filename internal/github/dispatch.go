@@ -0,0 +1,124 @@
+package github
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Types of workflow_dispatch inputs
+const (
+	InputString      = "string"
+	InputChoice      = "choice"
+	InputBoolean     = "boolean"
+	InputNumber      = "number"
+	InputEnvironment = "environment"
+)
+
+// DispatchInput is an input declared by a workflow's workflow_dispatch trigger
+type DispatchInput struct {
+	Name        string
+	Description string
+	Type        string
+	Required    bool
+	Default     string
+	Options     []string // Values of choice inputs
+}
+
+// ParseDispatchInputs reports whether workflow YAML has a workflow_dispatch trigger
+// and returns its inputs in the order they are declared
+func ParseDispatchInputs(data []byte) ([]DispatchInput, bool, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, false, fmt.Errorf("workflow is not a mapping")
+	}
+
+	// YAML 1.1 parsers read an unquoted "on" as true, so accept both spellings of the key
+	on := mappingValue(doc.Content[0], "on", "true")
+	if on == nil {
+		return nil, false, nil
+	}
+
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return nil, on.Value == "workflow_dispatch", nil
+	case yaml.SequenceNode:
+		for _, n := range on.Content {
+			if n.Value == "workflow_dispatch" {
+				return nil, true, nil
+			}
+		}
+		return nil, false, nil
+	case yaml.MappingNode:
+	default:
+		return nil, false, nil
+	}
+
+	var dispatch *yaml.Node
+	found := false
+	for i := 0; i+1 < len(on.Content); i += 2 {
+		if on.Content[i].Value == "workflow_dispatch" {
+			dispatch, found = on.Content[i+1], true
+			break
+		}
+	}
+	if !found {
+		return nil, false, nil
+	}
+
+	inputsNode := mappingValue(dispatch, "inputs")
+	if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+		return nil, true, nil
+	}
+
+	var inputs []DispatchInput
+	for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+		var decl struct {
+			Description string    `yaml:"description"`
+			Type        string    `yaml:"type"`
+			Required    bool      `yaml:"required"`
+			Default     yaml.Node `yaml:"default"`
+			Options     []string  `yaml:"options"`
+		}
+		if err := inputsNode.Content[i+1].Decode(&decl); err != nil {
+			return nil, true, fmt.Errorf("input %s: %w", inputsNode.Content[i].Value, err)
+		}
+
+		in := DispatchInput{
+			Name:        inputsNode.Content[i].Value,
+			Description: decl.Description,
+			Type:        decl.Type,
+			Required:    decl.Required,
+			Default:     decl.Default.Value,
+			Options:     decl.Options,
+		}
+		if in.Type == "" {
+			in.Type = InputString
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs, true, nil
+}
+
+// mappingValue returns the value of the first of keys found in a mapping node, or nil
+func mappingValue(m *yaml.Node, keys ...string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		for _, k := range keys {
+			if m.Content[i].Value == k {
+				return m.Content[i+1]
+			}
+		}
+	}
+	return nil
+}
+
+// RunStatusEmoji returns the emoji used for a workflow run's status in CI notifications
+func RunStatusEmoji(status, conclusion string) string {
+	return ciEmoji(&ciCheck{Status: status, Conclusion: conclusion})
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDispatchInputs(t *testing.T) {
+	workflow := `
+name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Where to deploy
+        type: choice
+        required: true
+        default: staging
+        options: [staging, production]
+      dry_run:
+        type: boolean
+        default: false
+      replicas:
+        type: number
+      note:
+        description: Free text
+jobs: {}
+`
+	inputs, ok, err := ParseDispatchInputs([]byte(workflow))
+	if err != nil || !ok {
+		t.Fatalf("ParseDispatchInputs() = %v, %v", ok, err)
+	}
+
+	want := []DispatchInput{
+		{Name: "environment", Description: "Where to deploy", Type: InputChoice, Required: true, Default: "staging", Options: []string{"staging", "production"}},
+		{Name: "dry_run", Type: InputBoolean, Default: "false"},
+		{Name: "replicas", Type: InputNumber},
+		{Name: "note", Description: "Free text", Type: InputString},
+	}
+	if !reflect.DeepEqual(inputs, want) {
+		t.Errorf("ParseDispatchInputs() = %+v, want %+v", inputs, want)
+	}
+}
+
+func TestParseDispatchTriggers(t *testing.T) {
+	tests := []struct {
+		yaml string
+		want bool
+	}{
+		{"on: workflow_dispatch", true},
+		{"on: [push, workflow_dispatch]", true},
+		{"on:\n  workflow_dispatch:\n", true},
+		{"true: workflow_dispatch", true},
+		{"on: push", false},
+		{"on:\n  pull_request:\n", false},
+		{"name: no triggers", false},
+	}
+
+	for _, tt := range tests {
+		inputs, ok, err := ParseDispatchInputs([]byte(tt.yaml))
+		if err != nil || ok != tt.want || len(inputs) != 0 {
+			t.Errorf("ParseDispatchInputs(%q) = %v, %v, %v, want %v", tt.yaml, inputs, ok, err, tt.want)
+		}
+	}
+
+	if _, _, err := ParseDispatchInputs([]byte("- not a mapping")); err == nil {
+		t.Error("expected an error for a workflow that is not a mapping")
+	}
+}
@@ -0,0 +1,238 @@
+package github
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// MentionResolver returns the GitHub login of the Telegram user behind a mention or
+// text_mention entity, or "" if the user has not connected GitHub. text is the entity's text.
+type MentionResolver func(entity gotgbot.MessageEntity, text string) string
+
+// entitySpan is a formatting entity in the tree built from a message's entities.
+// Offsets are in UTF-16 code units, like Telegram's.
+type entitySpan struct {
+	entity     gotgbot.MessageEntity
+	start, end int64
+	children   []*entitySpan
+}
+
+// EntitiesToMarkdown converts the text of a Telegram message and its formatting entities to
+// GitHub-flavoured Markdown. Entities that partially overlap are split so the output stays
+// well nested. Mentions resolve to @login when resolve knows the user; resolve may be nil.
+// Other @usernames get a zero-width joiner after the @, so GitHub does not mention whoever has that login.
+// Text outside entities is not escaped, so Markdown typed by hand keeps working.
+func EntitiesToMarkdown(text string, entities []gotgbot.MessageEntity, resolve MentionResolver) string {
+	if len(entities) == 0 {
+		return text
+	}
+
+	units := utf16.Encode([]rune(text))
+	r := &entityRenderer{units: units, resolve: resolve}
+	return r.children(buildEntityTree(entities, int64(len(units))))
+}
+
+// buildEntityTree nests entities by their ranges. An entity that starts inside another
+// but ends after it is cut at the end of the outer one, and the rest is nested on its own.
+func buildEntityTree(entities []gotgbot.MessageEntity, length int64) *entitySpan {
+	var pending []*entitySpan
+	for _, e := range entities {
+		start, end := max(e.Offset, 0), min(e.Offset+e.Length, length)
+		if start < end {
+			pending = append(pending, &entitySpan{entity: e, start: start, end: end})
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return spanBefore(pending[i], pending[j]) })
+
+	root := &entitySpan{end: length}
+	stack := []*entitySpan{root}
+	for len(pending) > 0 {
+		s := pending[0]
+		pending = pending[1:]
+
+		for s.start >= stack[len(stack)-1].end {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		if s.end > parent.end {
+			rest := &entitySpan{entity: s.entity, start: parent.end, end: s.end}
+			s.end = parent.end
+			i := sort.Search(len(pending), func(i int) bool { return spanBefore(rest, pending[i]) })
+			pending = append(pending[:i], append([]*entitySpan{rest}, pending[i:]...)...)
+		}
+
+		parent.children = append(parent.children, s)
+		stack = append(stack, s)
+	}
+	return root
+}
+
+// spanBefore orders spans by start, then outer spans first. Blocks go outside inline
+// formatting of the same range.
+func spanBefore(a, b *entitySpan) bool {
+	if a.start != b.start {
+		return a.start < b.start
+	}
+	if a.end != b.end {
+		return a.end > b.end
+	}
+	return isBlockEntity(a.entity.Type) && !isBlockEntity(b.entity.Type)
+}
+
+func isBlockEntity(typ string) bool {
+	return typ == "pre" || typ == "blockquote" || typ == "expandable_blockquote"
+}
+
+type entityRenderer struct {
+	units   []uint16
+	resolve MentionResolver
+}
+
+func (r *entityRenderer) text(start, end int64) string {
+	return string(utf16.Decode(r.units[start:end]))
+}
+
+// children renders the text of a span with its nested entities. Blocks start on their own
+// line and are followed by a blank line, so the text after a quote is not pulled into it.
+func (r *entityRenderer) children(s *entitySpan) string {
+	var sb strings.Builder
+	afterBlock := false
+	emit := func(out string, block bool) {
+		if afterBlock {
+			trimmed := strings.TrimLeft(out, " \n")
+			if trimmed == "" {
+				return
+			}
+			sb.WriteString("\n\n")
+			out, afterBlock = trimmed, false
+		}
+		if block && sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(out)
+		afterBlock = block
+	}
+
+	pos := s.start
+	for _, c := range s.children {
+		emit(r.text(pos, c.start), false)
+		emit(r.render(c), isBlockEntity(c.entity.Type))
+		pos = c.end
+	}
+	emit(r.text(pos, s.end), false)
+	return sb.String()
+}
+
+func (r *entityRenderer) render(s *entitySpan) string {
+	e := s.entity
+	switch e.Type {
+	case "code":
+		return codeSpan(r.text(s.start, s.end))
+	case "pre":
+		return codeBlock(r.text(s.start, s.end), e.Language)
+	case "mention", "text_mention":
+		if r.resolve != nil {
+			if login := r.resolve(e, r.text(s.start, s.end)); login != "" {
+				return "@" + login
+			}
+		}
+		// A Telegram @username may belong to someone else on GitHub, so it must not ping them
+		if e.Type == "mention" {
+			return "@\u200d" + strings.TrimPrefix(r.text(s.start, s.end), "@")
+		}
+	}
+
+	inner := r.children(s)
+	switch e.Type {
+	case "bold":
+		return wrapInline(inner, "**", "**")
+	case "italic":
+		// Underscores do not emphasize inside words, asterisks do
+		if r.intraword(s) {
+			return wrapInline(inner, "*", "*")
+		}
+		return wrapInline(inner, "_", "_")
+	case "underline":
+		return wrapInline(inner, "<ins>", "</ins>")
+	case "strikethrough":
+		return wrapInline(inner, "~~", "~~")
+	case "spoiler":
+		return wrapInline(inner, "<details><summary>Spoiler</summary>", "</details>")
+	case "text_link":
+		if strings.TrimSpace(inner) == "" {
+			return inner
+		}
+		return "[" + inner + "](" + linkDestination(e.Url) + ")"
+	case "blockquote", "expandable_blockquote":
+		lines := strings.Split(strings.TrimRight(inner, " \n"), "\n")
+		for i, l := range lines {
+			if l == "" {
+				lines[i] = ">"
+			} else {
+				lines[i] = "> " + l
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+	return inner
+}
+
+// intraword reports whether a span starts or ends in the middle of a word
+func (r *entityRenderer) intraword(s *entitySpan) bool {
+	isWord := func(i int64) bool {
+		if i < 0 || i >= int64(len(r.units)) || utf16.IsSurrogate(rune(r.units[i])) {
+			return false
+		}
+		c := rune(r.units[i])
+		return unicode.IsLetter(c) || unicode.IsDigit(c)
+	}
+	return isWord(s.start-1) && isWord(s.start) || isWord(s.end-1) && isWord(s.end)
+}
+
+// wrapInline puts Markdown delimiters around text. Surrounding whitespace is moved outside
+// the delimiters, since "**bold **" is not bold.
+func wrapInline(text, open, close string) string {
+	body := strings.TrimSpace(text)
+	if body == "" {
+		return text
+	}
+	i := strings.Index(text, body)
+	return text[:i] + open + body + close + text[i+len(body):]
+}
+
+// codeSpan returns code as an inline code span, with a fence longer than any run of backticks in it
+func codeSpan(code string) string {
+	fence := strings.Repeat("`", longestBacktickRun(code)+1)
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+// codeBlock returns code as a fenced code block
+func codeBlock(code, language string) string {
+	fence := strings.Repeat("`", max(3, longestBacktickRun(code)+1))
+	return fence + language + "\n" + strings.TrimSuffix(code, "\n") + "\n" + fence
+}
+
+func longestBacktickRun(s string) int {
+	longest, run := 0, 0
+	for _, c := range s {
+		if c == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	return longest
+}
+
+// linkDestination escapes the characters that would end a Markdown link destination early
+func linkDestination(url string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(url)
+}
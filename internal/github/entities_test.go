@@ -0,0 +1,213 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+func entity(typ string, offset, length int64) gotgbot.MessageEntity {
+	return gotgbot.MessageEntity{Type: typ, Offset: offset, Length: length}
+}
+
+func TestEntitiesToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []gotgbot.MessageEntity
+		want     string
+	}{
+		{
+			name: "plain text is kept verbatim",
+			text: "LGTM, *ship* it_",
+			want: "LGTM, *ship* it_",
+		},
+		{
+			name:     "bold",
+			text:     "hello world",
+			entities: []gotgbot.MessageEntity{entity("bold", 6, 5)},
+			want:     "hello **world**",
+		},
+		{
+			name:     "offsets count UTF-16 code units",
+			text:     "👍 nice and 🎉 done",
+			entities: []gotgbot.MessageEntity{entity("bold", 3, 4), entity("italic", 15, 4)},
+			want:     "👍 **nice** and 🎉 _done_",
+		},
+		{
+			name:     "cyrillic text",
+			text:     "привет мир",
+			entities: []gotgbot.MessageEntity{entity("strikethrough", 7, 3)},
+			want:     "привет ~~мир~~",
+		},
+		{
+			name:     "whitespace is moved outside delimiters",
+			text:     "bold text",
+			entities: []gotgbot.MessageEntity{entity("bold", 0, 5)},
+			want:     "**bold** text",
+		},
+		{
+			name:     "italic inside a word uses asterisks",
+			text:     "unbelievable",
+			entities: []gotgbot.MessageEntity{entity("italic", 2, 6)},
+			want:     "un*believ*able",
+		},
+		{
+			name:     "underline and spoiler",
+			text:     "see the twist",
+			entities: []gotgbot.MessageEntity{entity("underline", 0, 3), entity("spoiler", 8, 5)},
+			want:     "<ins>see</ins> the <details><summary>Spoiler</summary>twist</details>",
+		},
+		{
+			name:     "nested italic in bold",
+			text:     "bold and italic",
+			entities: []gotgbot.MessageEntity{entity("bold", 0, 15), entity("italic", 9, 6)},
+			want:     "**bold and _italic_**",
+		},
+		{
+			name:     "same range nests in entity order",
+			text:     "both",
+			entities: []gotgbot.MessageEntity{entity("bold", 0, 4), entity("italic", 0, 4)},
+			want:     "**_both_**",
+		},
+		{
+			name:     "inner entity listed first",
+			text:     "bold and italic",
+			entities: []gotgbot.MessageEntity{entity("italic", 9, 6), entity("bold", 0, 15)},
+			want:     "**bold and _italic_**",
+		},
+		{
+			name:     "partial overlap is split",
+			text:     "one two three",
+			entities: []gotgbot.MessageEntity{entity("bold", 0, 7), entity("italic", 4, 9)},
+			want:     "**one _two_** _three_",
+		},
+		{
+			name:     "overlap across two levels",
+			text:     "abc def ghi",
+			entities: []gotgbot.MessageEntity{entity("bold", 0, 7), entity("italic", 4, 3), entity("strikethrough", 5, 6)},
+			want:     "**abc _d~~ef~~_** ~~ghi~~",
+		},
+		{
+			name:     "code ignores nested entities and backticks get a longer fence",
+			text:     "run a`b now",
+			entities: []gotgbot.MessageEntity{entity("code", 4, 3), entity("bold", 4, 1)},
+			want:     "run ``a`b`` now",
+		},
+		{
+			name:     "code starting with a backtick is padded",
+			text:     "`x`",
+			entities: []gotgbot.MessageEntity{entity("code", 0, 3)},
+			want:     "`` `x` ``",
+		},
+		{
+			name:     "pre with language",
+			text:     "Example:\nfmt.Println()",
+			entities: []gotgbot.MessageEntity{{Type: "pre", Offset: 9, Length: 13, Language: "go"}},
+			want:     "Example:\n```go\nfmt.Println()\n```",
+		},
+		{
+			name:     "pre gets its own lines",
+			text:     "see x := 1 then",
+			entities: []gotgbot.MessageEntity{entity("pre", 4, 6)},
+			want:     "see \n```\nx := 1\n```\n\nthen",
+		},
+		{
+			name:     "pre containing a fence",
+			text:     "```\nnested\n```",
+			entities: []gotgbot.MessageEntity{entity("pre", 0, 14)},
+			want:     "````\n```\nnested\n```\n````",
+		},
+		{
+			name:     "text link",
+			text:     "read the docs",
+			entities: []gotgbot.MessageEntity{{Type: "text_link", Offset: 9, Length: 4, Url: "https://example.com/a (b)"}},
+			want:     "read the [docs](https://example.com/a%20%28b%29)",
+		},
+		{
+			name: "bold inside a link",
+			text: "read this",
+			entities: []gotgbot.MessageEntity{
+				{Type: "text_link", Offset: 0, Length: 9, Url: "https://x.io"},
+				entity("bold", 5, 4),
+			},
+			want: "[read **this**](https://x.io)",
+		},
+		{
+			name: "link overlapping bold",
+			text: "see this page",
+			entities: []gotgbot.MessageEntity{
+				entity("bold", 0, 8),
+				{Type: "text_link", Offset: 4, Length: 9, Url: "https://x.io"},
+			},
+			want: "**see [this](https://x.io)**[ page](https://x.io)",
+		},
+		{
+			name:     "blockquote",
+			text:     "line1\n\nline2\nafter",
+			entities: []gotgbot.MessageEntity{entity("blockquote", 0, 12)},
+			want:     "> line1\n>\n> line2\n\nafter",
+		},
+		{
+			name:     "expandable blockquote with formatting",
+			text:     "said: be brave",
+			entities: []gotgbot.MessageEntity{entity("expandable_blockquote", 6, 8), entity("bold", 9, 5)},
+			want:     "said: \n> be **brave**",
+		},
+		{
+			name:     "pre inside a blockquote",
+			text:     "look\ncode",
+			entities: []gotgbot.MessageEntity{entity("blockquote", 0, 9), entity("pre", 5, 4)},
+			want:     "> look\n> ```\n> code\n> ```",
+		},
+		{
+			name:     "entities past the end are clamped",
+			text:     "short",
+			entities: []gotgbot.MessageEntity{entity("bold", 2, 50), entity("italic", 10, 2)},
+			want:     "sh**ort**",
+		},
+		{
+			name:     "urls and hashtags are left alone",
+			text:     "#42 at https://x.io",
+			entities: []gotgbot.MessageEntity{entity("hashtag", 0, 3), entity("url", 7, 12)},
+			want:     "#42 at https://x.io",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EntitiesToMarkdown(tt.text, tt.entities, nil); got != tt.want {
+				t.Errorf("EntitiesToMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntitiesToMarkdownMentions(t *testing.T) {
+	resolve := func(e gotgbot.MessageEntity, text string) string {
+		if e.Type == "text_mention" && e.User != nil && e.User.Id == 42 {
+			return "octocat"
+		}
+		if e.Type == "mention" && strings.EqualFold(text, "@alice_tg") {
+			return "alice"
+		}
+		return ""
+	}
+
+	text := "thanks Mona, @Alice_tg and @stranger"
+	entities := []gotgbot.MessageEntity{
+		{Type: "text_mention", Offset: 7, Length: 4, User: &gotgbot.User{Id: 42}},
+		entity("mention", 13, 9),
+		entity("mention", 27, 9),
+		entity("bold", 0, 11),
+	}
+	want := "**thanks @octocat**, @alice and @\u200dstranger"
+	if got := EntitiesToMarkdown(text, entities, resolve); got != want {
+		t.Errorf("EntitiesToMarkdown() = %q, want %q", got, want)
+	}
+
+	if got := EntitiesToMarkdown(text, entities, nil); got != "**thanks Mona**, @\u200dAlice_tg and @\u200dstranger" {
+		t.Errorf("without resolver = %q", got)
+	}
+}
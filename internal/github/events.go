@@ -18,3 +18,21 @@ var SupportedEvents = []Event{
 	{Name: "fork", Label: "Forks", Short: "f"},
 	{Name: "star", Label: "Stars", Short: "s"},
 }
+
+// CIFailureEvent is a pseudo event for failed CI on the default branch, used by per-event sound settings
+var CIFailureEvent = Event{Name: "ci_failure", Label: "Failed CI (default branch)", Short: "cif"}
+
+// OrgEvents are only delivered by organization webhooks, on top of the repository events
+var OrgEvents = []Event{
+	{Name: "membership", Label: "Team membership", Short: "ms"},
+	{Name: "organization", Label: "Organization", Short: "org"},
+	{Name: "team", Label: "Teams", Short: "t"},
+}
+
+// LinkEvents returns the events a link's webhook can subscribe to
+func LinkEvents(org bool) []Event {
+	if !org {
+		return SupportedEvents
+	}
+	return append(append([]Event{}, SupportedEvents...), OrgEvents...)
+}
@@ -45,7 +45,7 @@ func FormatIssuesEvent(event *github.IssuesEvent) (string, *gotgbot.InlineKeyboa
 	case "assigned":
 		var assignees []string
 		for _, a := range issue.Assignees {
-			assignees = append(assignees, EscapeMarkdownV2(a.GetLogin()))
+			assignees = append(assignees, FormatUser(a.GetLogin()))
 		}
 		msg += fmt.Sprintf("*Assigned to:* %s\n", strings.Join(assignees, ", "))
 	case "labeled":
@@ -101,13 +101,13 @@ func FormatPullRequestEvent(event *github.PullRequestEvent) (string, *gotgbot.In
 	case "assigned":
 		var assignees []string
 		for _, a := range pr.Assignees {
-			assignees = append(assignees, EscapeMarkdownV2(a.GetLogin()))
+			assignees = append(assignees, FormatUser(a.GetLogin()))
 		}
 		msg += fmt.Sprintf("*Assigned:* %s\n", strings.Join(assignees, ", "))
 	case "review_requested":
 		var reviewers []string
 		for _, r := range pr.RequestedReviewers {
-			reviewers = append(reviewers, EscapeMarkdownV2(r.GetLogin()))
+			reviewers = append(reviewers, FormatUser(r.GetLogin()))
 		}
 		msg += fmt.Sprintf("*Reviewers:* %s\n", strings.Join(reviewers, ", "))
 	case "labeled":
@@ -120,7 +120,19 @@ func FormatPullRequestEvent(event *github.PullRequestEvent) (string, *gotgbot.In
 		msg += "🔄 New commits pushed\n"
 	}
 
-	return FormatMessageWithButton(msg, "View PR", url)
+	msg, markup := FormatMessageWithButton(msg, "View PR", url)
+	if state == "open" && !pr.GetDraft() && action != "closed" {
+		addMergeButton(markup)
+	}
+	return msg, markup
+}
+
+// addMergeButton adds the button that starts the merge flow for the notification's pull request
+func addMergeButton(markup *gotgbot.InlineKeyboardMarkup) {
+	if markup == nil || len(markup.InlineKeyboard) == 0 {
+		return
+	}
+	markup.InlineKeyboard[0] = append(markup.InlineKeyboard[0], gotgbot.InlineKeyboardButton{Text: "🔀 Merge", CallbackData: "mg:start"})
 }
 
 func FormatPushEvent(event *github.PushEvent) (string, *gotgbot.InlineKeyboardMarkup) {
@@ -129,65 +141,29 @@ func FormatPushEvent(event *github.PushEvent) (string, *gotgbot.InlineKeyboardMa
 	branch := strings.TrimPrefix(event.GetRef(), "refs/heads/")
 	compareURL := event.GetCompare()
 
-	var commits []*github.HeadCommit
-	if len(event.Commits) > 0 {
-		commits = event.Commits
-	} else if event.HeadCommit != nil {
-		commits = []*github.HeadCommit{event.HeadCommit}
-	}
-
+	commits := pushCommits(event)
 	commitCount := len(commits)
-	if commitCount == 0 {
+	if commitCount == 0 && (!event.GetForced() || event.GetDeleted()) {
 		return "", nil
 	}
 
-	var commitPlural string
-	if commitCount > 1 {
-		commitPlural = "s"
-	}
-	msg := fmt.Sprintf(
-		"🔨 *%d new commit%s to* `%s:%s`\n\n",
-		commitCount, commitPlural, EscapeMarkdownV2(repo), EscapeMarkdownV2(branch),
-	)
+	msg := pushHeadline(commitCount, repo, branch) + "\n\n"
 
 	if event.GetCreated() {
 		msg += "🌱 _New branch created_\n"
 	} else if event.GetDeleted() {
 		msg += "🗑️ _Branch deleted_\n"
 	} else if event.GetForced() {
-		msg += "⚠️ _Force pushed_\n"
+		msg += formatForcePush(event)
 	}
 
 	for _, commit := range commits {
-		shortSHA := commit.GetID()
-		if len(shortSHA) > 7 {
-			shortSHA = shortSHA[:7]
-		}
-		commitURL := fmt.Sprintf("%s/commit/%s", repoURL, commit.GetID())
-		var authorStr string
-		if login := commit.Author.GetLogin(); login != "" {
-			authorStr = FormatUser(login)
-		} else {
-			authorStr = EscapeMarkdownV2(commit.Author.GetName())
-		}
-
-		commitMessage := FormatTextWithMarkdown(commit.GetMessage())
-
-		msg += fmt.Sprintf(
-			"\\- [%s](%s): %s by %s\n",
-			EscapeMarkdownV2(shortSHA),
-			EscapeMarkdownV2URL(commitURL),
-			commitMessage,
-			authorStr,
-		)
+		msg += formatPushCommit(commit, repoURL)
 	}
 
 	if len(msg) > 4000 {
-		msg = fmt.Sprintf(
-			"🔨 *%d new commit(s) to* `%s:%s`\n\n"+
-				"⚠️ _Too many commits to display, check the repository for details\\._\n",
-			commitCount, EscapeMarkdownV2(repo), EscapeMarkdownV2(branch),
-		)
+		msg = pushHeadline(commitCount, repo, branch) + "\n\n" +
+			"⚠️ _Too many commits to display, check the repository for details\\._\n"
 	}
 
 	if commitCount == 1 {
@@ -197,6 +173,70 @@ func FormatPushEvent(event *github.PushEvent) (string, *gotgbot.InlineKeyboardMa
 	return FormatMessageWithButton(msg, "View Commits", compareURL)
 }
 
+// pushHeadline is the first line of a push notification. A force push that only dropped commits has no new ones to count.
+func pushHeadline(commitCount int, repo string, branch string) string {
+	if commitCount == 0 {
+		return fmt.Sprintf("⚠️ *Force push to* `%s:%s`", EscapeMarkdownV2(repo), EscapeMarkdownV2(branch))
+	}
+	var commitPlural string
+	if commitCount != 1 {
+		commitPlural = "s"
+	}
+	return fmt.Sprintf("🔨 *%d new commit%s to* `%s:%s`", commitCount, commitPlural, EscapeMarkdownV2(repo), EscapeMarkdownV2(branch))
+}
+
+// pushCommits returns the commits carried by a push, falling back to the head commit
+func pushCommits(event *github.PushEvent) []*github.HeadCommit {
+	if len(event.Commits) > 0 {
+		return event.Commits
+	} else if event.HeadCommit != nil {
+		return []*github.HeadCommit{event.HeadCommit}
+	}
+	return nil
+}
+
+// formatPushCommit renders one commit line of a push notification
+func formatPushCommit(commit *github.HeadCommit, repoURL string) string {
+	shortSHA := commit.GetID()
+	if len(shortSHA) > 7 {
+		shortSHA = shortSHA[:7]
+	}
+	commitURL := fmt.Sprintf("%s/commit/%s", repoURL, commit.GetID())
+	var authorStr string
+	if login := commit.Author.GetLogin(); login != "" {
+		authorStr = FormatUser(login)
+	} else {
+		authorStr = EscapeMarkdownV2(commit.Author.GetName())
+	}
+
+	commitMessage := FormatTextWithMarkdown(commit.GetMessage())
+
+	return fmt.Sprintf(
+		"\\- [%s](%s): %s by %s\n",
+		EscapeMarkdownV2(shortSHA),
+		EscapeMarkdownV2URL(commitURL),
+		commitMessage,
+		authorStr,
+	)
+}
+
+// formatForcePush renders the force-push marker with the rewritten range
+func formatForcePush(event *github.PushEvent) string {
+	before, after := event.GetBefore(), event.GetAfter()
+	if len(before) > 7 {
+		before = before[:7]
+	}
+	if len(after) > 7 {
+		after = after[:7]
+	}
+
+	msg := fmt.Sprintf("⚠️ *Force pushed* `%s` → `%s`", EscapeMarkdownV2(before), EscapeMarkdownV2(after))
+	if compare := event.GetCompare(); compare != "" {
+		msg += fmt.Sprintf(" \\([compare](%s)\\)", EscapeMarkdownV2URL(compare))
+	}
+	return msg + "\n"
+}
+
 func FormatCreateEvent(event *github.CreateEvent) (string, *gotgbot.InlineKeyboardMarkup) {
 	repo := event.Repo.GetFullName()
 	repoURL := event.Repo.GetHTMLURL()
@@ -558,6 +598,9 @@ func FormatWorkflowRunEvent(e *github.WorkflowRunEvent) (string, *gotgbot.Inline
 	case "queued":
 		statusEmoji = "🔄"
 		statusLabel = "Queued"
+	case "waiting":
+		statusEmoji = "⏸️"
+		statusLabel = "Waiting for approval"
 	default:
 		statusEmoji = "⚠️"
 		statusLabel = "Unknown status"
@@ -574,6 +617,9 @@ func FormatWorkflowRunEvent(e *github.WorkflowRunEvent) (string, *gotgbot.Inline
 		FormatRepo(repo),
 		FormatUser(sender),
 	)
+	if attempt := run.GetRunAttempt(); attempt > 1 {
+		msg += fmt.Sprintf("\n*Attempt:* %d", attempt)
+	}
 	return FormatMessageWithButton(msg, "View Run", run.GetHTMLURL())
 }
 
@@ -603,6 +649,8 @@ func FormatWorkflowJobEvent(e *github.WorkflowJobEvent) (string, *gotgbot.Inline
 		statusEmoji = "⏳"
 	case status == "queued":
 		statusEmoji = "🔄"
+	case status == "waiting":
+		statusEmoji = "⏸️"
 	case conclusion == "cancelled":
 		statusEmoji = "⛔"
 		statusText = "Cancelled"
@@ -845,7 +893,11 @@ func FormatPullRequestReviewEvent(e *github.PullRequestReviewEvent) (string, *go
 		EscapeMarkdownV2(review.GetState()),
 		FormatUser(e.GetSender().GetLogin()),
 	)
-	return FormatMessageWithButton(msg, "View Review", review.GetHTMLURL())
+	msg, markup := FormatMessageWithButton(msg, "View Review", review.GetHTMLURL())
+	if review.GetState() == "approved" && pr.GetState() == "open" {
+		addMergeButton(markup)
+	}
+	return msg, markup
 }
 
 func FormatPingEvent(e *github.PingEvent) (string, *gotgbot.InlineKeyboardMarkup) {
@@ -1497,10 +1549,30 @@ func FormatOrganizationEvent(e *github.OrganizationEvent) (string, *gotgbot.Inli
 	action := e.GetAction()
 	sender := e.GetSender()
 
-	msg := fmt.Sprintf("🏢 *Organization Event*\n*Action:* %s", EscapeMarkdownV2(action))
+	msg := fmt.Sprintf(
+		"🏢 *Organization %s*\n\n"+
+			"*Organization:* %s\n",
+		EscapeMarkdownV2(strings.ReplaceAll(action, "_", " ")),
+		EscapeMarkdownV2(e.GetOrganization().GetLogin()),
+	)
+
+	// Members added or removed come with their membership, invited ones with the invitation
+	member, role := e.GetMembership().GetUser().GetLogin(), e.GetMembership().GetRole()
+	if inv := e.GetInvitation(); inv != nil {
+		member, role = inv.GetLogin(), inv.GetRole()
+		if member == "" && inv.GetEmail() != "" {
+			msg += fmt.Sprintf("*Member:* %s\n", EscapeMarkdownV2(inv.GetEmail()))
+		}
+	}
+	if member != "" {
+		msg += fmt.Sprintf("*Member:* %s\n", FormatUser(member))
+	}
+	if role != "" {
+		msg += fmt.Sprintf("*Role:* %s\n", EscapeMarkdownV2(role))
+	}
 
 	if sender != nil {
-		msg += fmt.Sprintf("\n*By:* %s", FormatUser(sender.GetLogin()))
+		msg += fmt.Sprintf("*By:* %s", FormatUser(sender.GetLogin()))
 	}
 
 	return FormatMessageWithButton(msg, "View Organization", e.GetOrganization().GetHTMLURL())
@@ -1559,6 +1631,10 @@ func FormatMembershipEvent(e *github.MembershipEvent) (string, *gotgbot.InlineKe
 
 	msg := fmt.Sprintf("👥 *Membership %s*\n\n", EscapeMarkdownV2(e.GetAction()))
 
+	if org := e.GetOrg(); org != nil {
+		msg += fmt.Sprintf("*Organization:* %s\n", EscapeMarkdownV2(org.GetLogin()))
+	}
+
 	if scope := e.GetScope(); scope != "" {
 		msg += fmt.Sprintf("*Scope:* %s\n", EscapeMarkdownV2(scope))
 	}
@@ -1785,7 +1861,7 @@ func FormatCheckSuiteEvent(e *github.CheckSuiteEvent) (string, *gotgbot.InlineKe
 		msg.WriteString(fmt.Sprintf("*Triggered by:* %s", EscapeMarkdownV2(username)))
 	}
 
-	return FormatMessageWithButton(msg.String(), "View Details", e.GetCheckSuite().GetURL())
+	return FormatMessageWithButton(msg.String(), "View Details", CheckSuiteURL(e.GetRepo(), suite))
 }
 
 func FormatCheckRunEvent(e *github.CheckRunEvent) (string, *gotgbot.InlineKeyboardMarkup) {
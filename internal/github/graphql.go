@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// GraphQLError is returned when the GraphQL API answers with errors
+type GraphQLError struct {
+	Messages []string
+}
+
+func (e *GraphQLError) Error() string {
+	return "graphql: " + strings.Join(e.Messages, "; ")
+}
+
+// GraphQL runs a query or mutation with the client's credentials and decodes the response data into out.
+// The endpoint is derived from the client's base URL, so GitHub Enterprise and test servers work too.
+func GraphQL(ctx context.Context, client *github.Client, query string, variables map[string]interface{}, out interface{}) error {
+	endpoint := "graphql"
+	if base := client.BaseURL(); strings.HasSuffix(base, "/api/v3/") {
+		// Enterprise Server serves GraphQL at /api/graphql next to the REST API
+		endpoint = strings.TrimSuffix(base, "v3/") + "graphql"
+	}
+
+	req, err := client.NewRequest(ctx, "POST", endpoint, map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err := client.Do(req, &resp); err != nil {
+		return err
+	}
+
+	if len(resp.Errors) > 0 {
+		gqlErr := &GraphQLError{}
+		for _, e := range resp.Errors {
+			gqlErr.Messages = append(gqlErr.Messages, e.Message)
+		}
+		return gqlErr
+	}
+
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, out)
+}
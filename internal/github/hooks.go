@@ -0,0 +1,59 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github-webhook/internal/utils"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// ParseWebhookToken decrypts the token of a webhook URL into the chat and topic it delivers to;
+// the topic is 0 when the webhook delivers to the main chat
+func ParseWebhookToken(token, key string) (chatID, topicID int64, err error) {
+	decrypted, err := utils.Decrypt(token, key)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	chat, topic, found := strings.Cut(decrypted, ":")
+	chatID, err = strconv.ParseInt(chat, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid chat in webhook token: %w", err)
+	}
+	if found {
+		topicID, err = strconv.ParseInt(topic, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid topic in webhook token: %w", err)
+		}
+	}
+	return chatID, topicID, nil
+}
+
+// HookTopic returns the topic a webhook created by the bot delivers to, reporting false when its
+// URL is not one of the bot's
+func HookTopic(hook *github.Hook, key string) (int64, bool) {
+	_, token, found := strings.Cut(hook.GetConfig().GetURL(), "/webhook/")
+	if !found {
+		return 0, false
+	}
+	_, topicID, err := ParseWebhookToken(token, key)
+	return topicID, err == nil
+}
+
+// HookLastDelivery returns the HTTP status and message of a webhook's last delivery,
+// reporting false when nothing was delivered yet
+func HookLastDelivery(hook *github.Hook) (code int, message string, ok bool) {
+	last := hook.LastResponse
+	if last == nil || last["status"] == "unused" {
+		return 0, "", false
+	}
+	// The code is a JSON number, and null when the delivery got no response at all
+	if c, isNum := last["code"].(float64); isNum {
+		code = int(c)
+	}
+	message, _ = last["message"].(string)
+	return code, message, true
+}
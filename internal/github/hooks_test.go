@@ -0,0 +1,73 @@
+package github
+
+import (
+	"testing"
+
+	"github-webhook/internal/utils"
+
+	"github.com/google/go-github/v89/github"
+)
+
+const testKey = "12345678901234567890123456789012"
+
+func TestParseWebhookToken(t *testing.T) {
+	tests := []struct {
+		payload string
+		chat    int64
+		topic   int64
+		wantErr bool
+	}{
+		{"-1001234", -1001234, 0, false},
+		{"-1001234:42", -1001234, 42, false},
+		{"-1001234:42:7", 0, 0, true},
+		{"chat", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		token, err := utils.Encrypt(tt.payload, testKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chat, topic, err := ParseWebhookToken(token, testKey)
+		if (err != nil) != tt.wantErr || chat != tt.chat || topic != tt.topic {
+			t.Errorf("ParseWebhookToken(%q) = %d, %d, %v; want %d, %d, error %v", tt.payload, chat, topic, err, tt.chat, tt.topic, tt.wantErr)
+		}
+	}
+}
+
+func TestHookTopic(t *testing.T) {
+	token, err := utils.Encrypt("-1001234:42", testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hook := &github.Hook{Config: &github.HookConfig{URL: github.Ptr("https://bot.example.com/webhook/" + token)}}
+	if topic, ok := HookTopic(hook, testKey); !ok || topic != 42 {
+		t.Errorf("HookTopic() = %d, %v; want 42, true", topic, ok)
+	}
+
+	other := &github.Hook{Config: &github.HookConfig{URL: github.Ptr("https://ci.example.com/hook")}}
+	if _, ok := HookTopic(other, testKey); ok {
+		t.Error("HookTopic() reported a topic for a webhook that is not the bot's")
+	}
+}
+
+func TestHookLastDelivery(t *testing.T) {
+	tests := []struct {
+		last    map[string]any
+		code    int
+		message string
+		ok      bool
+	}{
+		{nil, 0, "", false},
+		{map[string]any{"code": nil, "status": "unused", "message": nil}, 0, "", false},
+		{map[string]any{"code": float64(200), "status": "active", "message": "OK"}, 200, "OK", true},
+		{map[string]any{"code": nil, "status": "timeout", "message": "Service Timeout"}, 0, "Service Timeout", true},
+	}
+
+	for _, tt := range tests {
+		code, message, ok := HookLastDelivery(&github.Hook{LastResponse: tt.last})
+		if code != tt.code || message != tt.message || ok != tt.ok {
+			t.Errorf("HookLastDelivery(%v) = %d, %q, %v; want %d, %q, %v", tt.last, code, message, ok, tt.code, tt.message, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// Reasons a GitHub user is targeted by an event
+const (
+	MentionReview  = "review"
+	MentionAssign  = "assign"
+	MentionMention = "mention"
+)
+
+// Mention is a GitHub user an event is addressed to
+type Mention struct {
+	Login  string
+	UserID int64 // GitHub user ID, unknown (0) for @mentions in text
+	Reason string
+}
+
+// LinkedMention is a mention whose GitHub user has a linked Telegram account
+type LinkedMention struct {
+	Mention
+	TelegramID int64
+}
+
+var (
+	mentionRe  = regexp.MustCompile("(?:^|[^A-Za-z0-9_`/@.\\-])@([A-Za-z0-9](?:-?[A-Za-z0-9]){0,38})")
+	codeSpanRe = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+)
+
+// MentionTargets returns the users an event requests a review from, assigns, or
+// @mentions in a newly created body or a pushed commit message. The event's sender is never included.
+func MentionTargets(event interface{}) []Mention {
+	var (
+		sender  string
+		targets []Mention
+	)
+	add := func(u *github.User, reason string) {
+		if u.GetLogin() != "" {
+			targets = append(targets, Mention{Login: u.GetLogin(), UserID: u.GetID(), Reason: reason})
+		}
+	}
+	addBody := func(body string) {
+		for _, login := range ParseMentions(body) {
+			targets = append(targets, Mention{Login: login, Reason: MentionMention})
+		}
+	}
+
+	switch e := event.(type) {
+	case *github.IssuesEvent:
+		sender = e.GetSender().GetLogin()
+		switch e.GetAction() {
+		case "assigned":
+			add(e.GetAssignee(), MentionAssign)
+		case "opened":
+			addBody(e.GetIssue().GetBody())
+		}
+	case *github.PullRequestEvent:
+		sender = e.GetSender().GetLogin()
+		switch e.GetAction() {
+		case "review_requested":
+			add(e.GetRequestedReviewer(), MentionReview)
+		case "assigned":
+			add(e.GetAssignee(), MentionAssign)
+		case "opened":
+			addBody(e.GetPullRequest().GetBody())
+		}
+	case *github.IssueCommentEvent:
+		sender = e.GetSender().GetLogin()
+		if e.GetAction() == "created" {
+			addBody(e.GetComment().GetBody())
+		}
+	case *github.PullRequestReviewEvent:
+		sender = e.GetSender().GetLogin()
+		if e.GetAction() == "submitted" {
+			addBody(e.GetReview().GetBody())
+		}
+	case *github.PullRequestReviewCommentEvent:
+		sender = e.GetSender().GetLogin()
+		if e.GetAction() == "created" {
+			addBody(e.GetComment().GetBody())
+		}
+	case *github.CommitCommentEvent:
+		sender = e.GetSender().GetLogin()
+		addBody(e.GetComment().GetBody())
+	case *github.PushEvent:
+		sender = e.GetSender().GetLogin()
+		for _, c := range e.Commits {
+			addBody(c.GetMessage())
+		}
+	case *github.DiscussionEvent:
+		sender = e.GetSender().GetLogin()
+		if e.GetAction() == "created" {
+			addBody(e.GetDiscussion().GetBody())
+		}
+	case *github.DiscussionCommentEvent:
+		sender = e.GetSender().GetLogin()
+		if e.GetAction() == "created" {
+			addBody(e.GetComment().GetBody())
+		}
+	}
+
+	// Keep the first reason per user, so a requested reviewer who is also mentioned counts as a review request
+	seen := map[string]bool{strings.ToLower(sender): true}
+	var out []Mention
+	for _, t := range targets {
+		key := strings.ToLower(t.Login)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, t)
+	}
+	return out
+}
+
+// ParseMentions returns the logins @mentioned in a Markdown body, ignoring code,
+// email addresses and team mentions.
+func ParseMentions(body string) []string {
+	body = codeSpanRe.ReplaceAllString(body, " ")
+
+	var logins []string
+	for _, m := range mentionRe.FindAllStringSubmatchIndex(body, -1) {
+		if end := m[3]; end < len(body) && body[end] == '/' {
+			continue
+		}
+		logins = append(logins, body[m[2]:m[3]])
+	}
+	return logins
+}
+
+// FormatTelegramMention links a GitHub login to a Telegram user so they get notified
+func FormatTelegramMention(login string, telegramID int64) string {
+	return fmt.Sprintf("[@%s](tg://user?id=%d)", EscapeMarkdownV2(login), telegramID)
+}
+
+// LinkMentions rewrites a MarkdownV2 message so linked users are mentioned on Telegram.
+// Their GitHub profile links and @logins are replaced in place; users who do not
+// appear in the message are mentioned on an extra line at the end.
+func LinkMentions(msg string, mentions []LinkedMention) string {
+	var missing []string
+	for _, m := range mentions {
+		link := FormatTelegramMention(m.Login, m.TelegramID)
+		replaced := false
+
+		profile := FormatUser(m.Login)
+		if strings.Contains(msg, profile) {
+			msg = strings.ReplaceAll(msg, profile, link)
+			replaced = true
+		}
+
+		var ok bool
+		if msg, ok = replaceAtLogin(msg, EscapeMarkdownV2(m.Login), link); ok {
+			replaced = true
+		}
+
+		if !replaced {
+			missing = append(missing, link)
+		}
+	}
+
+	if len(missing) > 0 {
+		msg = strings.TrimRight(msg, "\n") + "\n\n🔔 " + strings.Join(missing, " ")
+	}
+	return msg
+}
+
+// replaceAtLogin replaces standalone, case-insensitive "@login" occurrences in escaped text
+func replaceAtLogin(msg, escapedLogin, repl string) (string, bool) {
+	re := regexp.MustCompile(`(?i)@` + regexp.QuoteMeta(escapedLogin))
+
+	var (
+		b        strings.Builder
+		last     int
+		replaced bool
+	)
+	for _, loc := range re.FindAllStringIndex(msg, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && !isMentionBoundary(msg[start-1]) {
+			continue
+		}
+		if end < len(msg) && (isLoginChar(msg[end]) || strings.HasPrefix(msg[end:], `\-`)) {
+			continue
+		}
+		b.WriteString(msg[last:start])
+		b.WriteString(repl)
+		last = end
+		replaced = true
+	}
+	b.WriteString(msg[last:])
+	return b.String(), replaced
+}
+
+func isLoginChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isMentionBoundary(c byte) bool {
+	return !isLoginChar(c) && c != '[' && c != '/' && c != '`'
+}
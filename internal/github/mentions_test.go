@@ -0,0 +1,98 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		body string
+		want []string
+	}{
+		{"cc @alice and @bob-smith.", []string{"alice", "bob-smith"}},
+		{"(@alice) thanks", []string{"alice"}},
+		{"mail me at me@example.com", nil},
+		{"ping @octo-org/core", nil},
+		{"`@alice` is code", nil},
+		{"```\n@alice\n```\n@bob", []string{"bob"}},
+		{"@carol", []string{"carol"}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseMentions(tt.body); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseMentions(%q) = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestMentionTargets(t *testing.T) {
+	event := &github.PullRequestEvent{
+		Action:            github.Ptr("review_requested"),
+		Sender:            &github.User{Login: github.Ptr("octocat")},
+		RequestedReviewer: &github.User{Login: github.Ptr("alice")},
+	}
+	want := []Mention{{Login: "alice", Reason: MentionReview}}
+	if got := MentionTargets(event); !reflect.DeepEqual(got, want) {
+		t.Errorf("MentionTargets() = %v, want %v", got, want)
+	}
+
+	comment := &github.IssueCommentEvent{
+		Action:  github.Ptr("created"),
+		Sender:  &github.User{Login: github.Ptr("octocat")},
+		Comment: &github.IssueComment{Body: github.Ptr("@Octocat @alice @ALICE @bob")},
+	}
+	want = []Mention{{Login: "alice", Reason: MentionMention}, {Login: "bob", Reason: MentionMention}}
+	if got := MentionTargets(comment); !reflect.DeepEqual(got, want) {
+		t.Errorf("MentionTargets() = %v, want %v", got, want)
+	}
+
+	comment.Action = github.Ptr("edited")
+	if got := MentionTargets(comment); len(got) != 0 {
+		t.Errorf("MentionTargets() for edited comment = %v, want none", got)
+	}
+
+	push := &github.PushEvent{
+		Sender: &github.User{Login: github.Ptr("octocat")},
+		Commits: []*github.HeadCommit{
+			{Message: github.Ptr("Fix login\n\nReported by @alice")},
+			{Message: github.Ptr("Thanks @octocat and @bob")},
+		},
+	}
+	want = []Mention{{Login: "alice", Reason: MentionMention}, {Login: "bob", Reason: MentionMention}}
+	if got := MentionTargets(push); !reflect.DeepEqual(got, want) {
+		t.Errorf("MentionTargets() for push = %v, want %v", got, want)
+	}
+}
+
+func TestLinkMentions(t *testing.T) {
+	alice := LinkedMention{Mention: Mention{Login: "alice", Reason: MentionReview}, TelegramID: 1}
+	bob := LinkedMention{Mention: Mention{Login: "bob-smith", Reason: MentionMention}, TelegramID: 2}
+	carol := LinkedMention{Mention: Mention{Login: "carol", Reason: MentionAssign}, TelegramID: 3}
+
+	msg := "*Reviewers:* " + FormatUser("alice") + "\n" +
+		"*Description:*\nThanks @Bob\\-Smith\\. See @bob\\-smithy and " + FormatUser("dave") + "\n"
+
+	got := LinkMentions(msg, []LinkedMention{alice, bob, carol})
+
+	for _, want := range []string{
+		"*Reviewers:* [@alice](tg://user?id=1)\n",
+		"Thanks [@bob\\-smith](tg://user?id=2)\\.",
+		"@bob\\-smithy",
+		FormatUser("dave"),
+		"\n\n🔔 [@carol](tg://user?id=3)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("LinkMentions() missing %q in %q", want, got)
+		}
+	}
+	if strings.Contains(got, FormatUser("alice")) {
+		t.Errorf("LinkMentions() kept GitHub link for alice: %q", got)
+	}
+	if strings.Contains(got, "[@alice](tg://user?id=1)\n\n🔔") || strings.Count(got, "tg://user?id=1") != 1 {
+		t.Errorf("LinkMentions() mentioned alice twice: %q", got)
+	}
+}
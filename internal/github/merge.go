@@ -0,0 +1,361 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// Merge methods, as used by the REST API
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// MergeMethods lists the merge methods in the order they are offered
+var MergeMethods = []string{MergeMethodMerge, MergeMethodSquash, MergeMethodRebase}
+
+// Reasons a pull request cannot be merged right now
+const (
+	BlockerClosed           = "closed"
+	BlockerMerged           = "merged"
+	BlockerDraft            = "draft"
+	BlockerConflicts        = "conflicts"
+	BlockerChangesRequested = "changes_requested"
+	BlockerReviewRequired   = "review_required"
+	BlockerChecksFailed     = "checks_failed"
+	BlockerChecksPending    = "checks_pending"
+	BlockerBehind           = "behind"
+	BlockerBlocked          = "blocked"
+	BlockerUnknown          = "unknown"
+)
+
+// Check states after normalizing check runs and commit statuses
+const (
+	CheckSuccess = "success"
+	CheckPending = "pending"
+	CheckFailure = "failure"
+)
+
+// MergeCheck is a check run or commit status on the pull request's head commit
+type MergeCheck struct {
+	Name     string
+	State    string
+	Required bool
+}
+
+// MergeBlocker is a reason a pull request cannot be merged, with the checks it concerns
+type MergeBlocker struct {
+	Code   string
+	Checks []string
+}
+
+// MergeState describes whether a pull request can be merged
+type MergeState struct {
+	ID               string // GraphQL node ID
+	Number           int
+	Title            string
+	State            string // OPEN, CLOSED or MERGED
+	IsDraft          bool
+	HeadRef          string
+	HeadSHA          string
+	HeadOwner        string
+	Mergeable        string // MERGEABLE, CONFLICTING or UNKNOWN
+	MergeStateStatus string // CLEAN, BLOCKED, BEHIND, DIRTY, DRAFT, HAS_HOOKS, UNKNOWN or UNSTABLE
+	ReviewDecision   string // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED or empty when reviews are not required
+	AutoMerge        bool
+	Checks           []MergeCheck
+	Methods          []string // Merge methods allowed by the repository
+}
+
+const mergeStateQuery = `query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    mergeCommitAllowed
+    squashMergeAllowed
+    rebaseMergeAllowed
+    pullRequest(number: $number) {
+      id
+      number
+      title
+      state
+      isDraft
+      headRefName
+      headRefOid
+      headRepositoryOwner { login }
+      mergeable
+      mergeStateStatus
+      reviewDecision
+      autoMergeRequest { enabledAt }
+      commits(last: 1) {
+        nodes {
+          commit {
+            statusCheckRollup {
+              contexts(first: 100) {
+                nodes {
+                  __typename
+                  ... on CheckRun { name status conclusion isRequired(pullRequestNumber: $number) }
+                  ... on StatusContext { context state isRequired(pullRequestNumber: $number) }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type mergeStateResponse struct {
+	Repository struct {
+		MergeCommitAllowed bool `json:"mergeCommitAllowed"`
+		SquashMergeAllowed bool `json:"squashMergeAllowed"`
+		RebaseMergeAllowed bool `json:"rebaseMergeAllowed"`
+		PullRequest        *struct {
+			ID                  string `json:"id"`
+			Number              int    `json:"number"`
+			Title               string `json:"title"`
+			State               string `json:"state"`
+			IsDraft             bool   `json:"isDraft"`
+			HeadRefName         string `json:"headRefName"`
+			HeadRefOid          string `json:"headRefOid"`
+			HeadRepositoryOwner struct {
+				Login string `json:"login"`
+			} `json:"headRepositoryOwner"`
+			Mergeable        string `json:"mergeable"`
+			MergeStateStatus string `json:"mergeStateStatus"`
+			ReviewDecision   string `json:"reviewDecision"`
+			AutoMergeRequest *struct {
+				EnabledAt string `json:"enabledAt"`
+			} `json:"autoMergeRequest"`
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							Contexts struct {
+								Nodes []struct {
+									Typename   string `json:"__typename"`
+									Name       string `json:"name"`
+									Status     string `json:"status"`
+									Conclusion string `json:"conclusion"`
+									Context    string `json:"context"`
+									State      string `json:"state"`
+									IsRequired bool   `json:"isRequired"`
+								} `json:"nodes"`
+							} `json:"contexts"`
+						} `json:"statusCheckRollup"`
+					} `json:"commit"`
+				} `json:"nodes"`
+			} `json:"commits"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// FetchMergeState loads a pull request's mergeability, review decision and head commit checks
+func FetchMergeState(ctx context.Context, client *github.Client, owner, repo string, number int) (*MergeState, error) {
+	var resp mergeStateResponse
+	vars := map[string]interface{}{"owner": owner, "repo": repo, "number": number}
+	if err := GraphQL(ctx, client, mergeStateQuery, vars, &resp); err != nil {
+		return nil, err
+	}
+
+	pr := resp.Repository.PullRequest
+	if pr == nil {
+		return nil, fmt.Errorf("pull request %s/%s#%d not found", owner, repo, number)
+	}
+
+	s := &MergeState{
+		ID:               pr.ID,
+		Number:           pr.Number,
+		Title:            pr.Title,
+		State:            pr.State,
+		IsDraft:          pr.IsDraft,
+		HeadRef:          pr.HeadRefName,
+		HeadSHA:          pr.HeadRefOid,
+		HeadOwner:        pr.HeadRepositoryOwner.Login,
+		Mergeable:        pr.Mergeable,
+		MergeStateStatus: pr.MergeStateStatus,
+		ReviewDecision:   pr.ReviewDecision,
+		AutoMerge:        pr.AutoMergeRequest != nil,
+	}
+
+	if resp.Repository.MergeCommitAllowed {
+		s.Methods = append(s.Methods, MergeMethodMerge)
+	}
+	if resp.Repository.SquashMergeAllowed {
+		s.Methods = append(s.Methods, MergeMethodSquash)
+	}
+	if resp.Repository.RebaseMergeAllowed {
+		s.Methods = append(s.Methods, MergeMethodRebase)
+	}
+
+	for _, n := range pr.Commits.Nodes {
+		if n.Commit.StatusCheckRollup == nil {
+			continue
+		}
+		for _, c := range n.Commit.StatusCheckRollup.Contexts.Nodes {
+			check := MergeCheck{Required: c.IsRequired}
+			if c.Typename == "StatusContext" {
+				check.Name, check.State = c.Context, statusContextState(c.State)
+			} else {
+				check.Name, check.State = c.Name, checkRunState(c.Status, c.Conclusion)
+			}
+			s.Checks = append(s.Checks, check)
+		}
+	}
+
+	return s, nil
+}
+
+func checkRunState(status, conclusion string) string {
+	if status != "COMPLETED" {
+		return CheckPending
+	}
+	switch conclusion {
+	case "SUCCESS", "NEUTRAL", "SKIPPED":
+		return CheckSuccess
+	}
+	return CheckFailure
+}
+
+func statusContextState(state string) string {
+	switch state {
+	case "SUCCESS":
+		return CheckSuccess
+	case "PENDING", "EXPECTED":
+		return CheckPending
+	}
+	return CheckFailure
+}
+
+// CheckCounts returns the number of passed, pending and failed checks
+func (s *MergeState) CheckCounts() (passed, pending, failed int) {
+	for _, c := range s.Checks {
+		switch c.State {
+		case CheckSuccess:
+			passed++
+		case CheckPending:
+			pending++
+		default:
+			failed++
+		}
+	}
+	return passed, pending, failed
+}
+
+// Blockers lists why the pull request cannot be merged right now; it is empty when it can
+func (s *MergeState) Blockers() []MergeBlocker {
+	switch s.State {
+	case "MERGED":
+		return []MergeBlocker{{Code: BlockerMerged}}
+	case "CLOSED":
+		return []MergeBlocker{{Code: BlockerClosed}}
+	}
+
+	var blockers []MergeBlocker
+	if s.IsDraft {
+		blockers = append(blockers, MergeBlocker{Code: BlockerDraft})
+	}
+	if s.Mergeable == "CONFLICTING" || s.MergeStateStatus == "DIRTY" {
+		blockers = append(blockers, MergeBlocker{Code: BlockerConflicts})
+	}
+
+	switch s.ReviewDecision {
+	case "CHANGES_REQUESTED":
+		blockers = append(blockers, MergeBlocker{Code: BlockerChangesRequested})
+	case "REVIEW_REQUIRED":
+		blockers = append(blockers, MergeBlocker{Code: BlockerReviewRequired})
+	}
+
+	var failed, pending []string
+	for _, c := range s.Checks {
+		if !c.Required {
+			continue
+		}
+		switch c.State {
+		case CheckFailure:
+			failed = append(failed, c.Name)
+		case CheckPending:
+			pending = append(pending, c.Name)
+		}
+	}
+	if len(failed) > 0 {
+		blockers = append(blockers, MergeBlocker{Code: BlockerChecksFailed, Checks: failed})
+	}
+	if len(pending) > 0 {
+		blockers = append(blockers, MergeBlocker{Code: BlockerChecksPending, Checks: pending})
+	}
+
+	switch s.MergeStateStatus {
+	case "BEHIND":
+		blockers = append(blockers, MergeBlocker{Code: BlockerBehind})
+	case "BLOCKED":
+		// Blocked by something not covered above, such as rulesets or signed commits
+		if len(blockers) == 0 {
+			blockers = append(blockers, MergeBlocker{Code: BlockerBlocked})
+		}
+	case "UNKNOWN":
+		if s.Mergeable == "UNKNOWN" && len(blockers) == 0 {
+			blockers = append(blockers, MergeBlocker{Code: BlockerUnknown})
+		}
+	}
+
+	return blockers
+}
+
+// CanAutoMerge reports whether the blockers would clear by themselves, so auto-merge makes sense.
+// An out of date branch needs an update first, and an unknown state is not known to clear at all.
+func CanAutoMerge(blockers []MergeBlocker) bool {
+	if len(blockers) == 0 {
+		return false
+	}
+	for _, b := range blockers {
+		switch b.Code {
+		case BlockerClosed, BlockerMerged, BlockerDraft, BlockerConflicts, BlockerBehind, BlockerUnknown:
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultMethod returns the preferred merge method if the repository allows it, otherwise the first allowed one
+func (s *MergeState) DefaultMethod(preferred string) string {
+	for _, m := range s.Methods {
+		if m == preferred {
+			return m
+		}
+	}
+	if len(s.Methods) > 0 {
+		return s.Methods[0]
+	}
+	return MergeMethodMerge
+}
+
+// CommitTitle returns the title of the commit GitHub creates for a merge method.
+// Rebasing keeps the original commits, so it has none.
+func (s *MergeState) CommitTitle(method string) string {
+	switch method {
+	case MergeMethodSquash:
+		return fmt.Sprintf("%s (#%d)", s.Title, s.Number)
+	case MergeMethodMerge:
+		return fmt.Sprintf("Merge pull request #%d from %s/%s", s.Number, s.HeadOwner, s.HeadRef)
+	}
+	return ""
+}
+
+const enableAutoMergeMutation = `mutation($id: ID!, $method: PullRequestMergeMethod!, $headline: String) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, commitHeadline: $headline}) {
+    pullRequest { autoMergeRequest { enabledAt } }
+  }
+}`
+
+// EnableAutoMerge turns on auto-merge, so GitHub merges the pull request once its requirements are met
+func EnableAutoMerge(ctx context.Context, client *github.Client, s *MergeState, method string) error {
+	vars := map[string]interface{}{"id": s.ID, "method": strings.ToUpper(method)}
+	if title := s.CommitTitle(method); title != "" {
+		vars["headline"] = title
+	}
+	return GraphQL(ctx, client, enableAutoMergeMutation, vars, nil)
+}
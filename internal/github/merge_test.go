@@ -0,0 +1,160 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func blockerCodes(blockers []MergeBlocker) []string {
+	var codes []string
+	for _, b := range blockers {
+		codes = append(codes, b.Code)
+	}
+	return codes
+}
+
+func TestMergeStateBlockers(t *testing.T) {
+	clean := MergeState{State: "OPEN", Mergeable: "MERGEABLE", MergeStateStatus: "CLEAN"}
+
+	tests := []struct {
+		name string
+		edit func(s *MergeState)
+		want []string
+		auto bool
+	}{
+		{"clean", func(s *MergeState) {}, nil, false},
+		{"merged", func(s *MergeState) { s.State = "MERGED" }, []string{BlockerMerged}, false},
+		{"draft", func(s *MergeState) { s.IsDraft = true; s.MergeStateStatus = "DRAFT" }, []string{BlockerDraft}, false},
+		{"conflicts", func(s *MergeState) { s.Mergeable = "CONFLICTING"; s.MergeStateStatus = "DIRTY" }, []string{BlockerConflicts}, false},
+		{"review required", func(s *MergeState) { s.ReviewDecision = "REVIEW_REQUIRED"; s.MergeStateStatus = "BLOCKED" }, []string{BlockerReviewRequired}, true},
+		{"behind", func(s *MergeState) { s.MergeStateStatus = "BEHIND" }, []string{BlockerBehind}, false},
+		{"ruleset", func(s *MergeState) { s.MergeStateStatus = "BLOCKED" }, []string{BlockerBlocked}, true},
+		{"computing", func(s *MergeState) { s.Mergeable = "UNKNOWN"; s.MergeStateStatus = "UNKNOWN" }, []string{BlockerUnknown}, false},
+		{"checks", func(s *MergeState) {
+			s.MergeStateStatus = "BLOCKED"
+			s.Checks = []MergeCheck{
+				{Name: "build", State: CheckFailure, Required: true},
+				{Name: "lint", State: CheckPending, Required: true},
+				{Name: "optional", State: CheckFailure},
+			}
+		}, []string{BlockerChecksFailed, BlockerChecksPending}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := clean
+			tt.edit(&s)
+			blockers := s.Blockers()
+			if got := blockerCodes(blockers); !slices.Equal(got, tt.want) {
+				t.Errorf("Blockers() = %v, want %v", got, tt.want)
+			}
+			if got := CanAutoMerge(blockers); got != tt.auto {
+				t.Errorf("CanAutoMerge() = %v, want %v", got, tt.auto)
+			}
+		})
+	}
+
+	s := clean
+	s.Checks = []MergeCheck{{Name: "build", State: CheckFailure, Required: true}, {Name: "optional", State: CheckFailure}}
+	if b := s.Blockers(); len(b) != 1 || !slices.Equal(b[0].Checks, []string{"build"}) {
+		t.Errorf("only required checks should block: %+v", b)
+	}
+}
+
+func TestMergeStateMethods(t *testing.T) {
+	s := MergeState{Number: 7, Title: "Fix settings crash", HeadOwner: "octo", HeadRef: "fix/crash", Methods: []string{MergeMethodSquash, MergeMethodRebase}}
+
+	if got := s.DefaultMethod(""); got != MergeMethodSquash {
+		t.Errorf("DefaultMethod(\"\") = %q, want squash", got)
+	}
+	if got := s.DefaultMethod(MergeMethodRebase); got != MergeMethodRebase {
+		t.Errorf("DefaultMethod(rebase) = %q", got)
+	}
+	if got := s.DefaultMethod(MergeMethodMerge); got != MergeMethodSquash {
+		t.Errorf("disallowed method should fall back: %q", got)
+	}
+
+	for method, want := range map[string]string{
+		MergeMethodSquash: "Fix settings crash (#7)",
+		MergeMethodMerge:  "Merge pull request #7 from octo/fix/crash",
+		MergeMethodRebase: "",
+	} {
+		if got := s.CommitTitle(method); got != want {
+			t.Errorf("CommitTitle(%s) = %q, want %q", method, got, want)
+		}
+	}
+}
+
+func newGraphQLTestClient(t *testing.T, handler func(query string, vars map[string]interface{}) string) *github.Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" || r.Method != http.MethodPost {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(handler(body.Query, body.Variables)))
+	}))
+	t.Cleanup(srv.Close)
+
+	base := srv.URL + "/"
+	client, err := github.NewClient(github.WithURLs(&base, &base))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestFetchMergeState(t *testing.T) {
+	client := newGraphQLTestClient(t, func(query string, vars map[string]interface{}) string {
+		if vars["owner"] != "octo" || vars["repo"] != "app" || vars["number"] != float64(7) {
+			t.Errorf("unexpected variables %v", vars)
+		}
+		return `{"data":{"repository":{"mergeCommitAllowed":false,"squashMergeAllowed":true,"rebaseMergeAllowed":true,
+			"pullRequest":{"id":"PR_1","number":7,"title":"Fix","state":"OPEN","isDraft":false,"headRefName":"fix","headRefOid":"abc123",
+			"headRepositoryOwner":{"login":"octo"},"mergeable":"MERGEABLE","mergeStateStatus":"BLOCKED","reviewDecision":"APPROVED",
+			"autoMergeRequest":null,"commits":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+				{"__typename":"CheckRun","name":"build","status":"COMPLETED","conclusion":"FAILURE","isRequired":true},
+				{"__typename":"StatusContext","context":"ci/lint","state":"PENDING","isRequired":false}
+			]}}}}]}}}}}`
+	})
+
+	s, err := FetchMergeState(context.Background(), client, "octo", "app", 7)
+	if err != nil {
+		t.Fatalf("FetchMergeState() error = %v", err)
+	}
+	if s.ID != "PR_1" || s.HeadSHA != "abc123" || s.AutoMerge || !slices.Equal(s.Methods, []string{MergeMethodSquash, MergeMethodRebase}) {
+		t.Errorf("unexpected state %+v", s)
+	}
+	want := []MergeCheck{{Name: "build", State: CheckFailure, Required: true}, {Name: "ci/lint", State: CheckPending}}
+	if !slices.Equal(s.Checks, want) {
+		t.Errorf("Checks = %+v, want %+v", s.Checks, want)
+	}
+	if passed, pending, failed := s.CheckCounts(); passed != 0 || pending != 1 || failed != 1 {
+		t.Errorf("CheckCounts() = %d, %d, %d", passed, pending, failed)
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	client := newGraphQLTestClient(t, func(string, map[string]interface{}) string {
+		return `{"data":null,"errors":[{"message":"Pull request is in clean status"}]}`
+	})
+
+	err := EnableAutoMerge(context.Background(), client, &MergeState{ID: "PR_1", Number: 7, Title: "Fix"}, MergeMethodSquash)
+	gqlErr, ok := err.(*GraphQLError)
+	if !ok || len(gqlErr.Messages) != 1 || gqlErr.Messages[0] != "Pull request is in clean status" {
+		t.Errorf("EnableAutoMerge() error = %v, want GraphQLError", err)
+	}
+}
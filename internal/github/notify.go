@@ -0,0 +1,102 @@
+package github
+
+import (
+	"time"
+
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// notificationKind maps an incoming event to the key used for per-event sound settings.
+// Failed CI on the default branch is reported as CIFailureEvent so it can be configured separately.
+func notificationKind(eventType string, event interface{}) string {
+	var branch, defaultBranch string
+	failed := false
+
+	switch e := event.(type) {
+	case *github.WorkflowRunEvent:
+		run := e.GetWorkflowRun()
+		branch, defaultBranch = run.GetHeadBranch(), e.GetRepo().GetDefaultBranch()
+		failed = run.GetConclusion() == "failure"
+	case *github.WorkflowJobEvent:
+		job := e.GetWorkflowJob()
+		branch, defaultBranch = job.GetHeadBranch(), e.GetRepo().GetDefaultBranch()
+		failed = job.GetConclusion() == "failure"
+	case *github.CheckSuiteEvent:
+		suite := e.GetCheckSuite()
+		branch, defaultBranch = suite.GetHeadBranch(), e.GetRepo().GetDefaultBranch()
+		failed = suite.GetConclusion() == "failure"
+	case *github.CheckRunEvent:
+		check := e.GetCheckRun()
+		branch, defaultBranch = check.GetCheckSuite().GetHeadBranch(), e.GetRepo().GetDefaultBranch()
+		failed = check.GetConclusion() == "failure"
+	case *github.StatusEvent:
+		defaultBranch = e.GetRepo().GetDefaultBranch()
+		failed = e.GetState() == "failure" || e.GetState() == "error"
+		for _, b := range e.Branches {
+			if b.GetName() == defaultBranch {
+				branch = defaultBranch
+				break
+			}
+		}
+	}
+
+	if failed && branch != "" && branch == defaultBranch {
+		return CIFailureEvent.Name
+	}
+	return eventType
+}
+
+// deliveryMode decides how a notification of the given kind should be sent at time now.
+// It returns whether the message should be silent and, when quiet hours defer delivery, the time to send it.
+func deliveryMode(settings models.ChatSettings, kind string, now time.Time) (bool, time.Time) {
+	switch settings.EventSound[kind] {
+	case models.SoundLoud:
+		return false, time.Time{}
+	case models.SoundSilent:
+		return true, time.Time{}
+	}
+
+	end, active := quietWindow(settings.QuietHours, now)
+	if !active {
+		return false, time.Time{}
+	}
+
+	if settings.QuietHours.Defer {
+		return false, end
+	}
+	return true, time.Time{}
+}
+
+// quietWindow reports whether now falls inside the quiet hours and when the window ends.
+func quietWindow(q models.QuietHours, now time.Time) (time.Time, bool) {
+	if !q.Enabled || q.Start == q.End {
+		return time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := now.In(loc)
+	hour := local.Hour()
+
+	var active bool
+	if q.Start < q.End {
+		active = hour >= q.Start && hour < q.End
+	} else {
+		active = hour >= q.Start || hour < q.End
+	}
+
+	if !active {
+		return time.Time{}, false
+	}
+
+	end := time.Date(local.Year(), local.Month(), local.Day(), q.End, 0, 0, 0, loc)
+	if !end.After(local) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, true
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github-webhook/internal/models"
+)
+
+func TestQuietWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       models.QuietHours
+		now     time.Time
+		active  bool
+		wantEnd time.Time
+	}{
+		{
+			name: "Disabled",
+			q:    models.QuietHours{Enabled: false, Start: 22, End: 7},
+			now:  time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Wraps midnight, before midnight",
+			q:       models.QuietHours{Enabled: true, Start: 22, End: 7, Timezone: "UTC"},
+			now:     time.Date(2024, 1, 1, 23, 30, 0, 0, time.UTC),
+			active:  true,
+			wantEnd: time.Date(2024, 1, 2, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Wraps midnight, after midnight",
+			q:       models.QuietHours{Enabled: true, Start: 22, End: 7, Timezone: "UTC"},
+			now:     time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC),
+			active:  true,
+			wantEnd: time.Date(2024, 1, 2, 7, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Outside window",
+			q:    models.QuietHours{Enabled: true, Start: 22, End: 7, Timezone: "UTC"},
+			now:  time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Same-day window",
+			q:       models.QuietHours{Enabled: true, Start: 12, End: 14, Timezone: "UTC"},
+			now:     time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC),
+			active:  true,
+			wantEnd: time.Date(2024, 1, 2, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "Timezone applied",
+			q:       models.QuietHours{Enabled: true, Start: 22, End: 7, Timezone: "Asia/Tokyo"},
+			now:     time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC), // 23:00 in Tokyo
+			active:  true,
+			wantEnd: time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC), // 07:00 in Tokyo
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			end, active := quietWindow(tt.q, tt.now)
+			if active != tt.active {
+				t.Fatalf("quietWindow() active = %v, want %v", active, tt.active)
+			}
+			if active && !end.Equal(tt.wantEnd) {
+				t.Errorf("quietWindow() end = %v, want %v", end, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestDeliveryMode(t *testing.T) {
+	night := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	day := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	quiet := models.QuietHours{Enabled: true, Start: 22, End: 7, Timezone: "UTC"}
+
+	settings := models.ChatSettings{
+		QuietHours: quiet,
+		EventSound: map[string]string{
+			"star":              models.SoundSilent,
+			CIFailureEvent.Name: models.SoundLoud,
+		},
+	}
+
+	if silent, until := deliveryMode(settings, "push", day); silent || !until.IsZero() {
+		t.Errorf("push during the day should be loud and immediate")
+	}
+	if silent, _ := deliveryMode(settings, "push", night); !silent {
+		t.Errorf("push during quiet hours should be silent")
+	}
+	if silent, _ := deliveryMode(settings, "star", day); !silent {
+		t.Errorf("star should always be silent")
+	}
+	if silent, until := deliveryMode(settings, CIFailureEvent.Name, night); silent || !until.IsZero() {
+		t.Errorf("CI failure should always be loud")
+	}
+
+	settings.QuietHours.Defer = true
+	if _, until := deliveryMode(settings, "push", night); until.IsZero() {
+		t.Errorf("push during quiet hours should be deferred")
+	}
+}
@@ -23,7 +23,7 @@ func NewOAuth(cfg *config.Config) *OAuth {
 			ClientID:     cfg.GitHubClientID,
 			ClientSecret: cfg.GitHubClientSecret,
 			Endpoint:     github.Endpoint,
-			Scopes:       []string{"repo", "admin:repo_hook", "read:user"},
+			Scopes:       []string{"repo", "admin:repo_hook", "admin:org_hook", "read:user"},
 			RedirectURL:  cfg.TelegramWebhookURL + "/oauth/callback",
 		},
 	}
@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// OrgLinkName returns the name an organization webhook is linked under; it stands for every repository of the org
+func OrgLinkName(org string) string {
+	return org + "/*"
+}
+
+// splitLink returns the owner and repository of a link, the repository being "*" for organization links
+func splitLink(link models.RepoLink) (owner, repo string) {
+	owner, repo, _ = strings.Cut(link.RepoFullName, "/")
+	return owner, repo
+}
+
+// GetLinkHook fetches the webhook of a link from its repository or organization
+func GetLinkHook(ctx context.Context, client *github.Client, link models.RepoLink) (*github.Hook, error) {
+	owner, repo := splitLink(link)
+	var hook *github.Hook
+	var err error
+	if link.Org {
+		hook, _, err = client.Organizations.GetHook(ctx, owner, link.WebhookID)
+	} else {
+		hook, _, err = client.Repositories.GetHook(ctx, owner, repo, link.WebhookID)
+	}
+	return hook, err
+}
+
+// EditLinkHook updates the webhook of a link
+func EditLinkHook(ctx context.Context, client *github.Client, link models.RepoLink, hook *github.Hook) error {
+	owner, repo := splitLink(link)
+	var err error
+	if link.Org {
+		_, _, err = client.Organizations.EditHook(ctx, owner, link.WebhookID, hook)
+	} else {
+		_, _, err = client.Repositories.EditHook(ctx, owner, repo, link.WebhookID, hook)
+	}
+	return err
+}
+
+// DeleteLinkHook deletes the webhook of a link
+func DeleteLinkHook(ctx context.Context, client *github.Client, link models.RepoLink) error {
+	owner, repo := splitLink(link)
+	var err error
+	if link.Org {
+		_, err = client.Organizations.DeleteHook(ctx, owner, link.WebhookID)
+	} else {
+		_, err = client.Repositories.DeleteHook(ctx, owner, repo, link.WebhookID)
+	}
+	return err
+}
+
+// LinkHookSettingsURL returns the GitHub settings page of a link's webhook
+func LinkHookSettingsURL(link models.RepoLink) string {
+	owner, repo := splitLink(link)
+	if link.Org {
+		return fmt.Sprintf("https://github.com/organizations/%s/settings/hooks/%d", owner, link.WebhookID)
+	}
+	return fmt.Sprintf("https://github.com/%s/%s/settings/hooks/%d", owner, repo, link.WebhookID)
+}
+
+// ParseRepoPatterns splits /addorg arguments into include and exclude patterns; exclusions start with "-".
+// It reports false when a pattern is not a valid glob.
+func ParseRepoPatterns(args []string) (include, exclude []string, ok bool) {
+	for _, a := range args {
+		p, excluded := strings.CutPrefix(a, "-")
+		if p == "" {
+			return nil, nil, false
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, nil, false
+		}
+		if excluded {
+			exclude = append(exclude, p)
+		} else {
+			include = append(include, p)
+		}
+	}
+	return include, exclude, true
+}
+
+// MatchesRepoPatterns reports whether a repository name passes an organization link's patterns.
+// Without include patterns every repository is included; exclusions win over inclusions.
+func MatchesRepoPatterns(name string, include, exclude []string) bool {
+	match := func(patterns []string) bool {
+		for _, p := range patterns {
+			if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(name)); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if match(exclude) {
+		return false
+	}
+	return len(include) == 0 || match(include)
+}
+
+// eventRepo returns the owner/name and name of the repository an event is about,
+// empty for organization-wide events
+func eventRepo(event interface{}) (fullName, name string) {
+	switch e := event.(type) {
+	case *github.PushEvent:
+		return e.GetRepo().GetFullName(), e.GetRepo().GetName()
+	case interface{ GetRepo() *github.Repository }:
+		return e.GetRepo().GetFullName(), e.GetRepo().GetName()
+	case interface{ GetRepository() *github.Repository }:
+		return e.GetRepository().GetFullName(), e.GetRepository().GetName()
+	}
+	return "", ""
+}
+
+// skipOrgDelivery reports whether an event delivered by an organization webhook should be dropped:
+// its repository is filtered out by the link's patterns, or the chat also links that repository
+// directly and already gets the event from its own webhook
+func skipOrgDelivery(links []models.RepoLink, hookID int64, event interface{}) (org bool, skip bool) {
+	var link *models.RepoLink
+	for i := range links {
+		if links[i].Org && links[i].WebhookID == hookID {
+			link = &links[i]
+			break
+		}
+	}
+	if link == nil {
+		return false, false
+	}
+
+	fullName, name := eventRepo(event)
+	if fullName == "" {
+		return true, false
+	}
+	for _, l := range links {
+		if !l.Org && strings.EqualFold(l.RepoFullName, fullName) {
+			return true, true
+		}
+	}
+	return true, !MatchesRepoPatterns(name, link.Include, link.Exclude)
+}
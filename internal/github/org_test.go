@@ -0,0 +1,74 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestParseRepoPatterns(t *testing.T) {
+	include, exclude, ok := ParseRepoPatterns([]string{"api-*", "web", "-api-legacy*"})
+	if !ok || strings.Join(include, " ") != "api-* web" || strings.Join(exclude, " ") != "api-legacy*" {
+		t.Errorf("ParseRepoPatterns() = %v, %v, %v", include, exclude, ok)
+	}
+	for _, bad := range []string{"-", "api-[", ""} {
+		if _, _, ok := ParseRepoPatterns([]string{bad}); ok {
+			t.Errorf("ParseRepoPatterns(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMatchesRepoPatterns(t *testing.T) {
+	tests := []struct {
+		name             string
+		include, exclude []string
+		want             bool
+	}{
+		{"api", nil, nil, true},
+		{"API-Gateway", []string{"api-*"}, nil, true},
+		{"web", []string{"api-*"}, nil, false},
+		{"api-legacy", []string{"api-*"}, []string{"*-legacy"}, false},
+		{"docs", nil, []string{"*-legacy"}, true},
+	}
+	for _, tt := range tests {
+		if got := MatchesRepoPatterns(tt.name, tt.include, tt.exclude); got != tt.want {
+			t.Errorf("MatchesRepoPatterns(%q, %v, %v) = %v, want %v", tt.name, tt.include, tt.exclude, got, tt.want)
+		}
+	}
+}
+
+func TestSkipOrgDelivery(t *testing.T) {
+	links := []models.RepoLink{
+		{RepoFullName: "octo-org/*", WebhookID: 1, Org: true, Exclude: []string{"sandbox-*"}},
+		{RepoFullName: "octo-org/api", WebhookID: 2},
+	}
+	push := func(name string) *github.PushEvent {
+		return &github.PushEvent{Repo: &github.PushEventRepository{Name: github.Ptr(name), FullName: github.Ptr("octo-org/" + name)}}
+	}
+	repoEvent := func(name string) *github.StarEvent {
+		return &github.StarEvent{Repo: &github.Repository{Name: github.Ptr(name), FullName: github.Ptr("octo-org/" + name)}}
+	}
+
+	tests := []struct {
+		name      string
+		hookID    int64
+		event     interface{}
+		org, skip bool
+	}{
+		{"repository webhook", 2, repoEvent("api"), false, false},
+		{"new repository", 1, repoEvent("web"), true, false},
+		{"excluded repository", 1, repoEvent("sandbox-1"), true, true},
+		{"repository linked on its own", 1, repoEvent("api"), true, true},
+		{"organization event", 1, &github.TeamEvent{Action: github.Ptr("created")}, true, false},
+		{"push", 1, push("web"), true, false},
+		{"excluded push", 1, push("sandbox-2"), true, true},
+	}
+	for _, tt := range tests {
+		if org, skip := skipOrgDelivery(links, tt.hookID, tt.event); org != tt.org || skip != tt.skip {
+			t.Errorf("%s: skipOrgDelivery() = %v, %v, want %v, %v", tt.name, org, skip, tt.org, tt.skip)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github-webhook/internal/i18n"
+	"github-webhook/internal/models"
+	"github-webhook/internal/utils"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/google/go-github/v89/github"
+)
+
+// personalTTL is how long a personal notification is remembered, so an event delivered
+// to several linked chats reaches each user only once
+const personalTTL = 10 * time.Minute
+
+// maxPersonalBody is the number of characters of a mentioning body quoted in a personal notification
+const maxPersonalBody = 500
+
+// notifyUsers sends a private message to every connected user an event requests a review from,
+// assigns, or mentions, unless they turned that kind of notification off or the event
+// was delivered to their own private chat anyway. Users whose own token cannot read the repository
+// are skipped, so nothing of a private repository reaches someone without access.
+func (s *WebhookServer) notifyUsers(eventType string, event interface{}, chatID int64) {
+	targets := MentionTargets(event)
+	if len(targets) == 0 {
+		return
+	}
+
+	data := NewTemplateData(eventType, event)
+	for _, m := range s.resolveMentions(targets) {
+		if m.User.ID == chatID || slices.Contains(m.User.DMOff, m.Reason) {
+			continue
+		}
+		if !s.claimPersonal(fmt.Sprintf("%d:%s:%s:%s", m.User.ID, m.Reason, data.Action, data.URL)) {
+			continue
+		}
+		if !s.canRead(m.User, data.Repo) {
+			continue
+		}
+		s.sendPersonal(m, data, event)
+	}
+}
+
+// claimPersonal reports whether a personal notification has not been sent yet and marks it as sent
+func (s *WebhookServer) claimPersonal(key string) bool {
+	s.personalMu.Lock()
+	defer s.personalMu.Unlock()
+
+	if _, ok := s.personalSent.Get(key); ok {
+		return false
+	}
+	s.personalSent.Set(key, true, personalTTL)
+	return true
+}
+
+// canRead reports whether a user's own GitHub token can read a repository.
+// Answers are remembered for personalTTL so a busy repository is not checked on every event.
+func (s *WebhookServer) canRead(user models.User, repo string) bool {
+	key := fmt.Sprintf("%d:%s", user.ID, strings.ToLower(repo))
+	if ok, cached := s.readAccess.Get(key); cached {
+		return ok
+	}
+	ok := s.checkRead(user, repo)
+	s.readAccess.Set(key, ok, personalTTL)
+	return ok
+}
+
+func (s *WebhookServer) checkRead(user models.User, repo string) bool {
+	owner, name, found := strings.Cut(repo, "/")
+	if !found || user.EncryptedOAuthToken == "" {
+		return false
+	}
+	token, err := utils.Decrypt(user.EncryptedOAuthToken, s.Config.EncryptionKey)
+	if err != nil {
+		log.Printf("Failed to decrypt token of user %d: %v", user.ID, err)
+		return false
+	}
+	client, err := s.Clients.GetUserClient(context.Background(), token)
+	if err != nil {
+		return false
+	}
+	if _, _, err := client.Repositories.Get(context.Background(), owner, name); err != nil {
+		log.Printf("Skipping personal notification to %d: cannot read %s: %v", user.ID, repo, err)
+		return false
+	}
+	return true
+}
+
+func (s *WebhookServer) sendPersonal(m resolvedMention, data TemplateData, event interface{}) {
+	settings, _ := s.DB.GetChatSettings(context.Background(), m.User.ID)
+	lang := i18n.Resolve(settings.Language)
+	msg := FormatPersonalNotification(lang, m.Reason, data)
+
+	var kb [][]gotgbot.InlineKeyboardButton
+	row := []gotgbot.InlineKeyboardButton{{Text: i18n.T(lang, "personal.view"), Url: data.URL}}
+	if e, ok := event.(*github.PullRequestEvent); ok && m.Reason == MentionReview {
+		if id, err := GenerateState(); err == nil {
+			s.ActionCache.Set(id, models.PRActionContext{
+				Owner:    e.GetRepo().GetOwner().GetLogin(),
+				Repo:     e.GetRepo().GetName(),
+				PRNumber: e.GetPullRequest().GetNumber(),
+			}, 48*time.Hour)
+			row = append(row, gotgbot.InlineKeyboardButton{Text: i18n.T(lang, "personal.approve"), CallbackData: "act:approve:" + id})
+		}
+	}
+	kb = append(kb, row)
+	kb = append(kb, []gotgbot.InlineKeyboardButton{{Text: i18n.T(lang, "personal.off"), CallbackData: "me:off:" + m.Reason}})
+
+	sent, err := s.Bot.SendMessage(m.User.ID, msg, &gotgbot.SendMessageOpts{
+		ParseMode:          "MarkdownV2",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{IsDisabled: true},
+		ReplyMarkup:        gotgbot.InlineKeyboardMarkup{InlineKeyboard: kb},
+	})
+	if err != nil {
+		// Usually the user never started the bot in private chat or blocked it
+		log.Printf("Failed to send personal notification to %d: %v", m.User.ID, err)
+		return
+	}
+
+	s.storeMessageContext(sent.MessageId, m.User.ID, event)
+}
+
+// FormatPersonalNotification renders the private message sent to a user for a mention reason, in their language
+func FormatPersonalNotification(lang string, reason string, data TemplateData) string {
+	text := data.Title
+	if data.Number != 0 {
+		text = fmt.Sprintf("#%d %s", data.Number, data.Title)
+	}
+
+	msg := fmt.Sprintf(
+		"%s\n[%s](%s)\n\n"+
+			"*Repository:* %s\n"+
+			"*By:* %s\n",
+		i18n.T(lang, "personal."+reason),
+		EscapeMarkdownV2(text),
+		EscapeMarkdownV2URL(data.URL),
+		FormatRepo(data.Repo),
+		FormatUser(data.Sender),
+	)
+
+	if reason == MentionMention && data.Body != "" {
+		body := []rune(data.Body)
+		if len(body) > maxPersonalBody {
+			body = append(body[:maxPersonalBody], '…')
+		}
+		msg += "\n" + FormatReleaseBody(string(body))
+	}
+
+	return i18n.Localize(lang, normalizeMessage(msg), nil)
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatPersonalNotification(t *testing.T) {
+	data := NewTemplateData("pull_request", SampleEvent("pull_request"))
+
+	msg := FormatPersonalNotification("en", MentionReview, data)
+	for _, want := range []string{"*👀 Review requested*", "[\\#7 Fix settings crash](", FormatRepo(data.Repo), FormatUser(data.Sender)} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("FormatPersonalNotification() missing %q in %q", want, msg)
+		}
+	}
+	if strings.Contains(msg, ">") {
+		t.Errorf("review request should not quote the body: %q", msg)
+	}
+
+	data.Body = strings.Repeat("a", maxPersonalBody+100)
+	msg = FormatPersonalNotification("en", MentionMention, data)
+	if !strings.Contains(msg, "*💬 You were mentioned*") || !strings.Contains(msg, "…") {
+		t.Errorf("mention should quote a truncated body: %q", msg)
+	}
+	if strings.Count(msg, "a") > maxPersonalBody+10 {
+		t.Errorf("body not truncated: %d characters", strings.Count(msg, "a"))
+	}
+}
+
+func TestFormatPersonalNotificationLocalized(t *testing.T) {
+	data := NewTemplateData("pull_request", SampleEvent("pull_request"))
+
+	msg := FormatPersonalNotification("es", MentionAssign, data)
+	for _, want := range []string{"*📌 Te han asignado*", "*Repositorio:*", "*Por:*"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("FormatPersonalNotification(es) missing %q in %q", want, msg)
+		}
+	}
+}
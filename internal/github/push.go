@@ -0,0 +1,122 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github-webhook/internal/cache"
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/google/go-github/v89/github"
+)
+
+// pushGroup is a run of pushes to one branch that share a single notification
+type pushGroup struct {
+	mu        sync.Mutex
+	MessageID int64
+	Pushes    []*github.PushEvent
+}
+
+// PushCoalescer merges pushes arriving within a short window into one edited message
+type PushCoalescer struct {
+	mu     sync.Mutex
+	groups *cache.Cache[string, *pushGroup] // Key: "chat_id:repo:ref"
+}
+
+func NewPushCoalescer() *PushCoalescer {
+	return &PushCoalescer{groups: cache.New[string, *pushGroup]()}
+}
+
+// join adds a push to the branch's open group and returns it locked, or a new locked group when the window has passed.
+// Each push extends the window, so a burst of pushes keeps editing the same message.
+func (p *PushCoalescer) join(chatID int64, event *github.PushEvent, window time.Duration) *pushGroup {
+	key := fmt.Sprintf("%d:%s:%s", chatID, event.GetRepo().GetFullName(), event.GetRef())
+
+	p.mu.Lock()
+	group, ok := p.groups.Get(key)
+	if !ok {
+		group = &pushGroup{}
+	}
+	p.groups.Set(key, group, window)
+	p.mu.Unlock()
+
+	group.mu.Lock()
+	group.Pushes = append(group.Pushes, event)
+	return group
+}
+
+// maxPushCommits is how many of the latest commits of a push notification can be commented on
+const maxPushCommits = 20
+
+// PushContext returns the reply context of a push notification: the commit of a single-commit push,
+// or the latest commits to pick from. It reports false when the pushes carry no commits.
+func PushContext(events []*github.PushEvent) (models.MessageContext, bool) {
+	last := events[len(events)-1]
+	owner, repo, _ := strings.Cut(last.GetRepo().GetFullName(), "/")
+	ctx := models.MessageContext{Owner: owner, Repo: repo, Type: "push"}
+
+	var commits []models.CommitRef
+	for _, e := range events {
+		for _, c := range pushCommits(e) {
+			title, _, _ := strings.Cut(c.GetMessage(), "\n")
+			commits = append(commits, models.CommitRef{SHA: c.GetID(), Title: title})
+		}
+	}
+	switch {
+	case len(commits) == 0:
+		return ctx, false
+	case len(commits) == 1:
+		ctx.CommitSHA = commits[0].SHA
+	default:
+		ctx.Commits = commits[max(0, len(commits)-maxPushCommits):]
+	}
+	return ctx, true
+}
+
+// FormatCoalescedPushEvents renders several consecutive pushes to the same branch as one notification
+func FormatCoalescedPushEvents(events []*github.PushEvent) (string, *gotgbot.InlineKeyboardMarkup) {
+	if len(events) == 1 {
+		return FormatPushEvent(events[0])
+	}
+
+	first, last := events[0], events[len(events)-1]
+	repo := last.Repo.GetName()
+	repoURL := last.Repo.GetHTMLURL()
+	branch := strings.TrimPrefix(last.GetRef(), "refs/heads/")
+
+	var body string
+	commitCount, forced := 0, false
+	for _, event := range events {
+		if event.GetForced() {
+			body += formatForcePush(event)
+			forced = true
+		}
+		for _, commit := range pushCommits(event) {
+			body += formatPushCommit(commit, repoURL)
+			commitCount++
+		}
+	}
+	if commitCount == 0 && !forced {
+		return "", nil
+	}
+
+	msg := pushHeadline(commitCount, repo, branch) + fmt.Sprintf(" _\\(%d pushes\\)_\n\n", len(events))
+	if first.GetCreated() {
+		msg += "🌱 _New branch created_\n"
+	}
+	msg += body
+
+	if len(msg) > 4000 {
+		msg = pushHeadline(commitCount, repo, branch) + fmt.Sprintf(" _\\(%d pushes\\)_\n\n", len(events)) +
+			"⚠️ _Too many commits to display, check the repository for details\\._\n"
+	}
+
+	compareURL := last.GetCompare()
+	if before := first.GetBefore(); !first.GetCreated() && before != "" && last.GetAfter() != "" {
+		compareURL = fmt.Sprintf("%s/compare/%s...%s", repoURL, before, last.GetAfter())
+	}
+	return FormatMessageWithButton(msg, "View Commits", compareURL)
+}
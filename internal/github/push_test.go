@@ -0,0 +1,156 @@
+package github
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github-webhook/internal/models"
+
+	"github.com/google/go-github/v89/github"
+)
+
+func TestPushContext(t *testing.T) {
+	push := func(messages ...string) *github.PushEvent {
+		e := &github.PushEvent{Repo: &github.PushEventRepository{FullName: github.Ptr("octo/app")}}
+		for i, m := range messages {
+			e.Commits = append(e.Commits, &github.HeadCommit{ID: github.Ptr(fmt.Sprintf("%s%d", m[:1], i)), Message: github.Ptr(m)})
+		}
+		return e
+	}
+
+	ctx, ok := PushContext([]*github.PushEvent{push("fix: crash\n\nDetails")})
+	if !ok || ctx.Owner != "octo" || ctx.Repo != "app" || ctx.CommitSHA != "f0" || ctx.Commits != nil {
+		t.Errorf("single commit context = %+v, %v", ctx, ok)
+	}
+
+	ctx, ok = PushContext([]*github.PushEvent{push("add: a", "bump: b"), push("cut: c\nbody")})
+	want := []models.CommitRef{{SHA: "a0", Title: "add: a"}, {SHA: "b1", Title: "bump: b"}, {SHA: "c0", Title: "cut: c"}}
+	if !ok || ctx.CommitSHA != "" || !slices.Equal(ctx.Commits, want) {
+		t.Errorf("multi-commit context = %+v, %v", ctx, ok)
+	}
+
+	var many []string
+	for i := 0; i < maxPushCommits+5; i++ {
+		many = append(many, fmt.Sprintf("c%d", i))
+	}
+	ctx, _ = PushContext([]*github.PushEvent{push(many...)})
+	if len(ctx.Commits) != maxPushCommits || ctx.Commits[0].Title != "c5" {
+		t.Errorf("kept %d commits starting at %+v, want the latest %d", len(ctx.Commits), ctx.Commits[0], maxPushCommits)
+	}
+
+	deleted := push()
+	deleted.Deleted = github.Ptr(true)
+	if _, ok := PushContext([]*github.PushEvent{deleted}); ok {
+		t.Error("PushContext() of a push without commits reported ok")
+	}
+}
+
+func TestPushCoalescerJoin(t *testing.T) {
+	push := func(ref string) *github.PushEvent {
+		return &github.PushEvent{Ref: github.Ptr(ref), Repo: &github.PushEventRepository{FullName: github.Ptr("octo/app")}}
+	}
+
+	tests := []struct {
+		name   string
+		second *github.PushEvent
+		wait   time.Duration
+		merged bool
+	}{
+		{name: "same branch within the window", second: push("refs/heads/main"), merged: true},
+		{name: "other branch", second: push("refs/heads/dev"), merged: false},
+		{name: "window passed", second: push("refs/heads/main"), wait: 60 * time.Millisecond, merged: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPushCoalescer()
+			first := p.join(42, push("refs/heads/main"), 30*time.Millisecond)
+			first.mu.Unlock()
+
+			time.Sleep(tt.wait)
+			second := p.join(42, tt.second, 30*time.Millisecond)
+			second.mu.Unlock()
+
+			if merged := first == second; merged != tt.merged {
+				t.Fatalf("merged = %v, want %v", merged, tt.merged)
+			}
+			if want := map[bool]int{true: 2, false: 1}[tt.merged]; len(second.Pushes) != want {
+				t.Errorf("group has %d pushes, want %d", len(second.Pushes), want)
+			}
+		})
+	}
+}
+
+func TestFormatCoalescedPushEvents(t *testing.T) {
+	push := func(forced bool, ids ...string) *github.PushEvent {
+		e := &github.PushEvent{
+			Ref:     github.Ptr("refs/heads/main"),
+			Before:  github.Ptr("1111111aaaa"),
+			After:   github.Ptr("2222222bbbb"),
+			Compare: github.Ptr("https://github.com/octo/app/compare/1111111aaaa...2222222bbbb"),
+			Forced:  github.Ptr(forced),
+			Repo:    &github.PushEventRepository{Name: github.Ptr("app"), HTMLURL: github.Ptr("https://github.com/octo/app")},
+		}
+		for _, id := range ids {
+			e.Commits = append(e.Commits, &github.HeadCommit{ID: github.Ptr(id), Message: github.Ptr("change " + id)})
+		}
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		events  []*github.PushEvent
+		want    []string
+		notWant []string
+	}{
+		{
+			name:   "pushes within the window",
+			events: []*github.PushEvent{push(false, "a1"), push(false, "b2", "c3")},
+			want:   []string{"*3 new commits to* `app:main` _\\(2 pushes\\)_", "change a1", "change c3"},
+		},
+		{
+			name:    "force push with new commits",
+			events:  []*github.PushEvent{push(false, "a1"), push(true, "b2")},
+			want:    []string{"*2 new commits to*", "⚠️ *Force pushed* `1111111` → `2222222`"},
+			notWant: []string{"Force push to"},
+		},
+		{
+			name:    "force push without commits",
+			events:  []*github.PushEvent{push(true), push(true)},
+			want:    []string{"⚠️ *Force push to* `app:main` _\\(2 pushes\\)_", "*Force pushed*"},
+			notWant: []string{"0 new commit"},
+		},
+		{
+			name:    "single force push without commits",
+			events:  []*github.PushEvent{push(true)},
+			want:    []string{"⚠️ *Force push to* `app:main`\n"},
+			notWant: []string{"0 new commit", "pushes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, markup := FormatCoalescedPushEvents(tt.events)
+			for _, want := range tt.want {
+				if !strings.Contains(msg, want) {
+					t.Errorf("missing %q in:\n%s", want, msg)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(msg, notWant) {
+					t.Errorf("unexpected %q in:\n%s", notWant, msg)
+				}
+			}
+			if markup == nil {
+				t.Error("expected a button")
+			}
+		})
+	}
+
+	if msg, _ := FormatCoalescedPushEvents([]*github.PushEvent{push(false), push(false)}); msg != "" {
+		t.Errorf("pushes without commits rendered %q", msg)
+	}
+}
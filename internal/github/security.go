@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/google/go-github/v89/github"
+)
+
+// Kinds of security alerts that can be triaged from notifications
+const (
+	AlertDependabot     = "dependabot"
+	AlertSecretScanning = "secret_scanning"
+	AlertVulnerability  = "vulnerability"
+)
+
+// Triage operations on security alert notifications, also used in callback data (sa:<op>:<id>)
+const (
+	AlertDismissMenu   = "m" // Shows the dismissal reasons of a Dependabot alert
+	AlertBack          = "b" // Goes back from the dismissal reasons
+	AlertReopen        = "o"
+	AlertTrack         = "i"  // Opens a tracking issue assigned to the user
+	AlertTrackPublic   = "ip" // Opens a tracking issue in a public repository, after a warning
+	AlertTolerableRisk = "tr"
+	AlertFalsePositive = "fp"
+	AlertNoBandwidth   = "nb"
+	AlertFixStarted    = "fs"
+	AlertRevoked       = "rv"
+)
+
+// DependabotDismissReasons maps dismissal operations to the reasons the Dependabot API takes
+var DependabotDismissReasons = map[string]string{
+	AlertTolerableRisk: "tolerable_risk",
+	AlertFalsePositive: "inaccurate",
+	AlertNoBandwidth:   "no_bandwidth",
+	AlertFixStarted:    "fix_started",
+}
+
+// SecretScanningResolutions maps resolve operations to the resolutions the secret scanning API takes
+var SecretScanningResolutions = map[string]string{
+	AlertRevoked:       "revoked",
+	AlertFalsePositive: "false_positive",
+}
+
+// AlertActionTTL is how long the triage buttons on an alert notification keep working
+const AlertActionTTL = 7 * 24 * time.Hour
+
+// SecurityAlert returns the alert behind a Dependabot, secret scanning or vulnerability alert notification
+func SecurityAlert(event interface{}) (models.AlertActionContext, bool) {
+	switch e := event.(type) {
+	case *github.DependabotAlertEvent:
+		a := e.GetAlert()
+		// Fixed alerts have nothing left to triage
+		if a.GetNumber() == 0 || a.GetState() == "fixed" {
+			return models.AlertActionContext{}, false
+		}
+		pkg := a.GetSecurityVulnerability().GetPackage().GetName()
+		return models.AlertActionContext{
+			Kind:   AlertDependabot,
+			Owner:  e.GetRepo().GetOwner().GetLogin(),
+			Repo:   e.GetRepo().GetName(),
+			Number: a.GetNumber(),
+			Open:   a.GetState() == "open",
+			Title:  fmt.Sprintf("Dependabot alert #%d: %s (%s)", a.GetNumber(), pkg, a.GetSecurityVulnerability().GetSeverity()),
+			URL:    a.GetHTMLURL(),
+		}, true
+	case *github.SecretScanningAlertEvent:
+		a := e.GetAlert()
+		if a.GetNumber() == 0 {
+			return models.AlertActionContext{}, false
+		}
+		name := a.GetSecretTypeDisplayName()
+		if name == "" {
+			name = a.GetSecretType()
+		}
+		return models.AlertActionContext{
+			Kind:   AlertSecretScanning,
+			Owner:  e.GetRepo().GetOwner().GetLogin(),
+			Repo:   e.GetRepo().GetName(),
+			Number: a.GetNumber(),
+			Open:   a.GetState() == "open",
+			Title:  fmt.Sprintf("Secret scanning alert #%d: %s", a.GetNumber(), name),
+			URL:    a.GetHTMLURL(),
+		}, true
+	case *github.RepositoryVulnerabilityAlertEvent:
+		a := e.GetAlert()
+		if e.GetAction() != "create" {
+			return models.AlertActionContext{}, false
+		}
+		repo := e.GetRepository()
+		return models.AlertActionContext{
+			Kind:  AlertVulnerability,
+			Owner: repo.GetOwner().GetLogin(),
+			Repo:  repo.GetName(),
+			Open:  true,
+			Title: fmt.Sprintf("Vulnerability alert: %s (%s)", a.GetAffectedPackageName(), a.GetSeverity()),
+			URL:   fmt.Sprintf("%s/security/advisories/%s", repo.GetHTMLURL(), a.GetGitHubSecurityAdvisoryID()),
+		}, true
+	}
+	return models.AlertActionContext{}, false
+}
+
+// AlertButtons returns the triage buttons for a stored alert in its current state
+func AlertButtons(id string, alert models.AlertActionContext) [][]gotgbot.InlineKeyboardButton {
+	data := func(op string) string { return "sa:" + op + ":" + id }
+	track := []gotgbot.InlineKeyboardButton{{Text: "📝 Track issue", CallbackData: data(AlertTrack)}}
+	if alert.Issue != 0 {
+		track = nil
+	}
+
+	if !alert.Open {
+		// Dismissed and resolved alerts can only be reopened
+		return [][]gotgbot.InlineKeyboardButton{{{Text: "↩️ Reopen", CallbackData: data(AlertReopen)}}}
+	}
+	switch alert.Kind {
+	case AlertDependabot:
+		return [][]gotgbot.InlineKeyboardButton{append([]gotgbot.InlineKeyboardButton{{Text: "🙈 Dismiss", CallbackData: data(AlertDismissMenu)}}, track...)}
+	case AlertSecretScanning:
+		return [][]gotgbot.InlineKeyboardButton{
+			append([]gotgbot.InlineKeyboardButton{{Text: "🔑 Revoked", CallbackData: data(AlertRevoked)}, {Text: "🙅 False positive", CallbackData: data(AlertFalsePositive)}}, track...),
+		}
+	}
+	if track == nil {
+		return nil
+	}
+	return [][]gotgbot.InlineKeyboardButton{track}
+}
+
+// AlertDismissButtons returns the dismissal reasons of a Dependabot alert
+func AlertDismissButtons(id string) [][]gotgbot.InlineKeyboardButton {
+	data := func(op string) string { return "sa:" + op + ":" + id }
+	return [][]gotgbot.InlineKeyboardButton{
+		{{Text: "Tolerable risk", CallbackData: data(AlertTolerableRisk)}, {Text: "False positive", CallbackData: data(AlertFalsePositive)}},
+		{{Text: "No bandwidth", CallbackData: data(AlertNoBandwidth)}, {Text: "Fix started", CallbackData: data(AlertFixStarted)}},
+		{{Text: "« Back", CallbackData: data(AlertBack)}},
+	}
+}
+
+// AlertTrackPublicButtons asks to confirm a tracking issue in a public repository, where it discloses the alert
+func AlertTrackPublicButtons(id string) [][]gotgbot.InlineKeyboardButton {
+	data := func(op string) string { return "sa:" + op + ":" + id }
+	return [][]gotgbot.InlineKeyboardButton{
+		{{Text: "⚠️ Open public issue", CallbackData: data(AlertTrackPublic)}, {Text: "« Back", CallbackData: data(AlertBack)}},
+	}
+}
+
+// AssignAlert assigns a Dependabot or secret scanning alert to a GitHub user, reporting false for alerts
+// that cannot be assigned. go-github has no field for it, so the request is built by hand:
+// Dependabot takes a list of assignees, secret scanning a single assignee.
+func AssignAlert(ctx context.Context, client *github.Client, alert models.AlertActionContext, login string) (bool, error) {
+	var endpoint string
+	var body map[string]interface{}
+	switch alert.Kind {
+	case AlertDependabot:
+		endpoint = fmt.Sprintf("repos/%s/%s/dependabot/alerts/%d", alert.Owner, alert.Repo, alert.Number)
+		body = map[string]interface{}{"assignees": []string{login}}
+	case AlertSecretScanning:
+		endpoint = fmt.Sprintf("repos/%s/%s/secret-scanning/alerts/%d", alert.Owner, alert.Repo, alert.Number)
+		body = map[string]interface{}{"assignee": login}
+	default:
+		return false, nil
+	}
+
+	req, err := client.NewRequest(ctx, "PATCH", endpoint, body)
+	if err != nil {
+		return false, err
+	}
+	if _, err := client.Do(req, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// addAlertActions stores the alert of a security notification under a short ID and adds its triage buttons
+func (s *WebhookServer) addAlertActions(event interface{}, markup *gotgbot.InlineKeyboardMarkup) *gotgbot.InlineKeyboardMarkup {
+	alert, ok := SecurityAlert(event)
+	if !ok {
+		return markup
+	}
+
+	id, err := GenerateState()
+	if err != nil {
+		return markup
+	}
+	id = id[:12]
+	s.AlertActions.Set(id, alert, AlertActionTTL)
+
+	if markup == nil {
+		markup = &gotgbot.InlineKeyboardMarkup{}
+	}
+	markup.InlineKeyboard = append(markup.InlineKeyboard, AlertButtons(id, alert)...)
+	return markup
+}
@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github-webhook/internal/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/google/go-github/v89/github"
+)
+
+func TestSecurityAlert(t *testing.T) {
+	repo := &github.Repository{Name: github.Ptr("hello-world"), Owner: &github.User{Login: github.Ptr("octo-org")}}
+	dependabot := func(state string) *github.DependabotAlertEvent {
+		return &github.DependabotAlertEvent{Repo: repo, Alert: &github.DependabotAlert{
+			Number: github.Ptr(3),
+			State:  github.Ptr(state),
+			SecurityVulnerability: &github.AdvisoryVulnerability{
+				Package:  &github.VulnerabilityPackage{Name: github.Ptr("lodash")},
+				Severity: github.Ptr("high"),
+			},
+		}}
+	}
+
+	alert, ok := SecurityAlert(dependabot("open"))
+	if !ok || alert.Kind != AlertDependabot || alert.Number != 3 || !alert.Open || alert.Title != "Dependabot alert #3: lodash (high)" {
+		t.Errorf("SecurityAlert(open Dependabot alert) = %+v, %v", alert, ok)
+	}
+	if alert, ok := SecurityAlert(dependabot("dismissed")); !ok || alert.Open {
+		t.Errorf("SecurityAlert(dismissed Dependabot alert) = %+v, %v", alert, ok)
+	}
+	if _, ok := SecurityAlert(dependabot("fixed")); ok {
+		t.Error("fixed alerts should not offer triage")
+	}
+
+	secret := &github.SecretScanningAlertEvent{Repo: repo, Alert: &github.SecretScanningAlert{
+		Number: github.Ptr(8), State: github.Ptr("open"), SecretType: github.Ptr("github_personal_access_token"),
+	}}
+	if alert, ok := SecurityAlert(secret); !ok || alert.Kind != AlertSecretScanning || alert.Title != "Secret scanning alert #8: github_personal_access_token" {
+		t.Errorf("SecurityAlert(secret scanning alert) = %+v, %v", alert, ok)
+	}
+}
+
+func TestAlertButtons(t *testing.T) {
+	id := strings.Repeat("f", 12)
+	ops := func(rows [][]gotgbot.InlineKeyboardButton) []string {
+		var got []string
+		for _, row := range rows {
+			for _, b := range row {
+				if len(b.CallbackData) > 64 {
+					t.Errorf("callback data %q exceeds Telegram's 64 bytes", b.CallbackData)
+				}
+				got = append(got, strings.Split(b.CallbackData, ":")[1])
+			}
+		}
+		return got
+	}
+
+	tests := []struct {
+		name  string
+		alert models.AlertActionContext
+		want  string
+	}{
+		{"open Dependabot alert", models.AlertActionContext{Kind: AlertDependabot, Open: true}, "m i"},
+		{"open secret", models.AlertActionContext{Kind: AlertSecretScanning, Open: true}, "rv fp i"},
+		{"tracked secret", models.AlertActionContext{Kind: AlertSecretScanning, Open: true, Issue: 5}, "rv fp"},
+		{"dismissed alert", models.AlertActionContext{Kind: AlertDependabot}, "o"},
+		{"vulnerability alert", models.AlertActionContext{Kind: AlertVulnerability, Open: true}, "i"},
+		{"tracked vulnerability alert", models.AlertActionContext{Kind: AlertVulnerability, Open: true, Issue: 5}, ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(ops(AlertButtons(id, tt.alert)), " "); got != tt.want {
+			t.Errorf("%s: AlertButtons() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := strings.Join(ops(AlertDismissButtons(id)), " "); got != "tr fp nb fs b" {
+		t.Errorf("AlertDismissButtons() = %q", got)
+	}
+}
+
+func TestAssignAlert(t *testing.T) {
+	var path string
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		path = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	base := srv.URL + "/"
+	client, err := github.NewClient(github.WithURLs(&base, &base))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alert := models.AlertActionContext{Kind: AlertDependabot, Owner: "octo-org", Repo: "hello-world", Number: 3}
+	if ok, err := AssignAlert(context.Background(), client, alert, "octocat"); !ok || err != nil {
+		t.Fatalf("AssignAlert(dependabot) = %v, %v", ok, err)
+	}
+	if path != "/repos/octo-org/hello-world/dependabot/alerts/3" || len(body["assignees"].([]interface{})) != 1 {
+		t.Errorf("dependabot request = %s %v", path, body)
+	}
+
+	alert.Kind = AlertSecretScanning
+	if ok, err := AssignAlert(context.Background(), client, alert, "octocat"); !ok || err != nil {
+		t.Fatalf("AssignAlert(secret scanning) = %v, %v", ok, err)
+	}
+	if path != "/repos/octo-org/hello-world/secret-scanning/alerts/3" || body["assignee"] != "octocat" {
+		t.Errorf("secret scanning request = %s %v", path, body)
+	}
+
+	path = ""
+	alert.Kind = AlertVulnerability
+	if ok, err := AssignAlert(context.Background(), client, alert, "octocat"); ok || err != nil || path != "" {
+		t.Errorf("legacy vulnerability alerts cannot be assigned: %v, %v, %q", ok, err, path)
+	}
+}
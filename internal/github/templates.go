@@ -0,0 +1,267 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v89/github"
+)
+
+// TemplateData is the data model exposed to notification templates.
+// String fields hold raw GitHub values; pipe them through md, body, user or repo before output.
+type TemplateData struct {
+	Event   string // Webhook event name, e.g. "issues", "pull_request", "push"
+	Action  string // Event action, e.g. "opened", "closed"; empty for events without actions
+	Repo    string // Repository full name, owner/name
+	RepoURL string
+	Sender  string // Login of the user who triggered the event
+
+	Number int    // Issue or pull request number
+	Title  string // Issue, pull request, release or discussion title
+	Body   string // Issue, pull request, comment or release body
+	URL    string // Link to the issue, pull request, comment, release or run
+	State  string // Issue or pull request state, or CI conclusion
+
+	Branch  string           // Branch for pushes and CI events
+	Commits []TemplateCommit // Commits of a push
+
+	Payload interface{} // The full go-github event, for fields not covered above
+}
+
+// TemplateCommit is a single commit of a push event
+type TemplateCommit struct {
+	SHA     string
+	Message string
+	Author  string
+	URL     string
+}
+
+// TemplateFuncs are the helpers available inside notification templates
+var TemplateFuncs = template.FuncMap{
+	"md":    EscapeMarkdownV2,
+	"mdurl": EscapeMarkdownV2URL,
+	"user":  FormatUser,
+	"repo":  FormatRepo,
+	"body":  FormatTextWithMarkdown,
+	"quote": FormatReleaseBody,
+	"link": func(text, url string) string {
+		return fmt.Sprintf("[%s](%s)", EscapeMarkdownV2(text), EscapeMarkdownV2URL(url))
+	},
+	"short": func(sha string) string {
+		if len(sha) > 7 {
+			return sha[:7]
+		}
+		return sha
+	},
+	"truncate": func(n int, s string) string {
+		r := []rune(s)
+		if len(r) <= n {
+			return s
+		}
+		return string(r[:n]) + "…"
+	},
+	"title": func(s string) string {
+		r, n := utf8.DecodeRuneInString(s)
+		if r == utf8.RuneError {
+			return s
+		}
+		return string(unicode.ToUpper(r)) + s[n:]
+	},
+}
+
+// TemplateKey returns the settings key for an event type and optional action
+func TemplateKey(eventType, action string) string {
+	if action == "" {
+		return eventType
+	}
+	return eventType + ":" + action
+}
+
+// ParseTemplateKey splits a user supplied "event" or "event:action" key
+func ParseTemplateKey(key string) (string, string) {
+	eventType, action, _ := strings.Cut(key, ":")
+	return eventType, action
+}
+
+// lookupTemplate returns the chat's template for an event, preferring an action specific one
+func lookupTemplate(templates map[string]string, data TemplateData) (string, bool) {
+	if data.Action != "" {
+		if t, ok := templates[TemplateKey(data.Event, data.Action)]; ok {
+			return t, true
+		}
+	}
+	t, ok := templates[data.Event]
+	return t, ok
+}
+
+// RenderTemplate executes a notification template against the data model
+func RenderTemplate(text string, data TemplateData) (string, error) {
+	tmpl, err := template.New("notification").Funcs(TemplateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	out := strings.TrimSpace(buf.String())
+	if out == "" {
+		return "", fmt.Errorf("template produced an empty message")
+	}
+	return out, nil
+}
+
+// NewTemplateData builds the template data model for a webhook event
+func NewTemplateData(eventType string, event interface{}) TemplateData {
+	d := TemplateData{Event: eventType, Payload: event}
+
+	if e, ok := event.(interface{ GetAction() string }); ok {
+		d.Action = e.GetAction()
+	}
+	if e, ok := event.(interface{ GetRepo() *github.Repository }); ok {
+		d.Repo, d.RepoURL = e.GetRepo().GetFullName(), e.GetRepo().GetHTMLURL()
+	}
+	if e, ok := event.(interface{ GetSender() *github.User }); ok {
+		d.Sender = e.GetSender().GetLogin()
+	}
+
+	switch e := event.(type) {
+	case *github.IssuesEvent:
+		issue := e.GetIssue()
+		d.Number, d.Title, d.Body, d.URL, d.State = issue.GetNumber(), issue.GetTitle(), issue.GetBody(), issue.GetHTMLURL(), issue.GetState()
+	case *github.PullRequestEvent:
+		pr := e.GetPullRequest()
+		d.Number, d.Title, d.Body, d.URL, d.State = pr.GetNumber(), pr.GetTitle(), pr.GetBody(), pr.GetHTMLURL(), pr.GetState()
+		d.Branch = pr.GetHead().GetRef()
+	case *github.IssueCommentEvent:
+		d.Number, d.Title = e.GetIssue().GetNumber(), e.GetIssue().GetTitle()
+		d.Body, d.URL = e.GetComment().GetBody(), e.GetComment().GetHTMLURL()
+	case *github.PullRequestReviewEvent:
+		d.Number, d.Title = e.GetPullRequest().GetNumber(), e.GetPullRequest().GetTitle()
+		d.Body, d.URL, d.State = e.GetReview().GetBody(), e.GetReview().GetHTMLURL(), e.GetReview().GetState()
+	case *github.PullRequestReviewCommentEvent:
+		d.Number, d.Title = e.GetPullRequest().GetNumber(), e.GetPullRequest().GetTitle()
+		d.Body, d.URL = e.GetComment().GetBody(), e.GetComment().GetHTMLURL()
+	case *github.PushEvent:
+		d.Repo, d.RepoURL = e.GetRepo().GetFullName(), e.GetRepo().GetHTMLURL()
+		d.Branch, d.URL = strings.TrimPrefix(e.GetRef(), "refs/heads/"), e.GetCompare()
+		for _, c := range pushCommits(e) {
+			author := c.GetAuthor().GetLogin()
+			if author == "" {
+				author = c.GetAuthor().GetName()
+			}
+			d.Commits = append(d.Commits, TemplateCommit{SHA: c.GetID(), Message: c.GetMessage(), Author: author, URL: c.GetURL()})
+		}
+	case *github.ReleaseEvent:
+		d.Title, d.Body, d.URL = e.GetRelease().GetName(), e.GetRelease().GetBody(), e.GetRelease().GetHTMLURL()
+		if d.Title == "" {
+			d.Title = e.GetRelease().GetTagName()
+		}
+	case *github.DiscussionEvent:
+		d.Number, d.Title, d.Body, d.URL = e.GetDiscussion().GetNumber(), e.GetDiscussion().GetTitle(), e.GetDiscussion().GetBody(), e.GetDiscussion().GetHTMLURL()
+	case *github.DiscussionCommentEvent:
+		d.Number, d.Title = e.GetDiscussion().GetNumber(), e.GetDiscussion().GetTitle()
+		d.Body, d.URL = e.GetComment().GetBody(), e.GetComment().GetHTMLURL()
+	case *github.CommitCommentEvent:
+		d.Title, d.Body, d.URL = e.GetComment().GetCommitID(), e.GetComment().GetBody(), e.GetComment().GetHTMLURL()
+	case *github.WorkflowRunEvent:
+		run := e.GetWorkflowRun()
+		d.Title, d.URL, d.Branch = e.GetWorkflow().GetName(), run.GetHTMLURL(), run.GetHeadBranch()
+		d.State = run.GetConclusion()
+		if d.State == "" {
+			d.State = run.GetStatus()
+		}
+	case *github.StarEvent, *github.ForkEvent, *github.WatchEvent:
+		d.URL = d.RepoURL
+	}
+
+	return d
+}
+
+// TemplateEvents lists the event types with documented template data, in the order shown by /template
+var TemplateEvents = []string{
+	"issues",
+	"pull_request",
+	"issue_comment",
+	"pull_request_review",
+	"pull_request_review_comment",
+	"push",
+	"release",
+	"discussion",
+	"workflow_run",
+	"star",
+	"fork",
+}
+
+// SampleEvent returns a representative payload for previewing templates
+func SampleEvent(eventType string) interface{} {
+	repo := &github.Repository{FullName: github.Ptr("octo-org/hello-world"), Name: github.Ptr("hello-world"), HTMLURL: github.Ptr("https://github.com/octo-org/hello-world")}
+	sender := &github.User{Login: github.Ptr("octocat")}
+	issue := &github.Issue{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("Crash when opening settings"),
+		Body:    github.Ptr("Steps to reproduce:\n1. Open settings\n2. Watch it *crash*"),
+		HTMLURL: github.Ptr("https://github.com/octo-org/hello-world/issues/42"),
+		State:   github.Ptr("open"),
+	}
+	pr := &github.PullRequest{
+		Number:  github.Ptr(7),
+		Title:   github.Ptr("Fix settings crash"),
+		Body:    github.Ptr("Closes #42"),
+		HTMLURL: github.Ptr("https://github.com/octo-org/hello-world/pull/7"),
+		State:   github.Ptr("open"),
+		Head:    &github.PullRequestBranch{Ref: github.Ptr("fix-settings")},
+	}
+
+	switch eventType {
+	case "issues":
+		return &github.IssuesEvent{Action: github.Ptr("opened"), Issue: issue, Repo: repo, Sender: sender}
+	case "pull_request":
+		return &github.PullRequestEvent{Action: github.Ptr("opened"), PullRequest: pr, Repo: repo, Sender: sender}
+	case "issue_comment":
+		return &github.IssueCommentEvent{Action: github.Ptr("created"), Issue: issue, Repo: repo, Sender: sender, Comment: &github.IssueComment{
+			Body: github.Ptr("I can reproduce this on v1.2."), HTMLURL: github.Ptr("https://github.com/octo-org/hello-world/issues/42#issuecomment-1"),
+		}}
+	case "pull_request_review":
+		return &github.PullRequestReviewEvent{Action: github.Ptr("submitted"), PullRequest: pr, Repo: repo, Sender: sender, Review: &github.PullRequestReview{
+			State: github.Ptr("approved"), Body: github.Ptr("Looks good!"), HTMLURL: github.Ptr("https://github.com/octo-org/hello-world/pull/7#pullrequestreview-1"),
+		}}
+	case "pull_request_review_comment":
+		return &github.PullRequestReviewCommentEvent{Action: github.Ptr("created"), PullRequest: pr, Repo: repo, Sender: sender, Comment: &github.PullRequestComment{
+			Body: github.Ptr("Nit: rename this variable."), HTMLURL: github.Ptr("https://github.com/octo-org/hello-world/pull/7#discussion_r1"),
+		}}
+	case "push":
+		return &github.PushEvent{
+			Ref:     github.Ptr("refs/heads/main"),
+			Compare: github.Ptr("https://github.com/octo-org/hello-world/compare/1111111...2222222"),
+			Repo:    &github.PushEventRepository{FullName: repo.FullName, Name: repo.Name, HTMLURL: repo.HTMLURL},
+			Sender:  sender,
+			Commits: []*github.HeadCommit{
+				{ID: github.Ptr("2222222222222222222222222222222222222222"), Message: github.Ptr("Fix settings crash"), Author: &github.CommitAuthor{Login: github.Ptr("octocat")}, URL: github.Ptr("https://github.com/octo-org/hello-world/commit/2222222")},
+			},
+		}
+	case "release":
+		return &github.ReleaseEvent{Action: github.Ptr("published"), Repo: repo, Sender: sender, Release: &github.RepositoryRelease{
+			TagName: "v1.3.0", Name: github.Ptr("v1.3.0"), Body: github.Ptr("- Fixed settings crash"), HTMLURL: "https://github.com/octo-org/hello-world/releases/tag/v1.3.0",
+		}}
+	case "discussion":
+		return &github.DiscussionEvent{Action: github.Ptr("created"), Repo: repo, Sender: sender, Discussion: &github.Discussion{
+			Number: github.Ptr(3), Title: github.Ptr("Roadmap for v2"), Body: github.Ptr("What should we build next?"), HTMLURL: github.Ptr("https://github.com/octo-org/hello-world/discussions/3"),
+		}}
+	case "workflow_run":
+		return &github.WorkflowRunEvent{Action: github.Ptr("completed"), Repo: repo, Sender: sender, Workflow: &github.Workflow{Name: github.Ptr("CI")}, WorkflowRun: &github.WorkflowRun{
+			Status: github.Ptr("completed"), Conclusion: github.Ptr("failure"), HeadBranch: github.Ptr("main"), HTMLURL: github.Ptr("https://github.com/octo-org/hello-world/actions/runs/1"),
+		}}
+	case "star":
+		return &github.StarEvent{Action: github.Ptr("created"), Repo: repo, Sender: sender}
+	case "fork":
+		return &github.ForkEvent{Repo: repo, Sender: sender, Forkee: &github.Repository{FullName: github.Ptr("octocat/hello-world")}}
+	}
+	return nil
+}
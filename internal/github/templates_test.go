@@ -0,0 +1,94 @@
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github-webhook/internal/i18n"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	for _, event := range TemplateEvents {
+		data := NewTemplateData(event, SampleEvent(event))
+		if data.Repo == "" || data.Sender == "" {
+			t.Errorf("%s: sample data missing repo or sender: %+v", event, data)
+		}
+	}
+
+	data := NewTemplateData("issues", SampleEvent("issues"))
+	msg, err := RenderTemplate(`{{user .Sender}} {{.Action}} {{link (printf "#%d %s" .Number .Title) .URL}} {{md "a.b"}}`, data)
+	if err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+	for _, want := range []string{FormatUser("octocat"), "opened", "[\\#42 Crash when opening settings](https://github.com/octo-org/hello-world/issues/42)", "a\\.b"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("RenderTemplate() missing %q in %q", want, msg)
+		}
+	}
+
+	if _, err := RenderTemplate("{{.Missing}}", data); err == nil {
+		t.Errorf("expected error for unknown field")
+	}
+	if _, err := RenderTemplate("{{if .Body}}", data); err == nil {
+		t.Errorf("expected parse error")
+	}
+	if _, err := RenderTemplate("{{if false}}x{{end}}", data); err == nil {
+		t.Errorf("expected error for empty output")
+	}
+}
+
+func TestLookupTemplate(t *testing.T) {
+	templates := map[string]string{"issues": "any", "issues:closed": "closed"}
+
+	for _, tc := range []struct {
+		action string
+		want   string
+	}{
+		{"closed", "closed"},
+		{"opened", "any"},
+	} {
+		got, ok := lookupTemplate(templates, TemplateData{Event: "issues", Action: tc.action})
+		if !ok || got != tc.want {
+			t.Errorf("lookupTemplate(%q) = %q, %v; want %q", tc.action, got, ok, tc.want)
+		}
+	}
+
+	if _, ok := lookupTemplate(templates, TemplateData{Event: "push"}); ok {
+		t.Errorf("lookupTemplate(push) should not match")
+	}
+}
+
+func TestTitleFunc(t *testing.T) {
+	title := TemplateFuncs["title"].(func(string) string)
+
+	for _, tc := range []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"opened", "Opened"},
+		{"über", "Über"},
+		{"открыт", "Открыт"},
+	} {
+		if got := title(tc.in); got != tc.want {
+			t.Errorf("title(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+// TestSampleHeadlinesLocalized keeps the headline catalogs in step with the formatters
+func TestSampleHeadlinesLocalized(t *testing.T) {
+	s := &WebhookServer{}
+	for _, event := range TemplateEvents {
+		msg, markup := s.formatMessage(SampleEvent(event))
+		if msg == "" {
+			continue
+		}
+		english, _, _ := strings.Cut(msg, "\n")
+		for _, lang := range []string{"es", "ru"} {
+			translated, _, _ := strings.Cut(i18n.Localize(lang, msg, markup), "\n")
+			if translated == english {
+				t.Errorf("%s: %s headline %q is not translated", lang, event, english)
+			}
+		}
+	}
+}
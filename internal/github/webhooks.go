@@ -2,19 +2,21 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github-webhook/internal/cache"
 	"github-webhook/internal/config"
 	"github-webhook/internal/db"
+	"github-webhook/internal/i18n"
 	"github-webhook/internal/models"
-	"github-webhook/internal/utils"
 
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/google/go-github/v89/github"
@@ -24,17 +26,48 @@ type WebhookServer struct {
 	Config       *config.Config
 	DB           *db.DB
 	Bot          *gotgbot.Bot
-	ContextCache *cache.Cache[string, models.MessageContext]  // Key: "chat_id:message_id"
-	ActionCache  *cache.Cache[string, models.PRActionContext] // Key: UUID
+	ContextCache *cache.Cache[string, models.MessageContext]     // Key: "chat_id:message_id"
+	ActionCache  *cache.Cache[string, models.PRActionContext]    // Key: UUID
+	RunActions   *cache.Cache[string, models.RunActionContext]   // Key: short ID in the run and deployment buttons' callback data
+	AlertActions *cache.Cache[string, models.AlertActionContext] // Key: short ID in the alert triage buttons' callback data
+	CI           *CIAggregator
+	Pushes       *PushCoalescer
+	Clients      *ClientFactory // Builds clients with a user's own token, to check what they can read
+
+	deferMu   sync.Mutex
+	scheduled map[int64]bool // Key: chat ID whose held back notifications have a flush scheduled
+
+	personalMu   sync.Mutex
+	personalSent *cache.Cache[string, bool] // Key: "telegram_id:reason:action:url"
+	readAccess   *cache.Cache[string, bool] // Key: "telegram_id:owner/repo", whether the user's token can read the repository
+}
+
+// deferredMessage is a notification held back until a chat's quiet hours end
+type deferredMessage struct {
+	topicID   int64
+	text      string
+	markup    *gotgbot.InlineKeyboardMarkup
+	eventType string
+	event     interface{}
+
+	fallback string // Default rendering, sent if a custom template fails
 }
 
-func NewWebhookServer(cfg *config.Config, database *db.DB, bot *gotgbot.Bot, ctxCache *cache.Cache[string, models.MessageContext], actionCache *cache.Cache[string, models.PRActionContext]) *WebhookServer {
+func NewWebhookServer(cfg *config.Config, database *db.DB, clientFactory *ClientFactory, bot *gotgbot.Bot, ctxCache *cache.Cache[string, models.MessageContext], actionCache *cache.Cache[string, models.PRActionContext], runActions *cache.Cache[string, models.RunActionContext], alertActions *cache.Cache[string, models.AlertActionContext]) *WebhookServer {
 	return &WebhookServer{
 		Config:       cfg,
 		DB:           database,
 		Bot:          bot,
 		ContextCache: ctxCache,
 		ActionCache:  actionCache,
+		RunActions:   runActions,
+		AlertActions: alertActions,
+		CI:           NewCIAggregator(),
+		Pushes:       NewPushCoalescer(),
+		scheduled:    make(map[int64]bool),
+		personalSent: cache.New[string, bool](),
+		readAccess:   cache.New[string, bool](),
+		Clients:      clientFactory,
 	}
 }
 
@@ -46,18 +79,9 @@ func (s *WebhookServer) Handler(w http.ResponseWriter, r *http.Request) {
 	path := r.URL.Path
 	if strings.HasPrefix(path, "/webhook/") && len(path) > 9 {
 		token := path[9:] // strip "/webhook/"
-		decrypted, err := utils.Decrypt(token, s.Config.EncryptionKey)
-		if err == nil {
-			if strings.Contains(decrypted, ":") {
-				parts := strings.Split(decrypted, ":")
-				if len(parts) == 2 {
-					chatID, _ = strconv.ParseInt(parts[0], 10, 64)
-					topicID, _ = strconv.ParseInt(parts[1], 10, 64)
-				}
-			} else {
-				chatID, _ = strconv.ParseInt(decrypted, 10, 64)
-			}
-		} else {
+		var err error
+		chatID, topicID, err = ParseWebhookToken(token, s.Config.EncryptionKey)
+		if err != nil {
 			log.Printf("Failed to decrypt webhook token: %v", err)
 		}
 	}
@@ -87,12 +111,22 @@ func (s *WebhookServer) Handler(w http.ResponseWriter, r *http.Request) {
 		hookID, _ = strconv.ParseInt(idStr, 10, 64)
 	}
 
-	go s.processEvent(event, chatID, topicID, hookID)
+	go s.processEvent(github.WebHookType(r), event, chatID, topicID, hookID)
 	w.WriteHeader(http.StatusOK)
 }
 
-func (s *WebhookServer) processEvent(event interface{}, chatID int64, topicID int64, hookID int64) {
-	if e, ok := event.(*github.RepositoryEvent); ok && e.GetAction() == "renamed" {
+func (s *WebhookServer) processEvent(eventType string, event interface{}, chatID int64, topicID int64, hookID int64) {
+	links, err := s.DB.GetChatLinks(context.Background(), chatID)
+	if err != nil {
+		log.Printf("Failed to load links for chat %d: %v", chatID, err)
+	}
+	org, skip := skipOrgDelivery(links, hookID, event)
+	if skip {
+		return
+	}
+
+	// Organization links are named after the org, so renamed repositories leave them as they are
+	if e, ok := event.(*github.RepositoryEvent); ok && e.GetAction() == "renamed" && !org {
 		newFullName := e.GetRepo().GetFullName()
 		if newFullName != "" && hookID != 0 {
 			err := s.DB.UpdateRepoLinkName(context.Background(), chatID, hookID, newFullName)
@@ -104,28 +138,383 @@ func (s *WebhookServer) processEvent(event interface{}, chatID int64, topicID in
 		}
 	}
 
+	s.notifyUsers(eventType, event, chatID)
+
+	settings, err := s.DB.GetChatSettings(context.Background(), chatID)
+	if err != nil {
+		log.Printf("Failed to load settings for chat %d: %v", chatID, err)
+	}
+
+	if settings.CIAggregate && isCIEvent(event) {
+		s.processCIEvent(eventType, event, chatID, topicID, settings)
+		return
+	}
+
+	if e, ok := event.(*github.PushEvent); ok && settings.PushWindow > 0 && !e.GetDeleted() {
+		s.processPushEvent(eventType, e, chatID, topicID, settings)
+		return
+	}
+
 	msg, markup := s.formatMessage(event)
 	if msg == "" {
 		return
 	}
+	markup = s.addRunActions(event, markup)
+	markup = s.addAlertActions(event, markup)
+
+	msg = i18n.Localize(settings.Language, normalizeMessage(msg), markup)
+
+	var fallback string
+	if custom, ok := applyTemplate(settings, eventType, event); ok {
+		fallback, msg = msg, custom
+	}
+
+	if mentions := s.linkedMentions(event); len(mentions) > 0 {
+		msg = LinkMentions(msg, mentions)
+		if fallback != "" {
+			fallback = LinkMentions(fallback, mentions)
+		}
+	}
+
+	silent, deferUntil := deliveryMode(settings, notificationKind(eventType, event), time.Now())
+	if !deferUntil.IsZero() {
+		s.deferMessage(chatID, deferUntil, deferredMessage{topicID: topicID, text: msg, markup: markup, eventType: eventType, event: event, fallback: fallback})
+		return
+	}
+
+	s.sendWithFallback(chatID, topicID, msg, fallback, markup, event, silent)
+}
+
+// applyTemplate renders the chat's custom template for an event, if it has one.
+// Templates that fail to render are logged and ignored so the default message is sent instead.
+func applyTemplate(settings models.ChatSettings, eventType string, event interface{}) (string, bool) {
+	if len(settings.Templates) == 0 {
+		return "", false
+	}
+
+	data := NewTemplateData(eventType, event)
+	tmpl, ok := lookupTemplate(settings.Templates, data)
+	if !ok {
+		return "", false
+	}
+
+	msg, err := RenderTemplate(tmpl, data)
+	if err != nil {
+		log.Printf("Failed to render %s template: %v", TemplateKey(data.Event, data.Action), err)
+		return "", false
+	}
+	return normalizeMessage(msg), true
+}
+
+// linkedMentions resolves the users targeted by an event to their Telegram accounts,
+// skipping users who are not connected or have turned mentions off
+func (s *WebhookServer) linkedMentions(event interface{}) []LinkedMention {
+	var mentions []LinkedMention
+	for _, m := range s.resolveMentions(MentionTargets(event)) {
+		if !m.User.NoMentions {
+			mentions = append(mentions, LinkedMention{Mention: m.Mention, TelegramID: m.User.ID})
+		}
+	}
+	return mentions
+}
 
-	msg = normalizeMessage(msg)
+// resolvedMention is a mention matched to a connected user
+type resolvedMention struct {
+	Mention
+	User models.User
+}
+
+// resolveMentions matches mentions to connected users, by GitHub user ID when the event
+// carries one and by login otherwise
+func (s *WebhookServer) resolveMentions(targets []Mention) []resolvedMention {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	var (
+		ids    []int64
+		logins []string
+	)
+	for _, t := range targets {
+		if t.UserID != 0 {
+			ids = append(ids, t.UserID)
+		} else {
+			logins = append(logins, t.Login)
+		}
+	}
+	users, err := s.DB.FindGitHubUsers(context.Background(), ids, logins)
+	if err != nil {
+		log.Printf("Failed to look up mentioned users: %v", err)
+		return nil
+	}
+
+	var resolved []resolvedMention
+	for _, t := range targets {
+		for _, u := range users {
+			if t.UserID != 0 && t.UserID == u.GitHubUserID || t.UserID == 0 && strings.EqualFold(t.Login, u.GitHubUsername) {
+				resolved = append(resolved, resolvedMention{Mention: t, User: u})
+				break
+			}
+		}
+	}
+	return resolved
+}
+
+// sendWithFallback sends a templated notification, retrying with the default rendering if Telegram rejects it
+func (s *WebhookServer) sendWithFallback(chatID int64, topicID int64, msg string, fallback string, markup *gotgbot.InlineKeyboardMarkup, event interface{}, silent bool) int64 {
+	id := s.sendNotification(chatID, topicID, msg, markup, event, silent)
+	if id == 0 && fallback != "" {
+		id = s.sendNotification(chatID, topicID, fallback, markup, event, silent)
+	}
+	return id
+}
+
+// sendNotification sends a formatted notification and records its GitHub context for replies.
+// It returns the ID of the sent message, or 0 if sending failed.
+func (s *WebhookServer) sendNotification(chatID int64, topicID int64, msg string, markup *gotgbot.InlineKeyboardMarkup, event interface{}, silent bool) int64 {
 	opts := &gotgbot.SendMessageOpts{
 		ParseMode:       "MarkdownV2",
 		MessageThreadId: topicID,
 		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
 			IsDisabled: true,
 		},
-		ReplyMarkup: markup,
+		ReplyMarkup:         markup,
+		DisableNotification: silent,
 	}
 
 	sentMsg, err := s.Bot.SendMessage(chatID, msg, opts)
 	if err != nil {
 		log.Printf("Error sending message to chat %d: %v", chatID, err)
-		return
+		return 0
 	}
 
 	s.storeMessageContext(sentMsg.MessageId, chatID, event)
+	return sentMsg.MessageId
+}
+
+// processPushEvent merges pushes to the same branch within the chat's coalescing window into one message.
+// A push deferred by quiet hours closes the current group so later pushes start a new one.
+func (s *WebhookServer) processPushEvent(eventType string, event *github.PushEvent, chatID int64, topicID int64, settings models.ChatSettings) {
+	group := s.Pushes.join(chatID, event, time.Duration(settings.PushWindow)*time.Second)
+	defer group.mu.Unlock()
+
+	msg, markup := FormatCoalescedPushEvents(group.Pushes)
+	if msg == "" {
+		return
+	}
+	msg = i18n.Localize(settings.Language, normalizeMessage(msg), markup)
+
+	// A lone push looks like any other notification, so it gets the chat's push template
+	var fallback string
+	if len(group.Pushes) == 1 {
+		if custom, ok := applyTemplate(settings, eventType, event); ok {
+			fallback, msg = msg, custom
+		}
+	}
+
+	var mentions []LinkedMention
+	seen := make(map[string]bool)
+	for _, p := range group.Pushes {
+		for _, m := range s.linkedMentions(p) {
+			if key := strings.ToLower(m.Login); !seen[key] {
+				seen[key] = true
+				mentions = append(mentions, m)
+			}
+		}
+	}
+	if len(mentions) > 0 {
+		msg = LinkMentions(msg, mentions)
+		if fallback != "" {
+			fallback = LinkMentions(fallback, mentions)
+		}
+	}
+
+	if group.MessageID != 0 {
+		s.editNotification(chatID, group.MessageID, msg, markup)
+		// Replies to the merged message can comment on any of its commits
+		if ctx, ok := PushContext(group.Pushes); ok {
+			s.ContextCache.Set(fmt.Sprintf("%d:%d", chatID, group.MessageID), ctx, 48*time.Hour)
+		}
+		return
+	}
+
+	silent, deferUntil := deliveryMode(settings, notificationKind(eventType, event), time.Now())
+	if !deferUntil.IsZero() {
+		s.deferMessage(chatID, deferUntil, deferredMessage{topicID: topicID, text: msg, markup: markup, eventType: eventType, event: event, fallback: fallback})
+		group.Pushes = nil
+		return
+	}
+
+	group.MessageID = s.sendWithFallback(chatID, topicID, msg, fallback, markup, event, silent)
+}
+
+// processCIEvent folds a CI event into the per-commit status message, posting it on first use and editing it afterwards.
+// Aggregated messages are never deferred by quiet hours since they are edited in place; they are sent silently instead.
+func (s *WebhookServer) processCIEvent(eventType string, event interface{}, chatID int64, topicID int64, settings models.ChatSettings) {
+	commit := s.CI.commitFor(chatID, event)
+	if commit == nil {
+		return
+	}
+	defer commit.mu.Unlock()
+
+	// A GitHub Actions suite only updates the status of its workflows, there is nothing to show yet
+	if len(commit.Checks) == 0 {
+		return
+	}
+
+	done, _ := commit.overall()
+	if settings.CIFinalOnly && !done {
+		return
+	}
+
+	msg, markup := commit.render(settings.Language)
+	msg = i18n.Localize(settings.Language, msg, markup)
+	markup = s.addCIRunActions(commit, done, settings.Language, markup)
+
+	// The message usually starts with a queued run, so its sound must follow the commit's state rather than the event
+	kind := notificationKind(eventType, event)
+	alert := !commit.FailureAlerted && commit.failsDefaultBranch()
+	if alert {
+		kind = CIFailureEvent.Name
+	}
+	silent, deferUntil := deliveryMode(settings, kind, time.Now())
+	if alert {
+		commit.FailureAlerted = true
+	}
+
+	// Edits make no sound, so the first failure on the default branch is posted again unless it is meant to stay quiet
+	previous := commit.MessageID
+	if previous != 0 && (!alert || silent || !deferUntil.IsZero()) {
+		s.editNotification(chatID, previous, msg, markup)
+		return
+	}
+
+	opts := &gotgbot.SendMessageOpts{
+		ParseMode:       "MarkdownV2",
+		MessageThreadId: topicID,
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
+			IsDisabled: true,
+		},
+		ReplyMarkup:         markup,
+		DisableNotification: silent || !deferUntil.IsZero(),
+	}
+
+	sentMsg, err := s.Bot.SendMessage(chatID, msg, opts)
+	if err != nil {
+		log.Printf("Error sending CI message to chat %d: %v", chatID, err)
+		if previous != 0 {
+			s.editNotification(chatID, previous, msg, markup)
+		}
+		return
+	}
+	commit.MessageID = sentMsg.MessageId
+	if previous != 0 {
+		if _, err := s.Bot.DeleteMessage(chatID, previous, nil); err != nil {
+			log.Printf("Error deleting replaced CI message %d in chat %d: %v", previous, chatID, err)
+		}
+	}
+}
+
+// editNotification replaces the text of a previously sent notification
+func (s *WebhookServer) editNotification(chatID int64, messageID int64, msg string, markup *gotgbot.InlineKeyboardMarkup) {
+	opts := &gotgbot.EditMessageTextOpts{
+		ChatId:    chatID,
+		MessageId: messageID,
+		ParseMode: "MarkdownV2",
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
+			IsDisabled: true,
+		},
+	}
+	if markup != nil {
+		opts.ReplyMarkup = *markup
+	}
+
+	_, _, err := s.Bot.EditMessageText(msg, opts)
+	if err != nil && !strings.Contains(err.Error(), "message is not modified") {
+		log.Printf("Error editing message %d in chat %d: %v", messageID, chatID, err)
+	}
+}
+
+// deferMessage stores a notification until the chat's quiet hours end, so it survives restarts.
+// The first held back message schedules the flush; later ones just join it.
+func (s *WebhookServer) deferMessage(chatID int64, until time.Time, m deferredMessage) {
+	stored := models.DeferredMessage{
+		ChatID:    chatID,
+		TopicID:   m.topicID,
+		Until:     until,
+		Text:      m.text,
+		Fallback:  m.fallback,
+		EventType: m.eventType,
+	}
+	if m.markup != nil {
+		markup, _ := json.Marshal(m.markup)
+		stored.Markup = string(markup)
+	}
+	payload, err := json.Marshal(m.event)
+	if err == nil {
+		stored.Payload = string(payload)
+		err = s.DB.AddDeferredMessage(context.Background(), stored)
+	}
+	if err != nil {
+		// Sending it now beats losing it
+		log.Printf("Failed to hold back a notification for chat %d: %v", chatID, err)
+		s.sendWithFallback(chatID, m.topicID, m.text, m.fallback, m.markup, m.event, true)
+		return
+	}
+
+	s.scheduleFlush(chatID, until)
+}
+
+// scheduleFlush sends the held back notifications of a chat at the given time, unless a flush is already scheduled
+func (s *WebhookServer) scheduleFlush(chatID int64, at time.Time) {
+	s.deferMu.Lock()
+	defer s.deferMu.Unlock()
+
+	if s.scheduled[chatID] {
+		return
+	}
+	s.scheduled[chatID] = true
+	time.AfterFunc(time.Until(at), func() { s.flushDeferred(chatID) })
+}
+
+// RestoreDeferred schedules the notifications held back before a restart
+func (s *WebhookServer) RestoreDeferred() {
+	due, err := s.DB.DeferredChats(context.Background())
+	if err != nil {
+		log.Printf("Failed to load held back notifications: %v", err)
+		return
+	}
+	for chatID, at := range due {
+		s.scheduleFlush(chatID, at)
+	}
+}
+
+// flushDeferred sends all messages held during quiet hours. Only the first one plays a sound.
+func (s *WebhookServer) flushDeferred(chatID int64) {
+	s.deferMu.Lock()
+	delete(s.scheduled, chatID)
+	s.deferMu.Unlock()
+
+	pending, err := s.DB.TakeDeferredMessages(context.Background(), chatID)
+	if err != nil {
+		log.Printf("Failed to load held back notifications for chat %d: %v", chatID, err)
+		return
+	}
+
+	for i, m := range pending {
+		var markup *gotgbot.InlineKeyboardMarkup
+		if m.Markup != "" {
+			markup = &gotgbot.InlineKeyboardMarkup{}
+			if err := json.Unmarshal([]byte(m.Markup), markup); err != nil {
+				markup = nil
+			}
+		}
+		event, err := github.ParseWebHook(m.EventType, []byte(m.Payload))
+		if err != nil {
+			log.Printf("Failed to restore the %s event of a held back notification: %v", m.EventType, err)
+		}
+		s.sendWithFallback(chatID, m.TopicID, m.Text, m.Fallback, markup, event, i > 0)
+	}
 }
 
 // normalizeMessage trims trailing spaces on each line, collapses 3+ consecutive newlines into 2
@@ -189,6 +578,68 @@ func (s *WebhookServer) storeMessageContext(messageID int64, chatID int64, event
 			CommentID:   e.GetComment().GetID(),
 			Type:        "pr_review_comment",
 		}
+	case *github.PushEvent:
+		var ok bool
+		if ctx, ok = PushContext([]*github.PushEvent{e}); !ok {
+			return
+		}
+	case *github.CommitCommentEvent:
+		if e.GetAction() == "deleted" {
+			return
+		}
+		ctx = models.MessageContext{
+			Owner:     e.GetRepo().GetOwner().GetLogin(),
+			Repo:      e.GetRepo().GetName(),
+			CommitSHA: e.GetComment().GetCommitID(),
+			CommentID: e.GetComment().GetID(),
+			Type:      "commit_comment",
+		}
+	case *github.DiscussionEvent:
+		ctx = models.MessageContext{
+			Owner:        e.GetRepo().GetOwner().GetLogin(),
+			Repo:         e.GetRepo().GetName(),
+			DiscussionID: e.GetDiscussion().GetNodeID(),
+			Type:         "discussion",
+		}
+	case *github.DiscussionCommentEvent:
+		if e.GetAction() == "deleted" {
+			return
+		}
+		ctx = models.MessageContext{
+			Owner:         e.GetRepo().GetOwner().GetLogin(),
+			Repo:          e.GetRepo().GetName(),
+			DiscussionID:  e.GetDiscussion().GetNodeID(),
+			CommentNodeID: e.GetComment().GetNodeID(),
+			Type:          "discussion_comment",
+		}
+	case *github.WorkflowRunEvent, *github.WorkflowJobEvent:
+		typ := "workflow_run"
+		run, _, ok := FailedRun(e)
+		if !ok {
+			if run, ok = WaitingDeployment(e); !ok {
+				return
+			}
+			typ = "deployment_review"
+		}
+		ctx = models.MessageContext{
+			Owner:      run.Owner,
+			Repo:       run.Repo,
+			RunID:      run.RunID,
+			RunAttempt: run.Attempt,
+			Type:       typ,
+		}
+	case *github.DeploymentReviewEvent:
+		run, ok := WaitingDeployment(e)
+		if !ok {
+			return
+		}
+		ctx = models.MessageContext{
+			Owner:      run.Owner,
+			Repo:       run.Repo,
+			RunID:      run.RunID,
+			RunAttempt: run.Attempt,
+			Type:       "deployment_review",
+		}
 	default:
 		return
 	}
@@ -0,0 +1,498 @@
+package i18n
+
+var en = map[string]string{
+	"start": `<b>Welcome to the GitHub Bot!</b> 🤖
+
+I can help you manage your GitHub repositories and notifications directly from Telegram.
+
+<b>Get Started:</b>
+1. Use /connect to link your GitHub account.
+2. Use /addrepo to link a repository and start receiving notifications.
+3. Use /settings to customize your notification preferences.
+
+Need help? Type /help for a full list of commands.`,
+
+	"help": `<b>GitHub Bot Commands:</b>
+
+<b>Account</b>
+/connect - Link your GitHub account (<i>Must be used in private chat</i>)
+/mentions [on|off] - Get pinged when you are assigned, asked to review, or mentioned
+/me - Personal notifications and mention preferences (<i>private chat</i>)
+
+<b>Repository Management</b>
+/addrepo [owner/repo|name] - Link a repository, or search the ones you can admin by name and link several at once
+/addorg &lt;org&gt; [pattern...] [-pattern...] - Link every repository of an organization, including future ones
+/removerepo [owner/repo] - Unlink a repository; without an argument, pick one from a list
+/repos - List linked repositories with their topic, events, webhook health and who linked them
+/close - Close an issue or PR (reply to notification).
+/reopen - Reopen an issue or PR (reply to notification).
+/approve - Approve a PR (reply to notification).
+/issue [owner/repo] Title - Open an issue; body, <code>labels:</code>, <code>assignees:</code> and <code>milestone:</code> on the next lines. Reply to a message to quote it.
+/label [labels] - Add labels, comma-separated (reply to notification).
+/unlabel [labels] - Remove labels (reply to notification).
+/assign [@user|me] - Assign users (reply to notification).
+/unassign [@user|me] - Unassign users (reply to notification).
+/milestone [title|none] - Set or clear the milestone (reply to notification). Without an argument, each shows a picker.
+/merge [merge|squash|rebase] - Merge a PR after checking conflicts, reviews and required checks (reply to notification).
+/review approve|changes|comment &lt;text&gt; - Submit a review on a PR (reply to notification).
+/dismiss &lt;reason&gt; - Dismiss your latest review on a PR (reply to notification).
+/rerun [failed|all] - Re-run a failed workflow run (reply to notification).
+/deploy approve|reject [comment] - Approve or reject a deployment waiting for review (reply to notification).
+/dispatch [owner/repo] [workflow] - Run a workflow_dispatch workflow with inputs and follow the run.
+/prs [owner/repo] [filters] - Browse open PRs. Filters: author:, label:, review:required, is:draft.
+/issues [owner/repo] [filters] - Browse open issues. Filters: author:, label:, assignee:.
+/delete - Delete the GitHub comment posted from your replied message.
+/answer - Mark the replied discussion comment as the answer.
+
+<b>Configuration</b>
+/settings - Configure event notifications and language
+/template - Customize notification messages
+/reload - Reload admin cache
+
+
+<b>Need more help?</b>
+Visit the <a href="https://github.com/AshokShau/GithubBot">GitHub page</a> for more details.`,
+
+	"privacy": `<b>Privacy Policy</b>
+
+We value your privacy and are committed to protecting your data. This policy outlines how we collect, use, and safeguard your information.
+
+<b>1. Data Collection</b>
+• <b>Telegram Data:</b> We store your Telegram User ID, Chat ID, and basic profile information to route notifications and manage permissions.
+• <b>GitHub Data:</b> When you connect your account, we securely store your encrypted OAuth token. We also store the names of repositories you link and the Webhook IDs created.
+• <b>Events:</b> We process incoming GitHub webhook events (e.g., pushes, issues) to send notifications to your chat. The content of these events is processed in real-time and not permanently stored.
+
+<b>2. Data Usage</b>
+• <b>Functionality:</b> Your data is used strictly to provide the bot's services: sending notifications, managing repository links, and verifying permissions.
+• <b>Security:</b> Your OAuth tokens are encrypted using AES-GCM before being stored in our database.
+
+<b>3. Data Sharing</b>
+• We do <b>not</b> share, sell, or rent your personal data to third parties.
+• Data is only shared with GitHub APIs to the extent necessary to perform requested actions (e.g., creating webhooks).
+
+<b>4. Data Control & Rights</b>
+• <b>Disconnect:</b> You can unlink your GitHub account at any time, which invalidates the stored token.
+• <b>Removal:</b> You can remove repositories using /removerepo. To request full data deletion, please contact the developer or simply block the bot.
+
+<b>5. Contact</b>
+If you have questions or concerns, please visit our <a href="https://github.com/AshokShau/GithubBot">GitHub repository</a> or join our <a href="https://t.me/GuardxSupport">Telegram Support Group</a>.`,
+
+	// Commands menu
+	"cmd.start":      "Start the bot",
+	"cmd.help":       "Show available commands",
+	"cmd.connect":    "Connect your GitHub account",
+	"cmd.addrepo":    "Link a repository",
+	"cmd.addorg":     "Link every repository of an organization",
+	"cmd.removerepo": "Unlink a repository",
+	"cmd.repos":      "List linked repositories",
+	"cmd.settings":   "Configure notifications and language",
+	"cmd.template":   "Customize notification messages",
+	"cmd.close":      "Close an issue or PR (reply to notification)",
+	"cmd.reopen":     "Reopen an issue or PR (reply to notification)",
+	"cmd.approve":    "Approve a PR (reply to notification)",
+	"cmd.issue":      "Open an issue (reply to quote a message)",
+	"cmd.label":      "Add labels (reply to notification)",
+	"cmd.assign":     "Assign users (reply to notification)",
+	"cmd.milestone":  "Set the milestone (reply to notification)",
+	"cmd.merge":      "Merge a PR (reply to notification)",
+	"cmd.review":     "Review a PR (reply to notification)",
+	"cmd.dismiss":    "Dismiss your review (reply to notification)",
+	"cmd.rerun":      "Re-run a failed workflow (reply to notification)",
+	"cmd.deploy":     "Approve or reject a pending deployment (reply to notification)",
+	"cmd.dispatch":   "Run a workflow with inputs",
+	"cmd.prs":        "Browse open pull requests",
+	"cmd.issues":     "Browse open issues",
+	"cmd.delete":     "Delete your posted comment (reply to it)",
+	"cmd.answer":     "Mark a discussion comment as the answer",
+	"cmd.privacy":    "View the privacy policy",
+	"cmd.logout":     "Disconnect your GitHub account",
+	"cmd.mentions":   "Toggle Telegram mentions in notifications",
+	"cmd.me":         "Personal notification preferences",
+	"cmd.reload":     "Reload admin cache",
+
+	// Account
+	"connect.private_only": "⚠️ The /connect command can only be used in a private chat with the bot.",
+	"connect.prompt":       "Please [connect your GitHub account](%s) to enable automatic webhook setup and perform actions like approving PRs.",
+	"oauth.connected":      "✅ GitHub account <b>%s</b> connected successfully!",
+	"oauth.page_title":     "Connected",
+	"oauth.page_heading":   "Authentication Successful",
+	"oauth.page_text":      "Your GitHub account has been connected.",
+	"oauth.page_return":    "If the window does not close automatically, you can <a href=\"%s\">return to Telegram</a>.",
+	"connect.first":        "Please [connect your GitHub account](%s) first.",
+	"connect.first_repo":   "Please [connect your GitHub account](%s) first to link repository %s.",
+	"logout.error":         "Error logging out.",
+	"logout.done":          "✅ You have been logged out. Use /connect to reconnect.",
+	"mentions.status_on":   "🔔 Mentions are <b>on</b> for <b>%s</b>. Notifications ping you when you are assigned, asked to review, or @mentioned.\nUse <code>/mentions off</code> to turn them off.",
+	"mentions.status_off":  "🔕 Mentions are <b>off</b> for <b>%s</b>. Notifications link to your GitHub profile instead.\nUse <code>/mentions on</code> to turn them on.",
+	"mentions.usage":       "Usage: /mentions on|off",
+	"mentions.enabled":     "🔔 You will be mentioned in notifications.",
+	"mentions.disabled":    "🔕 You will no longer be mentioned in notifications.",
+	"me.private_only":      "⚠️ The /me command can only be used in a private chat with the bot.",
+	"me.menu":              "<b>Personal notifications</b> for <b>%s</b>\n\nI message you here when you are asked to review a PR, assigned, or @mentioned in a repository linked to any chat. Tap to toggle.",
+	"me.review":            "👀 Review requests: %s",
+	"me.assign":            "📌 Assignments: %s",
+	"me.mention":           "💬 Mentions: %s",
+	"me.group_mentions":    "🔔 Mention me in group notifications: %s",
+	"me.muted":             "🔕 Turned off. Use /me to turn it back on.",
+	"personal.review":      "*👀 Review requested*",
+	"personal.assign":      "*📌 Assigned to you*",
+	"personal.mention":     "*💬 You were mentioned*",
+	"personal.view":        "View on GitHub",
+	"personal.approve":     "✅ Approve",
+	"personal.off":         "🔕 Turn these off",
+
+	// Permissions
+	"admin.add_repo":        "Only admins can add repositories.",
+	"admin.remove_repo":     "Only admins can remove repositories.",
+	"admin.settings":        "Only admins can modify settings.",
+	"admin.change_settings": "Only admins can change settings",
+	"admin.template":        "Only admins can modify templates.",
+	"template.usage": `<b>Notification templates</b>
+
+Customize how notifications look in this chat using Go <a href="https://pkg.go.dev/text/template">text/template</a> syntax. Output is sent as Telegram MarkdownV2.
+
+<b>Usage</b>
+/template - List custom templates
+/template show &lt;event[:action]&gt; - Show a template
+/template set &lt;event[:action]&gt; - Set a template (template on the following lines)
+/template preview &lt;event[:action]&gt; - Preview against a sample payload (optionally with a new template on the following lines)
+/template reset &lt;event[:action]&gt;|all - Restore the default message
+
+<b>Fields</b>
+<code>.Event .Action .Repo .RepoURL .Sender .Number .Title .Body .URL .State .Branch .Commits .Payload</code>
+Each commit has <code>.SHA .Message .Author .URL</code>.
+
+<b>Helpers</b>
+<code>md</code> escape text, <code>mdurl</code> escape a URL, <code>user</code> link a login, <code>repo</code> link a repository, <code>link text url</code>, <code>body</code> convert Markdown, <code>quote</code> quote a long body, <code>short</code> shorten a SHA, <code>truncate n</code>, <code>title</code>.
+Always escape raw fields with <code>md</code>, or Telegram will reject the message.
+
+<b>Example</b>
+<code>/template set issues:opened
+🐞 {{user .Sender}} opened {{link (printf "#%d %s" .Number .Title) .URL}} in {{repo .Repo}}</code>`,
+	"template.none":         "No custom template for <code>%s</code>.",
+	"template.show":         "<b>Template for</b> <code>%s</code>:\n<pre>%s</pre>",
+	"template.body_missing": "Put the template on the lines after the command.",
+	"template.invalid":      "❌ Invalid template: %s",
+	"template.save_failed":  "Failed to save template.",
+	"template.saved":        "✅ Template for <code>%s</code> saved. Use <code>/template preview %s</code> to check it.",
+	"template.rejected":     "❌ Telegram rejected the rendered message: %s\n\nMake sure raw fields are escaped with <code>md</code>.",
+	"template.reset_done":   "✅ Default notification format restored.",
+	"template.custom":       "<b>Custom templates in this chat:</b>",
+	"template.samples":      "<b>Sample payloads:</b> %s",
+	"admin.reload":          "Only admins can reload the cache.",
+
+	// Errors
+	"err.auth":           "Auth error.",
+	"err.auth_reconnect": "Auth error. Reconnect via /connect",
+	"err.auth_again":     "Auth error. Please /connect again.",
+	"err.client":         "Failed to create GitHub client.",
+	"err.webhook_token":  "Error generating webhook token.",
+	"err.link_repo":      "Error linking repository.",
+	"err.api":            "GitHub API error.",
+	"err.failed":         "Failed: %v",
+	"err.token_revoked":  "GitHub auth error. Token revoked or expired.",
+	"err.auth_failed":    "⚠️ <b>GitHub authentication failed.</b>\nIt seems your token has expired or was revoked. Please /connect again.",
+	"err.forbidden":      "⛔ Your GitHub account lacks permission for this: %s",
+	"err.connect_first":  "Please /connect to GitHub first.",
+	"err.connect_via":    "Please connect GitHub account first via /connect",
+	"err.load_settings":  "Failed to load settings.",
+	"err.save_settings":  "Failed to save settings.",
+
+	// Repositories
+	"repo.invalid_format":        "Invalid repository format. Use owner/repo",
+	"repo.not_found":             "❌ <b>Repository not found.</b>\nPlease check the name and ensure you have access.",
+	"repo.fetch_error":           "Error fetching repository: %v",
+	"repo.no_permission":         "❌ <b>Insufficient permissions.</b>\nYou need admin access to repository <b>%s</b> to create webhooks.",
+	"repo.webhook_failed":        "⚠️ <b>Webhook creation failed.</b>\nPlease ensure you have admin rights and try again.",
+	"repo.webhook_failed_v":      "Webhook creation failed: %v. Check permissions",
+	"repo.linked":                "Repository <b>%s</b> linked successfully!",
+	"org.usage":                  "Usage: /addorg <org> [pattern...] [-pattern...]\nPatterns such as api-* limit the repositories, patterns starting with - exclude them.",
+	"org.bad_pattern":            "Invalid repository pattern. Use globs such as api-* or -legacy-*.",
+	"org.not_found":              "Organization <b>%s</b> not found.",
+	"org.scope_missing":          "Your GitHub connection lacks the <code>%s</code> scope needed for organization webhooks. Run /connect again in private chat to grant it.",
+	"org.already_linked":         "<b>%s</b> is already linked here. Remove it first with <code>/removerepo %s</code>.",
+	"org.no_permission":          "You cannot create webhooks for <b>%s</b>. Only organization owners can.",
+	"org.linked":                 "✅ Every repository of <b>%s</b> is linked, including ones created later.",
+	"org.include":                "Only: %s",
+	"org.exclude":                "Except: %s",
+	"repo.linked_check":          "✅ Repository <b>%s</b> linked successfully!",
+	"repo.connect_to_list":       "Please /connect your GitHub account first to list repositories.",
+	"repo.fetch_failed":          "Failed to fetch repositories from GitHub.",
+	"repo.none_found":            "No repositories found.",
+	"repo.select_add":            "Select a repository to add (Page %d):",
+	"repo.search_hint":           "Tip: search the repositories you can admin with /addrepo <name>.",
+	"repo.search_header":         "🔎 Repositories you can admin matching <code>%s</code>: %d (page %d/%d)\nTap one to link it to this chat. ✅ marks the linked ones, 🏢 those an organization link already covers.",
+	"repo.search_multi_hint":     "Tap repositories to select them, then link them all at once.",
+	"repo.search_truncated":      "⚠️ Only your %d most recently updated repositories were searched; refine the name if the one you want is missing.",
+	"repo.search_empty":          "No repository you can admin matches <code>%s</code>.",
+	"repo.search_select":         "☑️ Select several",
+	"repo.search_link_selected":  "🔗 Link %d selected",
+	"repo.search_single":         "✖️ Cancel selection",
+	"repo.search_none_selected":  "Select at least one repository first.",
+	"repo.search_already_linked": "%s is already linked to this chat.",
+	"repo.search_linked":         "✅ <b>%s</b> linked",
+	"repo.search_failed":         "❌ <b>%s</b>: %s",
+	"repo.search_no_admin":       "admin access is required to create webhooks",
+	"repo.access_denied":         "Repo not found or access denied.",
+	"repo.link_not_found":        "Repo not found",
+	"repos.none":                 "No repositories linked.",
+	"repos.title":                "<b>Linked Repositories:</b>\n",
+	"repos.digest":               "⚙️ <i>Applies to every link of this chat:</i>",
+	"repos.topic":                "📍 Topic #%d",
+	"repos.main_chat":            "📍 Main chat",
+	"repos.events":               "📡 %d/%d events",
+	"repos.hook_inactive":        "⏸ webhook disabled",
+	"repos.delivery_none":        "🆕 no deliveries yet",
+	"repos.delivery_ok":          "✅ last delivery %d",
+	"repos.delivery_failed":      "⚠️ last delivery failed: %s",
+	"repos.hook_missing":         "❌ Webhook not found on GitHub",
+	"repos.hook_unknown":         "❔ Webhook status unavailable: %s",
+	"repos.hook_connect":         "❔ /connect to see the webhook status",
+	"repos.linked_by":            "👤 Linked by %s on %s",
+	"nav.prev":                   "< Prev",
+	"nav.next":                   "Next >",
+
+	"remove.not_found":                "Error finding repository link or not found.",
+	"remove.db_error":                 "Error removing repository from database.",
+	"remove.done":                     "Repository <b>%s</b> removed successfully.%s",
+	"remove.warn_connect":             "\n\n⚠️ <b>Warning:</b> You are not connected to GitHub. The webhook could not be removed from the repository settings. Please remove it manually.",
+	"remove.warn_decrypt":             "\n\n⚠️ <b>Warning:</b> Could not decrypt your access token. Webhook not removed from GitHub.",
+	"remove.warn_client":              "\n\n⚠️ <b>Warning:</b> Failed to create GitHub client. Webhook not removed.",
+	"remove.warn_auth":                "\n\n⚠️ <b>Warning:</b> GitHub authentication failed. Webhook not removed.",
+	"remove.warn_failed":              "\n\n⚠️ <b>Warning:</b> Failed to remove webhook from GitHub: %v",
+	"remove.pick":                     "Select a repository to unlink from this chat:",
+	"remove.confirm":                  "Unlink <b>%s</b> from this chat? Its webhook is deleted from GitHub too.",
+	"remove.confirm_yes":              "🗑 Unlink",
+	"remove.back":                     "« Back",
+	"remove.cancel":                   "✖️ Cancel",
+	"remove.cancelled":                "Nothing was unlinked.",
+	"reload.wait":                     "Please wait %d minutes before reloading again.",
+	"reload.perm_failed":              "Failed to check permissions.",
+	"reload.done":                     "Admin cache reloaded.",
+	"reply.need_reply":                "Please use this command in reply to a notification.",
+	"reply.no_context":                "Context not found. The message might be too old.",
+	"reply.pr_only":                   "This command is only for Pull Requests.",
+	"reply.issue_only":                "This command is only for issues and Pull Requests.",
+	"approve.failed":                  "Failed to approve: %v",
+	"approve.done":                    "✅ PR #%d approved.",
+	"issue.usage":                     "<b>Usage:</b>\n<code>/issue [owner/repo] Title\nDescription on the following lines\nlabels: bug, ui\nassignees: me, octocat\nmilestone: v1.0</code>\n\nReply to a message to quote it in the issue.",
+	"issue.no_repo":                   "No repository is linked to this chat. Use <code>/issue owner/repo Title</code> or /addrepo first.",
+	"issue.pick_repo":                 "Where should I open <b>%s</b>?",
+	"issue.expired":                   "This issue draft has expired. Please send /issue again.",
+	"issue.not_yours":                 "Only the person who sent /issue can pick the repository.",
+	"issue.milestone_not_found":       "❌ Milestone <b>%s</b> not found in <b>%s</b>.",
+	"issue.failed":                    "❌ Failed to create the issue: %s",
+	"issue.created":                   "✅ Created issue <a href=\"%s\">#%d</a> in <b>%s</b>: %s\n\nReply to this message to comment.",
+	"triage.labeled":                  "✅ Added <b>%s</b> to #%d.",
+	"triage.unlabeled":                "✅ Removed <b>%s</b> from #%d.",
+	"triage.assigned":                 "✅ Assigned <b>%s</b> to #%d.",
+	"triage.unassigned":               "✅ Unassigned <b>%s</b> from #%d.",
+	"triage.milestoned":               "✅ Milestone of #%d set to <b>%s</b>.",
+	"triage.milestone_cleared":        "✅ Milestone of #%d cleared.",
+	"triage.pick_label":               "Pick a label to add to #%d:",
+	"triage.pick_unlabel":             "Pick a label to remove from #%d:",
+	"triage.pick_assign":              "Pick a user to assign to #%d:",
+	"triage.pick_unassign":            "Pick a user to unassign from #%d:",
+	"triage.pick_milestone":           "Pick a milestone for #%d:",
+	"triage.nothing":                  "Nothing to choose from.",
+	"picker.expired":                  "This menu has expired. Please send the command again.",
+	"picker.not_yours":                "Only the person who sent the command can use this menu.",
+	"merge.usage":                     "Usage: /merge [merge|squash|rebase] (reply to a PR notification)",
+	"merge.title":                     "<b>Merge PR #%d</b>: %s",
+	"merge.method":                    "<b>Method:</b> %s",
+	"merge.method.merge":              "Merge commit",
+	"merge.method.squash":             "Squash",
+	"merge.method.rebase":             "Rebase",
+	"merge.commit_title":              "<b>Commit title:</b> <code>%s</code>",
+	"merge.reviews":                   "<b>Reviews:</b> %s",
+	"merge.review.approved":           "✅ approved",
+	"merge.review.changes_requested":  "❌ changes requested",
+	"merge.review.review_required":    "⏳ review required",
+	"merge.review.none":               "not required",
+	"merge.checks":                    "<b>Checks:</b> %d passed, %d pending, %d failed",
+	"merge.blocked":                   "⚠️ <b>Cannot merge yet:</b>",
+	"merge.blocker.closed":            "The PR is closed.",
+	"merge.blocker.merged":            "The PR is already merged.",
+	"merge.blocker.draft":             "The PR is a draft.",
+	"merge.blocker.conflicts":         "There are merge conflicts with the base branch.",
+	"merge.blocker.changes_requested": "A reviewer requested changes.",
+	"merge.blocker.review_required":   "An approving review is required.",
+	"merge.blocker.checks_failed":     "Required checks failed: %s",
+	"merge.blocker.checks_pending":    "Required checks are still running: %s",
+	"merge.blocker.behind":            "The branch is out of date with the base branch.",
+	"merge.blocker.blocked":           "Blocked by branch protection or rulesets.",
+	"merge.blocker.unknown":           "GitHub is still computing mergeability. Try again in a moment.",
+	"merge.auto_already":              "⏳ Auto-merge is already enabled.",
+	"merge.do":                        "🔀 Merge",
+	"merge.auto":                      "⏳ Enable auto-merge",
+	"merge.cancel":                    "✖️ Cancel",
+	"merge.cancelled":                 "Merge cancelled.",
+	"merge.done":                      "✅ Merged PR #%d (%s) as <code>%s</code>.",
+	"merge.auto_done":                 "⏳ Auto-merge enabled for PR #%d. GitHub merges it once all requirements are met.",
+	"merge.update":                    "🔄 Update branch",
+	"merge.update_done":               "🔄 Updating PR #%d with the base branch. Checks run again on the new head; use /merge once they pass.",
+	"merge.failed":                    "❌ Merge failed: %s",
+	"review.usage":                    "Usage: <code>/review approve|changes|comment &lt;text&gt;</code> in reply to a PR notification. The text is required for changes and comment.",
+	"review.failed":                   "❌ Review failed: %s",
+	"review.done.approve":             "✅ <a href=\"%s\">Approved</a> PR #%d.",
+	"review.done.changes":             "❌ <a href=\"%s\">Requested changes</a> on PR #%d.",
+	"review.done.comment":             "💬 <a href=\"%s\">Reviewed</a> PR #%d.",
+	"dismiss.usage":                   "Usage: /dismiss <reason> (reply to a PR notification)",
+	"dismiss.none":                    "You have no approval or change request to dismiss on PR #%d.",
+	"dismiss.failed":                  "❌ Dismissing the review failed: %s",
+	"dismiss.done":                    "🚫 <a href=\"%s\">Review</a> on PR #%d dismissed.",
+	"rerun.usage":                     "Usage: /rerun [failed|all] (reply to a failed workflow notification)",
+	"rerun.not_run":                   "This command only works in reply to a failed workflow notification.",
+	"rerun.failed":                    "❌ Action failed: %s",
+	"rerun.done.f":                    "🔁 Re-running failed jobs.",
+	"rerun.done.a":                    "🔁 Re-running all jobs.",
+	"rerun.done.c":                    "⛔ Workflow run cancelled.",
+	"rerun.marked.f":                  "🔁 Failed jobs re-run by %s (attempt %d)",
+	"rerun.marked.a":                  "🔁 All jobs re-run by %s (attempt %d)",
+	"rerun.marked.c":                  "⛔ Cancelled by %s",
+	"deploy.usage":                    "Usage: /deploy approve|reject [comment] (reply to a deployment review notification)",
+	"deploy.not_pending":              "This command only works in reply to a deployment review notification.",
+	"deploy.none_pending":             "No deployments of this run are waiting for review.",
+	"deploy.not_reviewer":             "You are not a required reviewer for the environments this run is waiting on.",
+	"deploy.failed":                   "❌ Deployment review failed: %s",
+	"deploy.done.approved":            "✅ Deployment approved.",
+	"deploy.done.rejected":            "❌ Deployment rejected.",
+	"deploy.marked.approved":          "✅ Deployment to %s approved by %s",
+	"deploy.marked.rejected":          "❌ Deployment to %s rejected by %s",
+	"alert.failed":                    "❌ Alert update failed: %s",
+	"alert.dismissed.tr":              "🙈 Dismissed as tolerable risk by %s",
+	"alert.dismissed.fp":              "🙈 Dismissed as a false positive by %s",
+	"alert.dismissed.nb":              "🙈 Dismissed for lack of bandwidth by %s",
+	"alert.dismissed.fs":              "🙈 Dismissed by %s, a fix has started",
+	"alert.resolved.rv":               "🔑 Resolved as revoked by %s",
+	"alert.resolved.fp":               "🙅 Resolved as a false positive by %s",
+	"alert.reopened":                  "↩️ Reopened by %s",
+	"alert.tracked":                   "📝 Tracking issue opened by %s: %s",
+	"alert.tracked_assigned":          "📝 Tracking issue opened by %s: %s. The alert is assigned to %s.",
+	"alert.public_warning":            "⚠️ This repository is public. A tracking issue discloses the alert to everyone before it is fixed. Confirm only if that is fine.",
+	"dispatch.no_repo":                "No repository is linked to this chat. Use <code>/dispatch owner/repo [workflow]</code>.",
+	"dispatch.pick_repo":              "Pick the repository to run a workflow in:",
+	"dispatch.no_workflows":           "No workflows with a <code>workflow_dispatch</code> trigger in %s.",
+	"dispatch.workflow_not_found":     "No <code>workflow_dispatch</code> workflow matching <b>%s</b> in %s.",
+	"dispatch.pick_workflow":          "Pick a workflow to run in <b>%s</b>:",
+	"dispatch.input":                  "🚀 <b>%s</b>: input %d of %d",
+	"dispatch.input_required":         "(required)",
+	"dispatch.reply":                  "Reply with a value.",
+	"dispatch.reply_default":          "Reply with a value, or <code>-</code> to use the default <code>%s</code>.",
+	"dispatch.reply_optional":         "Reply with a value, or <code>-</code> to leave it empty.",
+	"dispatch.required":               "This input is required. Reply with a value.",
+	"dispatch.not_number":             "<code>%s</code> is not a number. Reply with a number.",
+	"dispatch.pick_ref":               "🚀 <b>%s</b>: pick the branch to run on:",
+	"dispatch.confirm":                "🚀 Run <b>%s</b> in %s on <code>%s</code>?",
+	"dispatch.run":                    "🚀 Run",
+	"dispatch.cancel":                 "✖️ Cancel",
+	"dispatch.cancelled":              "Dispatch cancelled.",
+	"dispatch.failed":                 "❌ Dispatch failed: %s",
+	"dispatch.dispatched":             "🚀 <b>%s</b> dispatched on <code>%s</code>. Waiting for the run…",
+	"dispatch.status":                 "🚀 <b>%s</b> on <code>%s</code>\n%s %s",
+	"dispatch.view_run":               "View Run",
+	"list.no_repo":                    "No repository is linked to this chat. Use <code>%s owner/repo [filters]</code>.",
+	"list.failed":                     "❌ Search failed: %s",
+	"list.header.pr":                  "🔀 <b>Pull requests</b>: %d found (page %d)",
+	"list.header.issue":               "🐛 <b>Issues</b>: %d found (page %d)",
+	"list.empty":                      "Nothing matches.",
+	"list.draft":                      "📝 Draft",
+	"list.labels":                     "🏷 %s",
+	"list.assignees":                  "👤 %s",
+	"list.comments":                   "💬 %d comments",
+	"list.approve":                    "✅ Approve",
+	"list.close":                      "🔒 Close",
+	"list.label":                      "🏷 Label",
+	"list.back":                       "⬅️ Back",
+	"list.open":                       "View on GitHub",
+	"comment.delete_usage":            "Reply to your message that was posted as a GitHub comment with /delete.",
+	"comment.not_posted":              "That message was not posted to GitHub, or it is too old to track.",
+	"comment.not_yours":               "You can only delete your own comments.",
+	"comment.delete_failed":           "❌ Failed to delete the comment: %s",
+	"comment.deleted":                 "🗑 Comment deleted on GitHub.",
+	"comment.posted":                  "✅ <a href=\"%s\">Comment posted</a>",
+	"comment.connect_hint":            "💡 Replies to notifications are posted as GitHub comments once you connect your account. Send /connect to me in a private chat.",
+	"comment.err.no_permission":       "❌ Your GitHub account has no permission to comment there.",
+	"comment.err.locked":              "🔒 The conversation is locked on GitHub, so the comment was not saved.",
+	"comment.err.archived":            "📦 The repository is archived and read-only, so the comment was not saved.",
+	"comment.err.rate_limited":        "⏳ GitHub rate limit reached. Try again in a few minutes.",
+	"comment.err.failed":              "❌ Failed to save the comment: %s",
+	"answer.not_comment":              "Reply to a discussion comment notification to mark it as the answer.",
+	"answer.failed":                   "❌ Failed to mark the answer: %s",
+	"answer.done":                     "✅ Marked as the answer.",
+	"commit.pick":                     "Which commit should the comment go on?",
+	"commit.cancel":                   "✖ Cancel",
+	"commit.expired":                  "This choice has expired. Reply to the notification again.",
+	"commit.not_yours":                "Only the author of the reply can pick the commit.",
+	"state.failed":                    "Failed to update state: %v",
+	"state.closed":                    "✅ Issue/PR #%d closed.",
+	"state.reopened":                  "✅ Issue/PR #%d reopened.",
+	"pr.action_expired":               "Action expired. Please open the PR link manually.",
+	"pr.not_linked":                   "This chat is not linked to the repo.",
+	"pr.approved":                     "Approved!",
+	"pr.closed":                       "Closed!",
+
+	// Settings
+	"settings.none_linked":   "No repositories linked. Use /addrepo first.",
+	"settings.select_repo":   "Select a repository to configure:",
+	"settings.notifications": "🔔 Notification settings",
+	"settings.language":      "🌐 Language: %s",
+	"settings.config_for":    "Configuration for <b>%s</b>:",
+	"settings.preset_push":   "Just the push event",
+	"settings.preset_all":    "Send me everything",
+	"settings.individual":    "Let me select individual events",
+	"settings.back_list":     "🔙 Back to Repo List",
+	"settings.back":          "🔙 Back",
+	"settings.fetch_failed":  "Failed to fetch GitHub settings.",
+	"settings.update_failed": "Failed to update GitHub.",
+	"settings.hook_fetch":    "Failed to fetch GitHub hook.",
+	"settings.hook_update":   "Failed to update GitHub hook.",
+	"settings.all_done":      "✅ <b>Success!</b> I've updated the repository settings to send <b>everything</b>.",
+	"settings.push_done":     "✅ <b>Success!</b> I've updated the repository settings to send <b>push events only</b>.",
+	"events.must_connect":    "Error: You must be connected to GitHub to view/edit settings.",
+	"events.auth":            "Auth error. Please reconnect.",
+	"events.fetch_failed":    "Error fetching webhook settings from GitHub. Check permissions.",
+	"events.edit_on_github":  "🌐 Edit more on GitHub",
+	"events.title":           "Individual Events for <b>%s</b>:",
+	"lang.pick":              "Choose the language for bot replies and notifications in this chat:",
+	"lang.auto":              "🔄 Automatic (each user's Telegram language)",
+	"lang.auto_short":        "Automatic",
+
+	// Notification settings
+	"notify.on":  "On",
+	"notify.off": "Off",
+	"notify.menu": "<b>Notification settings</b>\n\n" +
+		"<b>Quiet hours:</b> %s\n" +
+		"<b>Window:</b> %02d:00 – %02d:00 (%s)\n" +
+		"<b>During quiet hours:</b> %s\n\n" +
+		"Events marked as loud always play a sound, even during quiet hours.\n" +
+		"Grouped CI posts one message per commit and edits it as workflows and jobs finish.\n" +
+		"Merged pushes edit the previous push message for the same branch instead of sending a new one.",
+	"notify.mode_silent":   "🔕 Send silently",
+	"notify.mode_hold":     "⏰ Hold until they end",
+	"notify.quiet":         "🌙 Quiet hours: %s",
+	"notify.start":         "Start %02d:00",
+	"notify.end":           "End %02d:00",
+	"notify.timezone":      "🌍 Timezone: %s",
+	"notify.sounds":        "🔊 Per-event sounds",
+	"notify.ci":            "🧪 Group CI by commit: %s",
+	"notify.ci_final":      "⏭ Only post finished CI: %s",
+	"ci.title":             "🧪 *CI for* [`%s`](%s)",
+	"ci.branch":            " *on* `%s`",
+	"ci.more":              "_\\.\\.\\. and %d more_",
+	"ci.repository":        "*Repository:* %s",
+	"ci.commit":            "*Commit:* %s",
+	"ci.overall":           "*Overall:* %s",
+	"ci.view_checks":       "View Checks",
+	"ci.running":           "⏳ Running",
+	"ci.failed":            "❌ Failed",
+	"ci.passed":            "✅ Passed",
+	"notify.push_window":   "🔨 Merge pushes within: %s",
+	"notify.reply_confirm": "💬 Confirm posted replies: %s",
+	"notify.tz_pick":       "Select the timezone for quiet hours:",
+	"notify.sounds_text": "<b>Per-event sounds</b>\n\n" +
+		"▫️ follow quiet hours\n" +
+		"🔕 always silent\n" +
+		"🔊 always play a sound\n\n" +
+		"Tap an event to cycle through the modes.",
+}
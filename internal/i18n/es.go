@@ -0,0 +1,793 @@
+package i18n
+
+var es = catalog{
+	Messages: map[string]string{
+		"start": `<b>¡Bienvenido al bot de GitHub!</b> 🤖
+
+Puedo ayudarte a gestionar tus repositorios y notificaciones de GitHub directamente desde Telegram.
+
+<b>Primeros pasos:</b>
+1. Usa /connect para vincular tu cuenta de GitHub.
+2. Usa /addrepo para vincular un repositorio y empezar a recibir notificaciones.
+3. Usa /settings para personalizar tus notificaciones.
+
+¿Necesitas ayuda? Escribe /help para ver la lista completa de comandos.`,
+
+		"help": `<b>Comandos del bot de GitHub:</b>
+
+<b>Cuenta</b>
+/connect - Vincula tu cuenta de GitHub (<i>solo en chat privado</i>)
+/mentions [on|off] - Recibe una mención cuando te asignen, te pidan una revisión o te mencionen
+/me - Notificaciones personales y preferencias de menciones (<i>chat privado</i>)
+
+<b>Repositorios</b>
+/addrepo [owner/repo|nombre] - Vincula un repositorio, o busca por nombre los que administras y vincula varios a la vez
+/addorg &lt;org&gt; [patrón...] [-patrón...] - Vincula todos los repositorios de una organización, también los futuros
+/removerepo [owner/repo] - Desvincula un repositorio; sin argumento, elígelo de una lista
+/repos - Lista los repositorios vinculados con su tema, eventos, estado del webhook y quién los vinculó
+/close - Cierra un issue o PR (responde a la notificación).
+/reopen - Reabre un issue o PR (responde a la notificación).
+/approve - Aprueba un PR (responde a la notificación).
+/issue [owner/repo] Título - Abre un issue; descripción, <code>labels:</code>, <code>assignees:</code> y <code>milestone:</code> en las líneas siguientes. Responde a un mensaje para citarlo.
+/label [etiquetas] - Añade etiquetas separadas por comas (responde a la notificación).
+/unlabel [etiquetas] - Quita etiquetas (responde a la notificación).
+/assign [@usuario|me] - Asigna usuarios (responde a la notificación).
+/unassign [@usuario|me] - Desasigna usuarios (responde a la notificación).
+/milestone [título|none] - Fija o quita el milestone (responde a la notificación). Sin argumento, cada uno muestra un selector.
+/merge [merge|squash|rebase] - Fusiona un PR tras comprobar conflictos, revisiones y checks obligatorios (responde a la notificación).
+/review approve|changes|comment &lt;texto&gt; - Envía una revisión de un PR (responde a la notificación).
+/dismiss &lt;motivo&gt; - Descarta tu última revisión de un PR (responde a la notificación).
+/rerun [failed|all] - Vuelve a ejecutar un workflow fallido (responde a la notificación).
+/deploy approve|reject [comentario] - Aprueba o rechaza un despliegue pendiente de revisión (responde a la notificación).
+/dispatch [owner/repo] [workflow] - Ejecuta un workflow con workflow_dispatch y sus inputs, y sigue la ejecución.
+/prs [owner/repo] [filtros] - Explora los PRs abiertos. Filtros: author:, label:, review:required, is:draft.
+/issues [owner/repo] [filtros] - Explora los issues abiertos. Filtros: author:, label:, assignee:.
+/delete - Borra el comentario de GitHub publicado desde el mensaje respondido.
+/answer - Marca el comentario de discusión respondido como respuesta.
+
+<b>Configuración</b>
+/settings - Configura las notificaciones y el idioma
+/template - Personaliza los mensajes de notificación
+/reload - Recarga la caché de administradores
+
+
+<b>¿Necesitas más ayuda?</b>
+Visita la <a href="https://github.com/AshokShau/GithubBot">página de GitHub</a> para más detalles.`,
+
+		"privacy": `<b>Política de privacidad</b>
+
+Valoramos tu privacidad y nos comprometemos a proteger tus datos. Esta política describe cómo recopilamos, usamos y protegemos tu información.
+
+<b>1. Recopilación de datos</b>
+• <b>Datos de Telegram:</b> Guardamos tu ID de usuario, el ID del chat y datos básicos del perfil para enviar notificaciones y gestionar permisos.
+• <b>Datos de GitHub:</b> Al conectar tu cuenta guardamos tu token OAuth cifrado. También guardamos los nombres de los repositorios que vinculas y los IDs de los webhooks creados.
+• <b>Eventos:</b> Procesamos los eventos de webhook de GitHub (p. ej., pushes, issues) para enviar notificaciones a tu chat. Su contenido se procesa en tiempo real y no se almacena de forma permanente.
+
+<b>2. Uso de los datos</b>
+• <b>Funcionalidad:</b> Tus datos se usan únicamente para prestar los servicios del bot: enviar notificaciones, gestionar repositorios vinculados y verificar permisos.
+• <b>Seguridad:</b> Tus tokens OAuth se cifran con AES-GCM antes de guardarse en la base de datos.
+
+<b>3. Compartición de datos</b>
+• <b>No</b> compartimos, vendemos ni alquilamos tus datos personales a terceros.
+• Los datos solo se comparten con las APIs de GitHub en la medida necesaria para realizar las acciones solicitadas (p. ej., crear webhooks).
+
+<b>4. Control y derechos</b>
+• <b>Desconexión:</b> Puedes desvincular tu cuenta de GitHub en cualquier momento, lo que invalida el token guardado.
+• <b>Eliminación:</b> Puedes eliminar repositorios con /removerepo. Para solicitar el borrado completo de tus datos, contacta con el desarrollador o simplemente bloquea el bot.
+
+<b>5. Contacto</b>
+Si tienes preguntas, visita nuestro <a href="https://github.com/AshokShau/GithubBot">repositorio de GitHub</a> o únete a nuestro <a href="https://t.me/GuardxSupport">grupo de soporte en Telegram</a>.`,
+
+		"cmd.start":      "Inicia el bot",
+		"cmd.help":       "Muestra los comandos disponibles",
+		"cmd.connect":    "Conecta tu cuenta de GitHub",
+		"cmd.addrepo":    "Vincula un repositorio",
+		"cmd.addorg":     "Vincula todos los repositorios de una organización",
+		"cmd.removerepo": "Desvincula un repositorio",
+		"cmd.repos":      "Lista los repositorios vinculados",
+		"cmd.settings":   "Configura notificaciones e idioma",
+		"cmd.template":   "Personaliza los mensajes de notificación",
+		"cmd.close":      "Cierra un issue o PR (responde a la notificación)",
+		"cmd.reopen":     "Reabre un issue o PR (responde a la notificación)",
+		"cmd.approve":    "Aprueba un PR (responde a la notificación)",
+		"cmd.issue":      "Abre un issue (responde para citar un mensaje)",
+		"cmd.label":      "Añade etiquetas (responde a la notificación)",
+		"cmd.assign":     "Asigna usuarios (responde a la notificación)",
+		"cmd.milestone":  "Fija el milestone (responde a la notificación)",
+		"cmd.merge":      "Fusiona un PR (responde a la notificación)",
+		"cmd.review":     "Revisa un PR (responde a la notificación)",
+		"cmd.dismiss":    "Descarta tu revisión (responde a la notificación)",
+		"cmd.rerun":      "Reejecuta un workflow fallido (responde a la notificación)",
+		"cmd.deploy":     "Aprueba o rechaza un despliegue pendiente (responde a la notificación)",
+		"cmd.dispatch":   "Ejecuta un workflow con inputs",
+		"cmd.prs":        "Explora los pull requests abiertos",
+		"cmd.issues":     "Explora los issues abiertos",
+		"cmd.delete":     "Borra tu comentario publicado (responde a él)",
+		"cmd.answer":     "Marca un comentario de discusión como respuesta",
+		"cmd.privacy":    "Muestra la política de privacidad",
+		"cmd.logout":     "Desconecta tu cuenta de GitHub",
+		"cmd.mentions":   "Activa o desactiva las menciones en Telegram",
+		"cmd.me":         "Preferencias de notificaciones personales",
+		"cmd.reload":     "Recarga la caché de administradores",
+
+		"connect.private_only": "⚠️ El comando /connect solo puede usarse en un chat privado con el bot.",
+		"connect.prompt":       "[Conecta tu cuenta de GitHub](%s) para configurar los webhooks automáticamente y realizar acciones como aprobar PRs.",
+		"oauth.connected":      "✅ ¡Cuenta de GitHub <b>%s</b> conectada!",
+		"oauth.page_title":     "Conectado",
+		"oauth.page_heading":   "Autenticación correcta",
+		"oauth.page_text":      "Tu cuenta de GitHub se ha conectado.",
+		"oauth.page_return":    "Si la ventana no se cierra sola, puedes <a href=\"%s\">volver a Telegram</a>.",
+		"connect.first":        "Primero [conecta tu cuenta de GitHub](%s).",
+		"connect.first_repo":   "Primero [conecta tu cuenta de GitHub](%s) para vincular el repositorio %s.",
+		"logout.error":         "Error al cerrar la sesión.",
+		"logout.done":          "✅ Has cerrado la sesión. Usa /connect para volver a conectarte.",
+		"mentions.status_on":   "🔔 Las menciones están <b>activadas</b> para <b>%s</b>. Las notificaciones te mencionan cuando te asignan, te piden una revisión o te @mencionan.\nUsa <code>/mentions off</code> para desactivarlas.",
+		"mentions.status_off":  "🔕 Las menciones están <b>desactivadas</b> para <b>%s</b>. Las notificaciones enlazan a tu perfil de GitHub.\nUsa <code>/mentions on</code> para activarlas.",
+		"mentions.usage":       "Uso: /mentions on|off",
+		"mentions.enabled":     "🔔 Se te mencionará en las notificaciones.",
+		"mentions.disabled":    "🔕 Ya no se te mencionará en las notificaciones.",
+		"me.private_only":      "⚠️ El comando /me solo se puede usar en un chat privado con el bot.",
+		"me.menu":              "<b>Notificaciones personales</b> de <b>%s</b>\n\nTe escribo aquí cuando te piden revisar un PR, te asignan o te @mencionan en un repositorio vinculado a cualquier chat. Toca para cambiar.",
+		"me.review":            "👀 Solicitudes de revisión: %s",
+		"me.assign":            "📌 Asignaciones: %s",
+		"me.mention":           "💬 Menciones: %s",
+		"me.group_mentions":    "🔔 Mencionarme en notificaciones de grupo: %s",
+		"me.muted":             "🔕 Desactivado. Usa /me para volver a activarlo.",
+		"personal.review":      "*👀 Te piden una revisión*",
+		"personal.assign":      "*📌 Te han asignado*",
+		"personal.mention":     "*💬 Te han mencionado*",
+		"personal.view":        "Ver en GitHub",
+		"personal.approve":     "✅ Aprobar",
+		"personal.off":         "🔕 Desactivar estos avisos",
+
+		"admin.add_repo":        "Solo los administradores pueden añadir repositorios.",
+		"admin.remove_repo":     "Solo los administradores pueden eliminar repositorios.",
+		"admin.settings":        "Solo los administradores pueden modificar la configuración.",
+		"admin.change_settings": "Solo los administradores pueden cambiar la configuración",
+		"admin.template":        "Solo los administradores pueden modificar las plantillas.",
+		"template.usage": `<b>Plantillas de notificación</b>
+
+Personaliza cómo se ven las notificaciones en este chat con la sintaxis de Go <a href="https://pkg.go.dev/text/template">text/template</a>. El resultado se envía como MarkdownV2 de Telegram.
+
+<b>Uso</b>
+/template - Lista las plantillas personalizadas
+/template show &lt;evento[:acción]&gt; - Muestra una plantilla
+/template set &lt;evento[:acción]&gt; - Define una plantilla (la plantilla en las líneas siguientes)
+/template preview &lt;evento[:acción]&gt; - Vista previa con un payload de ejemplo (opcionalmente con una plantilla nueva en las líneas siguientes)
+/template reset &lt;evento[:acción]&gt;|all - Restaura el mensaje predeterminado
+
+<b>Campos</b>
+<code>.Event .Action .Repo .RepoURL .Sender .Number .Title .Body .URL .State .Branch .Commits .Payload</code>
+Cada commit tiene <code>.SHA .Message .Author .URL</code>.
+
+<b>Funciones</b>
+<code>md</code> escapa texto, <code>mdurl</code> escapa una URL, <code>user</code> enlaza un usuario, <code>repo</code> enlaza un repositorio, <code>link texto url</code>, <code>body</code> convierte Markdown, <code>quote</code> cita un texto largo, <code>short</code> acorta un SHA, <code>truncate n</code>, <code>title</code>.
+Escapa siempre los campos con <code>md</code>, o Telegram rechazará el mensaje.
+
+<b>Ejemplo</b>
+<code>/template set issues:opened
+🐞 {{user .Sender}} abrió {{link (printf "#%d %s" .Number .Title) .URL}} en {{repo .Repo}}</code>`,
+		"template.none":         "No hay plantilla personalizada para <code>%s</code>.",
+		"template.show":         "<b>Plantilla para</b> <code>%s</code>:\n<pre>%s</pre>",
+		"template.body_missing": "Pon la plantilla en las líneas siguientes al comando.",
+		"template.invalid":      "❌ Plantilla no válida: %s",
+		"template.save_failed":  "No se pudo guardar la plantilla.",
+		"template.saved":        "✅ Plantilla para <code>%s</code> guardada. Usa <code>/template preview %s</code> para revisarla.",
+		"template.rejected":     "❌ Telegram rechazó el mensaje generado: %s\n\nAsegúrate de escapar los campos con <code>md</code>.",
+		"template.reset_done":   "✅ Formato de notificación predeterminado restaurado.",
+		"template.custom":       "<b>Plantillas personalizadas en este chat:</b>",
+		"template.samples":      "<b>Payloads de ejemplo:</b> %s",
+		"admin.reload":          "Solo los administradores pueden recargar la caché.",
+
+		"err.auth":           "Error de autenticación.",
+		"err.auth_reconnect": "Error de autenticación. Vuelve a conectarte con /connect",
+		"err.auth_again":     "Error de autenticación. Usa /connect de nuevo.",
+		"err.client":         "No se pudo crear el cliente de GitHub.",
+		"err.webhook_token":  "Error al generar el token del webhook.",
+		"err.link_repo":      "Error al vincular el repositorio.",
+		"err.api":            "Error de la API de GitHub.",
+		"err.failed":         "Error: %v",
+		"err.token_revoked":  "Error de autenticación de GitHub. El token fue revocado o ha caducado.",
+		"err.auth_failed":    "⚠️ <b>Falló la autenticación con GitHub.</b>\nParece que tu token ha caducado o fue revocado. Usa /connect de nuevo.",
+		"err.forbidden":      "⛔ Tu cuenta de GitHub no tiene permiso para esto: %s",
+		"err.connect_first":  "Primero usa /connect para conectarte a GitHub.",
+		"err.connect_via":    "Primero conecta tu cuenta de GitHub con /connect",
+		"err.load_settings":  "No se pudo cargar la configuración.",
+		"err.save_settings":  "No se pudo guardar la configuración.",
+
+		"repo.invalid_format":        "Formato de repositorio no válido. Usa owner/repo",
+		"repo.not_found":             "❌ <b>Repositorio no encontrado.</b>\nComprueba el nombre y que tienes acceso.",
+		"repo.fetch_error":           "Error al obtener el repositorio: %v",
+		"repo.no_permission":         "❌ <b>Permisos insuficientes.</b>\nNecesitas acceso de administrador al repositorio <b>%s</b> para crear webhooks.",
+		"repo.webhook_failed":        "⚠️ <b>No se pudo crear el webhook.</b>\nAsegúrate de tener permisos de administrador e inténtalo de nuevo.",
+		"repo.webhook_failed_v":      "No se pudo crear el webhook: %v. Revisa los permisos",
+		"repo.linked":                "¡Repositorio <b>%s</b> vinculado correctamente!",
+		"org.usage":                  "Uso: /addorg <org> [patrón...] [-patrón...]\nPatrones como api-* limitan los repositorios, los que empiezan por - los excluyen.",
+		"org.bad_pattern":            "Patrón de repositorio no válido. Usa globs como api-* o -legacy-*.",
+		"org.not_found":              "No se encontró la organización <b>%s</b>.",
+		"org.scope_missing":          "Tu conexión con GitHub no tiene el permiso <code>%s</code> que necesitan los webhooks de organización. Ejecuta /connect de nuevo en privado para concederlo.",
+		"org.already_linked":         "<b>%s</b> ya está vinculada aquí. Quítala primero con <code>/removerepo %s</code>.",
+		"org.no_permission":          "No puedes crear webhooks en <b>%s</b>. Solo los propietarios de la organización pueden.",
+		"org.linked":                 "✅ Todos los repositorios de <b>%s</b> están vinculados, también los que se creen más adelante.",
+		"org.include":                "Solo: %s",
+		"org.exclude":                "Excepto: %s",
+		"repo.linked_check":          "✅ ¡Repositorio <b>%s</b> vinculado correctamente!",
+		"repo.connect_to_list":       "Primero conecta tu cuenta de GitHub con /connect para ver tus repositorios.",
+		"repo.fetch_failed":          "No se pudieron obtener los repositorios de GitHub.",
+		"repo.none_found":            "No se encontraron repositorios.",
+		"repo.select_add":            "Elige un repositorio para añadir (página %d):",
+		"repo.search_hint":           "Consejo: busca los repositorios que administras con /addrepo <nombre>.",
+		"repo.search_header":         "🔎 Repositorios que administras que coinciden con <code>%s</code>: %d (página %d/%d)\nToca uno para vincularlo a este chat. ✅ marca los ya vinculados y 🏢 los que ya cubre una organización vinculada.",
+		"repo.search_multi_hint":     "Toca los repositorios para seleccionarlos y vincúlalos todos a la vez.",
+		"repo.search_truncated":      "⚠️ Solo se buscó en tus %d repositorios actualizados más recientemente; precisa el nombre si falta el que buscas.",
+		"repo.search_empty":          "Ningún repositorio que administres coincide con <code>%s</code>.",
+		"repo.search_select":         "☑️ Seleccionar varios",
+		"repo.search_link_selected":  "🔗 Vincular %d seleccionados",
+		"repo.search_single":         "✖️ Cancelar selección",
+		"repo.search_none_selected":  "Selecciona al menos un repositorio primero.",
+		"repo.search_already_linked": "%s ya está vinculado a este chat.",
+		"repo.search_linked":         "✅ <b>%s</b> vinculado",
+		"repo.search_failed":         "❌ <b>%s</b>: %s",
+		"repo.search_no_admin":       "se necesita acceso de administrador para crear webhooks",
+		"repo.access_denied":         "Repositorio no encontrado o acceso denegado.",
+		"repo.link_not_found":        "Repositorio no encontrado",
+		"repos.none":                 "No hay repositorios vinculados.",
+		"repos.title":                "<b>Repositorios vinculados:</b>\n",
+		"repos.digest":               "⚙️ <i>Se aplica a todos los vínculos de este chat:</i>",
+		"repos.topic":                "📍 Tema #%d",
+		"repos.main_chat":            "📍 Chat principal",
+		"repos.events":               "📡 %d/%d eventos",
+		"repos.hook_inactive":        "⏸ webhook desactivado",
+		"repos.delivery_none":        "🆕 sin entregas todavía",
+		"repos.delivery_ok":          "✅ última entrega %d",
+		"repos.delivery_failed":      "⚠️ última entrega fallida: %s",
+		"repos.hook_missing":         "❌ Webhook no encontrado en GitHub",
+		"repos.hook_unknown":         "❔ Estado del webhook no disponible: %s",
+		"repos.hook_connect":         "❔ Usa /connect para ver el estado del webhook",
+		"repos.linked_by":            "👤 Vinculado por %s el %s",
+		"nav.prev":                   "< Anterior",
+		"nav.next":                   "Siguiente >",
+
+		"remove.not_found":                "Error al buscar el repositorio o no está vinculado.",
+		"remove.db_error":                 "Error al eliminar el repositorio de la base de datos.",
+		"remove.done":                     "Repositorio <b>%s</b> eliminado correctamente.%s",
+		"remove.warn_connect":             "\n\n⚠️ <b>Aviso:</b> No estás conectado a GitHub. No se pudo eliminar el webhook de la configuración del repositorio. Elimínalo manualmente.",
+		"remove.warn_decrypt":             "\n\n⚠️ <b>Aviso:</b> No se pudo descifrar tu token de acceso. El webhook no se eliminó de GitHub.",
+		"remove.warn_client":              "\n\n⚠️ <b>Aviso:</b> No se pudo crear el cliente de GitHub. El webhook no se eliminó.",
+		"remove.warn_auth":                "\n\n⚠️ <b>Aviso:</b> Falló la autenticación con GitHub. El webhook no se eliminó.",
+		"remove.warn_failed":              "\n\n⚠️ <b>Aviso:</b> No se pudo eliminar el webhook de GitHub: %v",
+		"remove.pick":                     "Elige un repositorio para desvincularlo de este chat:",
+		"remove.confirm":                  "¿Desvincular <b>%s</b> de este chat? Su webhook también se elimina de GitHub.",
+		"remove.confirm_yes":              "🗑 Desvincular",
+		"remove.back":                     "« Volver",
+		"remove.cancel":                   "✖️ Cancelar",
+		"remove.cancelled":                "No se desvinculó nada.",
+		"reload.wait":                     "Espera %d minutos antes de volver a recargar.",
+		"reload.perm_failed":              "No se pudieron comprobar los permisos.",
+		"reload.done":                     "Caché de administradores recargada.",
+		"reply.need_reply":                "Usa este comando respondiendo a una notificación.",
+		"reply.no_context":                "No se encontró el contexto. Puede que el mensaje sea demasiado antiguo.",
+		"reply.pr_only":                   "Este comando es solo para pull requests.",
+		"reply.issue_only":                "Este comando es solo para issues y pull requests.",
+		"approve.failed":                  "No se pudo aprobar: %v",
+		"approve.done":                    "✅ PR #%d aprobado.",
+		"issue.usage":                     "<b>Uso:</b>\n<code>/issue [owner/repo] Título\nDescripción en las líneas siguientes\nlabels: bug, ui\nassignees: me, octocat\nmilestone: v1.0</code>\n\nResponde a un mensaje para citarlo en el issue.",
+		"issue.no_repo":                   "No hay repositorios vinculados a este chat. Usa <code>/issue owner/repo Título</code> o primero /addrepo.",
+		"issue.pick_repo":                 "¿Dónde abro <b>%s</b>?",
+		"issue.expired":                   "Este borrador de issue ha caducado. Envía /issue de nuevo.",
+		"issue.not_yours":                 "Solo quien envió /issue puede elegir el repositorio.",
+		"issue.milestone_not_found":       "❌ No se encontró el milestone <b>%s</b> en <b>%s</b>.",
+		"issue.failed":                    "❌ No se pudo crear el issue: %s",
+		"issue.created":                   "✅ Issue <a href=\"%s\">#%d</a> creado en <b>%s</b>: %s\n\nResponde a este mensaje para comentar.",
+		"triage.labeled":                  "✅ Se añadió <b>%s</b> a #%d.",
+		"triage.unlabeled":                "✅ Se quitó <b>%s</b> de #%d.",
+		"triage.assigned":                 "✅ Se asignó <b>%s</b> a #%d.",
+		"triage.unassigned":               "✅ Se desasignó <b>%s</b> de #%d.",
+		"triage.milestoned":               "✅ Milestone de #%d fijado en <b>%s</b>.",
+		"triage.milestone_cleared":        "✅ Se quitó el milestone de #%d.",
+		"triage.pick_label":               "Elige una etiqueta para añadir a #%d:",
+		"triage.pick_unlabel":             "Elige una etiqueta para quitar de #%d:",
+		"triage.pick_assign":              "Elige a quién asignar #%d:",
+		"triage.pick_unassign":            "Elige a quién desasignar de #%d:",
+		"triage.pick_milestone":           "Elige un milestone para #%d:",
+		"triage.nothing":                  "No hay nada para elegir.",
+		"picker.expired":                  "Este menú ha caducado. Envía el comando de nuevo.",
+		"picker.not_yours":                "Solo quien envió el comando puede usar este menú.",
+		"merge.usage":                     "Uso: /merge [merge|squash|rebase] (responde a una notificación de PR)",
+		"merge.title":                     "<b>Fusionar PR #%d</b>: %s",
+		"merge.method":                    "<b>Método:</b> %s",
+		"merge.method.merge":              "Commit de merge",
+		"merge.method.squash":             "Squash",
+		"merge.method.rebase":             "Rebase",
+		"merge.commit_title":              "<b>Título del commit:</b> <code>%s</code>",
+		"merge.reviews":                   "<b>Revisiones:</b> %s",
+		"merge.review.approved":           "✅ aprobado",
+		"merge.review.changes_requested":  "❌ cambios solicitados",
+		"merge.review.review_required":    "⏳ se requiere revisión",
+		"merge.review.none":               "no requeridas",
+		"merge.checks":                    "<b>Checks:</b> %d correctos, %d pendientes, %d fallidos",
+		"merge.blocked":                   "⚠️ <b>Aún no se puede fusionar:</b>",
+		"merge.blocker.closed":            "El PR está cerrado.",
+		"merge.blocker.merged":            "El PR ya está fusionado.",
+		"merge.blocker.draft":             "El PR es un borrador.",
+		"merge.blocker.conflicts":         "Hay conflictos con la rama base.",
+		"merge.blocker.changes_requested": "Un revisor solicitó cambios.",
+		"merge.blocker.review_required":   "Se requiere una revisión aprobatoria.",
+		"merge.blocker.checks_failed":     "Fallaron checks obligatorios: %s",
+		"merge.blocker.checks_pending":    "Checks obligatorios en curso: %s",
+		"merge.blocker.behind":            "La rama está desactualizada respecto a la base.",
+		"merge.blocker.blocked":           "Bloqueado por la protección de rama o reglas.",
+		"merge.blocker.unknown":           "GitHub aún está calculando si se puede fusionar. Inténtalo en un momento.",
+		"merge.auto_already":              "⏳ El auto-merge ya está activado.",
+		"merge.do":                        "🔀 Fusionar",
+		"merge.auto":                      "⏳ Activar auto-merge",
+		"merge.cancel":                    "✖️ Cancelar",
+		"merge.cancelled":                 "Fusión cancelada.",
+		"merge.done":                      "✅ PR #%d fusionado (%s) como <code>%s</code>.",
+		"merge.auto_done":                 "⏳ Auto-merge activado para el PR #%d. GitHub lo fusionará cuando se cumplan todos los requisitos.",
+		"merge.update":                    "🔄 Actualizar rama",
+		"merge.update_done":               "🔄 Actualizando el PR #%d con la rama base. Los checks se ejecutan de nuevo sobre el nuevo head; usa /merge cuando pasen.",
+		"merge.failed":                    "❌ La fusión falló: %s",
+		"review.usage":                    "Uso: <code>/review approve|changes|comment &lt;texto&gt;</code> en respuesta a una notificación de PR. El texto es obligatorio para changes y comment.",
+		"review.failed":                   "❌ La revisión falló: %s",
+		"review.done.approve":             "✅ <a href=\"%s\">Aprobado</a> el PR #%d.",
+		"review.done.changes":             "❌ <a href=\"%s\">Cambios solicitados</a> en el PR #%d.",
+		"review.done.comment":             "💬 <a href=\"%s\">Revisión</a> enviada en el PR #%d.",
+		"dismiss.usage":                   "Uso: /dismiss <motivo> (responde a una notificación de PR)",
+		"dismiss.none":                    "No tienes ninguna aprobación ni solicitud de cambios que descartar en el PR #%d.",
+		"dismiss.failed":                  "❌ No se pudo descartar la revisión: %s",
+		"dismiss.done":                    "🚫 <a href=\"%s\">Revisión</a> del PR #%d descartada.",
+		"rerun.usage":                     "Uso: /rerun [failed|all] (responde a la notificación de un workflow fallido)",
+		"rerun.not_run":                   "Este comando solo funciona en respuesta a la notificación de un workflow fallido.",
+		"rerun.failed":                    "❌ La acción falló: %s",
+		"rerun.done.f":                    "🔁 Reejecutando los jobs fallidos.",
+		"rerun.done.a":                    "🔁 Reejecutando todos los jobs.",
+		"rerun.done.c":                    "⛔ Ejecución cancelada.",
+		"rerun.marked.f":                  "🔁 Jobs fallidos reejecutados por %s (intento %d)",
+		"rerun.marked.a":                  "🔁 Todos los jobs reejecutados por %s (intento %d)",
+		"rerun.marked.c":                  "⛔ Cancelado por %s",
+		"deploy.usage":                    "Uso: /deploy approve|reject [comentario] (responde a la notificación de revisión de un despliegue)",
+		"deploy.not_pending":              "Este comando solo funciona en respuesta a la notificación de revisión de un despliegue.",
+		"deploy.none_pending":             "Ningún despliegue de esta ejecución espera revisión.",
+		"deploy.not_reviewer":             "No eres revisor obligatorio de los entornos que espera esta ejecución.",
+		"deploy.failed":                   "❌ La revisión del despliegue falló: %s",
+		"deploy.done.approved":            "✅ Despliegue aprobado.",
+		"deploy.done.rejected":            "❌ Despliegue rechazado.",
+		"deploy.marked.approved":          "✅ Despliegue a %s aprobado por %s",
+		"deploy.marked.rejected":          "❌ Despliegue a %s rechazado por %s",
+		"alert.failed":                    "❌ No se pudo actualizar la alerta: %s",
+		"alert.dismissed.tr":              "🙈 Descartada como riesgo tolerable por %s",
+		"alert.dismissed.fp":              "🙈 Descartada como falso positivo por %s",
+		"alert.dismissed.nb":              "🙈 Descartada por falta de tiempo por %s",
+		"alert.dismissed.fs":              "🙈 Descartada por %s, ya se está corrigiendo",
+		"alert.resolved.rv":               "🔑 Resuelta como revocada por %s",
+		"alert.resolved.fp":               "🙅 Resuelta como falso positivo por %s",
+		"alert.reopened":                  "↩️ Reabierta por %s",
+		"alert.tracked":                   "📝 Issue de seguimiento abierto por %s: %s",
+		"alert.tracked_assigned":          "📝 Issue de seguimiento abierto por %s: %s. La alerta queda asignada a %s.",
+		"alert.public_warning":            "⚠️ Este repositorio es público. Un issue de seguimiento revela la alerta a todo el mundo antes de corregirla. Confirma solo si no hay problema.",
+		"dispatch.no_repo":                "No hay ningún repositorio vinculado a este chat. Usa <code>/dispatch owner/repo [workflow]</code>.",
+		"dispatch.pick_repo":              "Elige el repositorio donde ejecutar un workflow:",
+		"dispatch.no_workflows":           "No hay workflows con <code>workflow_dispatch</code> en %s.",
+		"dispatch.workflow_not_found":     "Ningún workflow con <code>workflow_dispatch</code> coincide con <b>%s</b> en %s.",
+		"dispatch.pick_workflow":          "Elige el workflow a ejecutar en <b>%s</b>:",
+		"dispatch.input":                  "🚀 <b>%s</b>: input %d de %d",
+		"dispatch.input_required":         "(obligatorio)",
+		"dispatch.reply":                  "Responde con un valor.",
+		"dispatch.reply_default":          "Responde con un valor, o <code>-</code> para usar el valor por defecto <code>%s</code>.",
+		"dispatch.reply_optional":         "Responde con un valor, o <code>-</code> para dejarlo vacío.",
+		"dispatch.required":               "Este input es obligatorio. Responde con un valor.",
+		"dispatch.not_number":             "<code>%s</code> no es un número. Responde con un número.",
+		"dispatch.pick_ref":               "🚀 <b>%s</b>: elige la rama donde ejecutarlo:",
+		"dispatch.confirm":                "🚀 ¿Ejecutar <b>%s</b> en %s sobre <code>%s</code>?",
+		"dispatch.run":                    "🚀 Ejecutar",
+		"dispatch.cancel":                 "✖️ Cancelar",
+		"dispatch.cancelled":              "Ejecución cancelada.",
+		"dispatch.failed":                 "❌ No se pudo lanzar el workflow: %s",
+		"dispatch.dispatched":             "🚀 <b>%s</b> lanzado sobre <code>%s</code>. Esperando la ejecución…",
+		"dispatch.status":                 "🚀 <b>%s</b> sobre <code>%s</code>\n%s %s",
+		"dispatch.view_run":               "Ver ejecución",
+		"list.no_repo":                    "No hay ningún repositorio vinculado a este chat. Usa <code>%s owner/repo [filtros]</code>.",
+		"list.failed":                     "❌ La búsqueda falló: %s",
+		"list.header.pr":                  "🔀 <b>Pull requests</b>: %d encontrados (página %d)",
+		"list.header.issue":               "🐛 <b>Issues</b>: %d encontrados (página %d)",
+		"list.empty":                      "No hay resultados.",
+		"list.draft":                      "📝 Borrador",
+		"list.labels":                     "🏷 %s",
+		"list.assignees":                  "👤 %s",
+		"list.comments":                   "💬 %d comentarios",
+		"list.approve":                    "✅ Aprobar",
+		"list.close":                      "🔒 Cerrar",
+		"list.label":                      "🏷 Etiquetar",
+		"list.back":                       "⬅️ Volver",
+		"list.open":                       "Ver en GitHub",
+		"comment.delete_usage":            "Responde con /delete a tu mensaje que se publicó como comentario en GitHub.",
+		"comment.not_posted":              "Ese mensaje no se publicó en GitHub o es demasiado antiguo para seguirlo.",
+		"comment.not_yours":               "Solo puedes borrar tus propios comentarios.",
+		"comment.delete_failed":           "❌ No se pudo borrar el comentario: %s",
+		"comment.deleted":                 "🗑 Comentario borrado en GitHub.",
+		"comment.posted":                  "✅ <a href=\"%s\">Comentario publicado</a>",
+		"comment.connect_hint":            "💡 Las respuestas a las notificaciones se publican como comentarios en GitHub cuando conectas tu cuenta. Envíame /connect en un chat privado.",
+		"comment.err.no_permission":       "❌ Tu cuenta de GitHub no tiene permiso para comentar ahí.",
+		"comment.err.locked":              "🔒 La conversación está bloqueada en GitHub, así que el comentario no se guardó.",
+		"comment.err.archived":            "📦 El repositorio está archivado y es de solo lectura, así que el comentario no se guardó.",
+		"comment.err.rate_limited":        "⏳ Se alcanzó el límite de peticiones de GitHub. Inténtalo de nuevo en unos minutos.",
+		"comment.err.failed":              "❌ No se pudo guardar el comentario: %s",
+		"answer.not_comment":              "Responde a la notificación de un comentario de discusión para marcarlo como respuesta.",
+		"answer.failed":                   "❌ No se pudo marcar la respuesta: %s",
+		"answer.done":                     "✅ Marcado como respuesta.",
+		"commit.pick":                     "¿En qué commit se publica el comentario?",
+		"commit.cancel":                   "✖ Cancelar",
+		"commit.expired":                  "Esta elección ha caducado. Responde de nuevo a la notificación.",
+		"commit.not_yours":                "Solo el autor de la respuesta puede elegir el commit.",
+		"state.failed":                    "No se pudo actualizar el estado: %v",
+		"state.closed":                    "✅ Issue/PR #%d cerrado.",
+		"state.reopened":                  "✅ Issue/PR #%d reabierto.",
+		"pr.action_expired":               "La acción ha caducado. Abre el enlace del PR manualmente.",
+		"pr.not_linked":                   "Este chat no está vinculado al repositorio.",
+		"pr.approved":                     "¡Aprobado!",
+		"pr.closed":                       "¡Cerrado!",
+
+		"settings.none_linked":   "No hay repositorios vinculados. Usa /addrepo primero.",
+		"settings.select_repo":   "Elige un repositorio para configurar:",
+		"settings.notifications": "🔔 Notificaciones",
+		"settings.language":      "🌐 Idioma: %s",
+		"settings.config_for":    "Configuración de <b>%s</b>:",
+		"settings.preset_push":   "Solo el evento push",
+		"settings.preset_all":    "Enviarme todo",
+		"settings.individual":    "Elegir eventos individuales",
+		"settings.back_list":     "🔙 Volver a la lista",
+		"settings.back":          "🔙 Volver",
+		"settings.fetch_failed":  "No se pudo obtener la configuración de GitHub.",
+		"settings.update_failed": "No se pudo actualizar GitHub.",
+		"settings.hook_fetch":    "No se pudo obtener el webhook de GitHub.",
+		"settings.hook_update":   "No se pudo actualizar el webhook de GitHub.",
+		"settings.all_done":      "✅ <b>¡Listo!</b> He configurado el repositorio para enviar <b>todo</b>.",
+		"settings.push_done":     "✅ <b>¡Listo!</b> He configurado el repositorio para enviar <b>solo eventos push</b>.",
+		"events.must_connect":    "Error: debes estar conectado a GitHub para ver o editar la configuración.",
+		"events.auth":            "Error de autenticación. Vuelve a conectarte.",
+		"events.fetch_failed":    "Error al obtener la configuración del webhook de GitHub. Revisa los permisos.",
+		"events.edit_on_github":  "🌐 Editar más en GitHub",
+		"events.title":           "Eventos individuales de <b>%s</b>:",
+		"lang.pick":              "Elige el idioma de las respuestas y notificaciones del bot en este chat:",
+		"lang.auto":              "🔄 Automático (idioma de Telegram de cada usuario)",
+		"lang.auto_short":        "Automático",
+
+		"notify.on":  "Sí",
+		"notify.off": "No",
+		"notify.menu": "<b>Notificaciones</b>\n\n" +
+			"<b>Horas de silencio:</b> %s\n" +
+			"<b>Horario:</b> %02d:00 – %02d:00 (%s)\n" +
+			"<b>Durante las horas de silencio:</b> %s\n\n" +
+			"Los eventos marcados como sonoros siempre suenan, incluso en horas de silencio.\n" +
+			"El CI agrupado publica un mensaje por commit y lo edita a medida que terminan los workflows y jobs.\n" +
+			"Los pushes combinados editan el mensaje anterior de la misma rama en lugar de enviar uno nuevo.",
+		"notify.mode_silent":   "🔕 Enviar sin sonido",
+		"notify.mode_hold":     "⏰ Retener hasta que terminen",
+		"notify.quiet":         "🌙 Horas de silencio: %s",
+		"notify.start":         "Inicio %02d:00",
+		"notify.end":           "Fin %02d:00",
+		"notify.timezone":      "🌍 Zona horaria: %s",
+		"notify.sounds":        "🔊 Sonido por evento",
+		"notify.ci":            "🧪 Agrupar CI por commit: %s",
+		"notify.ci_final":      "⏭ Solo CI terminado: %s",
+		"ci.title":             "🧪 *CI de* [`%s`](%s)",
+		"ci.branch":            " *en* `%s`",
+		"ci.more":              "_\\.\\.\\. y %d más_",
+		"ci.repository":        "*Repositorio:* %s",
+		"ci.commit":            "*Commit:* %s",
+		"ci.overall":           "*Resultado:* %s",
+		"ci.view_checks":       "Ver checks",
+		"ci.running":           "⏳ En curso",
+		"ci.failed":            "❌ Fallido",
+		"ci.passed":            "✅ Correcto",
+		"notify.push_window":   "🔨 Combinar pushes en: %s",
+		"notify.reply_confirm": "💬 Confirmar respuestas publicadas: %s",
+		"notify.tz_pick":       "Elige la zona horaria de las horas de silencio:",
+		"notify.sounds_text": "<b>Sonido por evento</b>\n\n" +
+			"▫️ sigue las horas de silencio\n" +
+			"🔕 siempre sin sonido\n" +
+			"🔊 siempre con sonido\n\n" +
+			"Toca un evento para cambiar de modo.",
+	},
+	Labels: map[string]string{
+		"Member":                      "Miembro",
+		"Role":                        "Rol",
+		"Repository":                  "Repositorio",
+		"By":                          "Por",
+		"Title":                       "Título",
+		"Description":                 "Descripción",
+		"Status":                      "Estado",
+		"State":                       "Estado",
+		"Name":                        "Nombre",
+		"Comment":                     "Comentario",
+		"Organization":                "Organización",
+		"Branch":                      "Rama",
+		"Labels":                      "Etiquetas",
+		"Milestone":                   "Hito",
+		"Reviewers":                   "Revisores",
+		"Assigned":                    "Asignado",
+		"Assigned to":                 "Asignado a",
+		"Closed by":                   "Cerrado por",
+		"Triggered by":                "Iniciado por",
+		"Started":                     "Inicio",
+		"Completed":                   "Fin",
+		"Result":                      "Resultado",
+		"Severity":                    "Gravedad",
+		"Environment":                 "Entorno",
+		"Commit":                      "Commit",
+		"Attempt":                     "Intento",
+		"Account":                     "Cuenta",
+		"Action":                      "Acción",
+		"Billing":                     "Facturación",
+		"Blocked":                     "Bloqueado",
+		"CVE":                         "CVE",
+		"Changes":                     "Cambios",
+		"Color":                       "Color",
+		"Default branch":              "Rama principal",
+		"Discussion":                  "Discusión",
+		"Draft Issue":                 "Borrador de issue",
+		"Edited by":                   "Editado por",
+		"Error":                       "Error",
+		"Forks":                       "Forks",
+		"From Status":                 "Estado anterior",
+		"Hook ID":                     "ID del webhook",
+		"ID":                          "ID",
+		"Inputs":                      "Parámetros",
+		"Install ID":                  "ID de instalación",
+		"Issue":                       "Issue",
+		"New Values":                  "Valores nuevos",
+		"Next Bill":                   "Próximo cobro",
+		"Notes":                       "Notas",
+		"Org":                         "Org",
+		"PR":                          "PR",
+		"Package":                     "Paquete",
+		"Page Changes":                "Páginas cambiadas",
+		"Payload":                     "Payload",
+		"Plan":                        "Plan",
+		"Previous Desc":               "Descripción anterior",
+		"Previous Name":               "Nombre anterior",
+		"Project":                     "Proyecto",
+		"Property Name":               "Propiedad",
+		"Pull Request":                "Pull request",
+		"Reference":                   "Referencia",
+		"Reported by":                 "Reportado por",
+		"Repositories Added":          "Repositorios añadidos",
+		"Repositories Removed":        "Repositorios quitados",
+		"Rule Name":                   "Regla",
+		"Ruleset":                     "Conjunto de reglas",
+		"Runner":                      "Runner",
+		"Scope":                       "Alcance",
+		"Secret Type":                 "Tipo de secreto",
+		"Sponsor":                     "Patrocinador",
+		"Stars":                       "Estrellas",
+		"Summary":                     "Resumen",
+		"Tag":                         "Etiqueta",
+		"Target":                      "Destino",
+		"Team":                        "Equipo",
+		"Tier":                        "Nivel",
+		"Unexpected watch action":     "Acción de seguimiento inesperada",
+		"Units":                       "Unidades",
+		"Unknown installation action": "Acción de instalación desconocida",
+		"User":                        "Usuario",
+	},
+	Buttons: map[string]string{
+		"⚠️ Open public issue":       "⚠️ Abrir issue público",
+		"🙈 Dismiss":                  "🙈 Descartar",
+		"📝 Track issue":              "📝 Issue de seguimiento",
+		"↩️ Reopen":                  "↩️ Reabrir",
+		"🔑 Revoked":                  "🔑 Revocado",
+		"🙅 False positive":           "🙅 Falso positivo",
+		"Tolerable risk":             "Riesgo tolerable",
+		"False positive":             "Falso positivo",
+		"No bandwidth":               "Sin tiempo",
+		"Fix started":                "Corrección en curso",
+		"« Back":                     "« Atrás",
+		"✅ Approve deploy":           "✅ Aprobar despliegue",
+		"❌ Reject":                   "❌ Rechazar",
+		"View Repository":            "Ver repositorio",
+		"View Issue":                 "Ver issue",
+		"View PR":                    "Ver PR",
+		"🔀 Merge":                    "🔀 Fusionar",
+		"View Comment":               "Ver comentario",
+		"View Commits":               "Ver commits",
+		"View Commit":                "Ver commit",
+		"View Release":               "Ver release",
+		"View Review":                "Ver revisión",
+		"View Discussion":            "Ver discusión",
+		"View Workflow Run":          "Ver ejecución",
+		"View Run":                   "Ver ejecución",
+		"View Job":                   "Ver job",
+		"🔁 Re-run failed":            "🔁 Reejecutar fallidos",
+		"🔁 Re-run all":               "🔁 Reejecutar todo",
+		"⛔ Cancel":                   "⛔ Cancelar",
+		"View Alert":                 "Ver alerta",
+		"View Deployment":            "Ver despliegue",
+		"View Fork":                  "Ver fork",
+		"View Milestone":             "Ver hito",
+		"View Advisory":              "Ver aviso",
+		"View Branch Settings":       "Ver ajustes de ramas",
+		"View Details":               "Ver detalles",
+		"View Installation":          "Ver instalación",
+		"View Item":                  "Ver elemento",
+		"View Organization":          "Ver organización",
+		"View Organization Settings": "Ver ajustes de la organización",
+		"View Package":               "Ver paquete",
+		"View Project":               "Ver proyecto",
+		"View Repository Settings":   "Ver ajustes del repositorio",
+		"View Ruleset":               "Ver reglas",
+		"View Security Settings":     "Ver ajustes de seguridad",
+		"View Sponsorship":           "Ver patrocinio",
+		"View Team":                  "Ver equipo",
+		"View Thread":                "Ver hilo",
+		"View User":                  "Ver usuario",
+	},
+	Headlines: map[string]string{
+		"*📌 %a issue \\#%d*":                            "*📌 %a: issue \\#%d*",
+		"*🚀 PR %a \\#%d: %s*":                           "*🚀 %a PR \\#%d: %s*",
+		"🔨 *%d new commit to* `%s`":                     "🔨 *%d commit nuevo en* `%s`",
+		"🔨 *%d new commits to* `%s`":                    "🔨 *%d commits nuevos en* `%s`",
+		"🔨 *%d new commit to* `%s` _\\(%d pushes\\)_":   "🔨 *%d commit nuevo en* `%s` _\\(%d envíos\\)_",
+		"🔨 *%d new commits to* `%s` _\\(%d pushes\\)_":  "🔨 *%d commits nuevos en* `%s` _\\(%d envíos\\)_",
+		"⚠️ *Force push to* `%s`":                       "⚠️ *Push forzado en* `%s`",
+		"⚠️ *Force push to* `%s` _\\(%d pushes\\)_":     "⚠️ *Push forzado en* `%s` _\\(%d envíos\\)_",
+		"✨ *New %a created*":                            "✨ *Nueva %a creada*",
+		"%s *Deleted %a:* `%s`":                         "%s *Eliminada %a:* `%s`",
+		"🍴 %s forked by %s":                             "🍴 %s bifurcado por %s",
+		"%s *%s %a comment on commit*":                  "%s *%s: comentario %a en un commit*",
+		"🔓 *Repository made public*":                    "🔓 *Repositorio hecho público*",
+		"%s *%s %a comment on* [%s\\#%d](%s)":           "%s *%s: comentario %a en* [%s\\#%d](%s)",
+		"%s *%s* added to *%s*":                         "%s *%s* añadido a *%s*",
+		"%s *%s* removed from *%s*":                     "%s *%s* quitado de *%s*",
+		"%s *%s* updated in *%s*":                       "%s *%s* actualizado en *%s*",
+		"%s *%s* performed action on *%s*":              "%s *%s* realizó una acción en *%s*",
+		"%s %s created":                                 "%s %s creado",
+		"%s %s archived":                                "%s %s archivado",
+		"%s %s unarchived":                              "%s %s desarchivado",
+		"%s %s renamed to %s":                           "%s %s renombrado a %s",
+		"%s *%a in* %s":                                 "%s *%a en* %s",
+		"⭐ %s starred %s":                               "⭐ %s marcó con estrella %s",
+		"%s %s starred %s":                              "%s %s marcó con estrella %s",
+		"%s %s unstarred %s":                            "%s %s quitó la estrella de %s",
+		"%s *%a for commit* [`%s`](%s)":                 "%s *%a en el commit* [`%s`](%s)",
+		"%s *%s workflow*":                              "%s *Workflow %s*",
+		"⚙️ *No workflow job data*":                     "⚙️ *Sin datos del job*",
+		"⚙️ *Invalid workflow job*":                     "⚙️ *Job no válido*",
+		"%s *Workflow Job %a*":                          "%s *Job: %a*",
+		"🚀 *%s manually triggered*":                     "🚀 *%s iniciado manualmente*",
+		"👥 *Team added*":                                "👥 *Equipo añadido*",
+		"%s *Team %a*":                                  "%s *Equipo: %a*",
+		"🚀 *Repository Dispatch*":                       "🚀 *Evento de repositorio*",
+		"%s *PR Review Comment %a*":                     "%s *Comentario de revisión: %a*",
+		"%s *PR Review %a*":                             "%s *Revisión de PR: %a*",
+		"🏓 *Webhook Ping Received*":                     "🏓 *Ping del webhook recibido*",
+		"💖 *Sponsorship %a*":                            "💖 *Patrocinio: %a*",
+		"👤 *User %a*":                                   "👤 *Usuario: %a*",
+		"📥 *Repository Import %a*":                      "📥 *Importación de repositorio: %a*",
+		"📜 *Repository Ruleset %a*":                     "📜 *Conjunto de reglas: %a*",
+		"🤫 *Secret Scanning Alert %a*":                  "🤫 *Alerta de secreto: %a*",
+		"📍 *Secret Scanning Alert Location %a*":         "📍 *Ubicación de alerta de secreto: %a*",
+		"🔒 *Security & Analysis Settings Updated*":      "🔒 *Ajustes de seguridad y análisis actualizados*",
+		"🧵 *PR Review Thread %a*":                       "🧵 *Hilo de revisión: %a*",
+		"🎯 *PR Target %a*":                              "🎯 *PR target: %a*",
+		"📦 *Registry Package %a*":                       "📦 *Paquete del registro: %a*",
+		"🔄 *Merge Group %a*":                            "🔄 *Grupo de fusión: %a*",
+		"🔑 *Personal Access Token Request %a*":          "🔑 *Solicitud de token de acceso: %a*",
+		"📋 *Project %a*":                                "📋 *Proyecto: %a*",
+		"📄 *Project Item %a*":                           "📄 *Elemento de proyecto: %a*",
+		"🔒 *GitHub App Authorization %a*":               "🔒 *Autorización de la GitHub App: %a*",
+		"📦 *Installation Repositories %a*":              "📦 *Repositorios de la instalación: %a*",
+		"🎯 *Installation Target %a*":                    "🎯 *Destino de la instalación: %a*",
+		"💬 *Discussion Comment %a*":                     "💬 *Comentario de discusión: %a*",
+		"📣 *Discussion %a*":                             "📣 *Discusión: %a*",
+		"🤖 *Dependabot Alert %a*":                       "🤖 *Alerta de Dependabot: %a*",
+		"🛡️ *Deployment Protection Rule %a*":            "🛡️ *Regla de protección de despliegue: %a*",
+		"🔎 *Deployment Review %a*":                      "🔎 *Revisión de despliegue: %a*",
+		"🔗 *Content Reference %a*":                      "🔗 *Referencia de contenido: %a*",
+		"📝 *Custom Property %a*":                        "📝 *Propiedad personalizada: %a*",
+		"🔄 *Custom Property Values Updated*":            "🔄 *Valores de propiedades actualizados*",
+		"🛡️ *Branch Protection Rule %a*":                "🛡️ *Regla de protección de rama: %a*",
+		"🛡️ *Branch Protection Configuration %a*":       "🛡️ *Configuración de protección de ramas: %a*",
+		"🚨 *Vulnerability Alert: %s*":                   "🚨 *Alerta de vulnerabilidad: %s*",
+		"🏗️ *GitHub Pages Build*":                       "🏗️ *Compilación de GitHub Pages*",
+		"📦 *Package Event*":                             "📦 *Evento de paquete*",
+		"🚫 *Organization Block*":                        "🚫 *Bloqueo en la organización*",
+		"🏢 *Organization %a*":                           "🏢 *Organización: %a*",
+		"🏁 *Milestone %a*":                              "🏁 *Hito: %a*",
+		"⚙️ *Meta Event*":                               "⚙️ *Evento meta*",
+		"🚫 *No membership event data*":                  "🚫 *Sin datos de membresía*",
+		"👥 *Membership %a*":                             "👥 *Membresía: %a*",
+		"🚀 *Deployment Event*":                          "🚀 *Evento de despliegue*",
+		"🏷️ *No label event data*":                      "🏷️ *Sin datos de etiqueta*",
+		"🏷️ *Label %a*":                                 "🏷️ *Etiqueta: %a*",
+		"🛒 *No marketplace data*":                       "🛒 *Sin datos de Marketplace*",
+		"🛒 *Marketplace %a*":                            "🛒 *Marketplace: %a*",
+		"📚 *No wiki update data available*":             "📚 *Sin datos de la wiki*",
+		"📚 *Wiki Update*":                               "📚 *Actualización de la wiki*",
+		"🔑 *No deploy key data*":                        "🔑 *Sin datos de la clave de despliegue*",
+		"🔑 *Deploy Key %a*":                             "🔑 *Clave de despliegue: %a*",
+		"✅ *No check suite data*":                       "✅ *Sin datos del check suite*",
+		"✅ *Check Suite: %a*":                           "✅ *Check suite: %a*",
+		"⚙️ *No check run data*":                        "⚙️ *Sin datos del check*",
+		"⚙️ *Check Run: %a*":                            "⚙️ *Check: %a*",
+		"🚦 *No deployment status data*":                 "🚦 *Sin datos del estado del despliegue*",
+		"🚦 *Deployment %a*":                             "🚦 *Despliegue: %a*",
+		"⚠️ *No security advisory data*":                "⚠️ *Sin datos del aviso de seguridad*",
+		"⚠️ *Security Advisory %a*":                     "⚠️ *Aviso de seguridad: %a*",
+		"🎉 *New installation*\\! Welcome aboard\\! 🎉":   "🎉 *Nueva instalación*\\! ¡Bienvenido\\! 🎉",
+		"🗑️ *Installation uninstalled*\\! Goodbye\\! 👋": "🗑️ *Instalación eliminada*\\! ¡Adiós\\! 👋",
+	},
+	Words: map[string]string{
+		"opened":                 "abierto",
+		"closed":                 "cerrado",
+		"reopened":               "reabierto",
+		"edited":                 "editado",
+		"deleted":                "eliminado",
+		"created":                "creado",
+		"added":                  "añadido",
+		"removed":                "quitado",
+		"assigned":               "asignado",
+		"unassigned":             "desasignado",
+		"labeled":                "etiquetado",
+		"unlabeled":              "sin etiqueta",
+		"synchronize":            "actualizado",
+		"ready_for_review":       "listo para revisión",
+		"review_requested":       "revisión solicitada",
+		"review_request_removed": "revisión cancelada",
+		"converted_to_draft":     "pasado a borrador",
+		"submitted":              "enviado",
+		"dismissed":              "descartado",
+		"published":              "publicado",
+		"transferred":            "transferido",
+		"pinned":                 "fijado",
+		"unpinned":               "desfijado",
+		"locked":                 "bloqueado",
+		"unlocked":               "desbloqueado",
+		"milestoned":             "con hito",
+		"demilestoned":           "sin hito",
+		"answered":               "respondido",
+		"unanswered":             "sin respuesta",
+		"resolved":               "resuelto",
+		"unresolved":             "sin resolver",
+		"completed":              "completado",
+		"requested":              "solicitado",
+		"rerequested":            "solicitado de nuevo",
+		"in_progress":            "en curso",
+		"queued":                 "en cola",
+		"waiting":                "en espera",
+		"running":                "en ejecución",
+		"success":                "éxito",
+		"failure":                "fallo",
+		"failed":                 "fallido",
+		"error":                  "error",
+		"pending":                "pendiente",
+		"cancelled":              "cancelado",
+		"neutral":                "neutral",
+		"approved":               "aprobado",
+		"rejected":               "rechazado",
+		"fixed":                  "corregido",
+		"auto_dismissed":         "descartado automáticamente",
+		"auto_reopened":          "reabierto automáticamente",
+		"renamed":                "renombrado",
+		"archived":               "archivado",
+		"unarchived":             "desarchivado",
+		"modified":               "modificado",
+		"started":                "iniciado",
+		"member_added":           "miembro añadido",
+		"member_removed":         "miembro eliminado",
+		"member_invited":         "miembro invitado",
+		"branch":                 "rama",
+		"tag":                    "etiqueta",
+		"checks_requested":       "checks solicitados",
+		"destroyed":              "destruido",
+		"new_release":            "nueva release",
+		"release_published":      "release publicada",
+		"release_deleted":        "release eliminada",
+		"release_edited":         "release editada",
+		"reported":               "reportado",
+		"denied":                 "denegado",
+		"suspend":                "suspendido",
+		"unsuspend":              "reactivado",
+		"promoted_to_repository": "promovido a repositorio",
+	},
+}
@@ -0,0 +1,255 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+// DefaultLanguage is used when neither the chat nor the user has a supported language
+const DefaultLanguage = "en"
+
+type Language struct {
+	Code string
+	Name string
+}
+
+// Languages lists the supported languages in the order shown by the language picker
+var Languages = []Language{
+	{Code: "en", Name: "🇬🇧 English"},
+	{Code: "es", Name: "🇪🇸 Español"},
+	{Code: "ru", Name: "🇷🇺 Русский"},
+}
+
+// catalog holds the translations for one language
+type catalog struct {
+	Messages map[string]string
+	Labels   map[string]string // Notification field labels, keyed by the English label
+	Buttons  map[string]string // Notification button texts, keyed by the English text
+	// Headlines translates the first line of a notification, keyed by the English line as the formatters write it.
+	// %s stands for text kept as is, %d for a number and %a for an action or state looked up in Words.
+	Headlines map[string]string
+	Words     map[string]string // Actions and states shown in headlines, keyed by the lowercase English word with underscores
+}
+
+var catalogs = map[string]catalog{
+	"en": {Messages: en},
+	"es": es,
+	"ru": ru,
+}
+
+// Resolve maps a Telegram language_code such as "es-419" to a supported language
+func Resolve(code string) string {
+	code = strings.ToLower(code)
+	if i := strings.IndexAny(code, "-_"); i != -1 {
+		code = code[:i]
+	}
+	if _, ok := catalogs[code]; ok {
+		return code
+	}
+	return DefaultLanguage
+}
+
+// Pick returns the chat's language if set, otherwise the user's Telegram language
+func Pick(chatLang string, userCode string) string {
+	if chatLang != "" {
+		return Resolve(chatLang)
+	}
+	return Resolve(userCode)
+}
+
+// Name returns the display name of a language
+func Name(lang string) string {
+	for _, l := range Languages {
+		if l.Code == lang {
+			return l.Name
+		}
+	}
+	return lang
+}
+
+// T returns the translated message for key, formatted with args.
+// Missing translations fall back to English.
+func T(lang string, key string, args ...interface{}) string {
+	msg, ok := catalogs[lang].Messages[key]
+	if !ok {
+		if msg, ok = en[key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Localize translates the field labels and buttons of a formatted MarkdownV2 notification.
+// Labels are written as "*Label:*" by the formatters; anything else is left as is.
+func Localize(lang string, msg string, markup *gotgbot.InlineKeyboardMarkup) string {
+	c, ok := catalogs[lang]
+	if !ok || lang == DefaultLanguage {
+		return msg
+	}
+
+	pairs := make([]string, 0, len(c.Labels)*2)
+	for label, translated := range c.Labels {
+		pairs = append(pairs, "*"+label+":*", "*"+translated+":*")
+	}
+	msg = strings.NewReplacer(pairs...).Replace(msg)
+	msg = c.localizeHeadline(lang, msg)
+
+	if markup != nil {
+		for _, row := range markup.InlineKeyboard {
+			for i := range row {
+				if translated, ok := c.Buttons[row[i].Text]; ok {
+					row[i].Text = translated
+				}
+			}
+		}
+	}
+	return msg
+}
+
+// headline is a compiled Headlines entry
+type headline struct {
+	re         *regexp.Regexp
+	verbs      []string // Placeholders of the English line, in order
+	literal    int      // Length of the English line without placeholders
+	translated string
+}
+
+var (
+	headlinesMu sync.Mutex
+	headlines   = map[string][]headline{} // Key: language, longest literal text first
+)
+
+var placeholderRe = regexp.MustCompile(`%[sda]`)
+
+// compiledHeadlines returns the headline patterns of a language, compiling them on first use
+func (c catalog) compiledHeadlines(lang string) []headline {
+	headlinesMu.Lock()
+	defer headlinesMu.Unlock()
+
+	if compiled, ok := headlines[lang]; ok {
+		return compiled
+	}
+
+	var compiled []headline
+	for english, translated := range c.Headlines {
+		literals := placeholderRe.Split(english, -1)
+		verbs := placeholderRe.FindAllString(english, -1)
+		expr := "^" + regexp.QuoteMeta(literals[0])
+		for i, verb := range verbs {
+			if verb == "%d" {
+				expr += `(\d+)`
+			} else {
+				expr += "(.+?)"
+			}
+			expr += regexp.QuoteMeta(literals[i+1])
+		}
+		compiled = append(compiled, headline{
+			re:         regexp.MustCompile(expr + "$"),
+			verbs:      verbs,
+			literal:    len(placeholderRe.ReplaceAllString(english, "")),
+			translated: translated,
+		})
+	}
+	// More literal text means a more specific line, so "Deployment Review %a" wins over "Deployment %a"
+	sort.Slice(compiled, func(i, j int) bool {
+		if compiled[i].literal != compiled[j].literal {
+			return compiled[i].literal > compiled[j].literal
+		}
+		return compiled[i].re.String() < compiled[j].re.String()
+	})
+
+	headlines[lang] = compiled
+	return compiled
+}
+
+// localizeHeadline translates the first line of a notification if it matches a known headline
+func (c catalog) localizeHeadline(lang string, msg string) string {
+	first, rest, _ := strings.Cut(msg, "\n")
+	for _, h := range c.compiledHeadlines(lang) {
+		m := h.re.FindStringSubmatch(first)
+		if m == nil {
+			continue
+		}
+		i := 0
+		line := placeholderRe.ReplaceAllStringFunc(h.translated, func(string) string {
+			i++
+			if h.verbs[i-1] == "%a" {
+				return c.word(m[i])
+			}
+			return m[i]
+		})
+		if len(msg) == len(first) {
+			return line
+		}
+		return line + "\n" + rest
+	}
+	return msg
+}
+
+// word translates an action or state taken from a MarkdownV2 headline, keeping its capitalization
+func (c catalog) word(escaped string) string {
+	plain := strings.ReplaceAll(escaped, "\\", "")
+	translated, ok := c.Words[strings.ReplaceAll(strings.ToLower(plain), " ", "_")]
+	if !ok {
+		return escaped
+	}
+	if r, _ := utf8.DecodeRuneInString(plain); unicode.IsUpper(r) {
+		first, n := utf8.DecodeRuneInString(translated)
+		translated = string(unicode.ToUpper(first)) + translated[n:]
+	}
+	return translated
+}
+
+// Commands returns the bot command list with descriptions in the given language
+func Commands(lang string) []gotgbot.BotCommand {
+	var cmds []gotgbot.BotCommand
+	for _, name := range commandNames {
+		cmds = append(cmds, gotgbot.BotCommand{Command: name, Description: T(lang, "cmd."+name)})
+	}
+	return cmds
+}
+
+// commandNames are the commands shown in Telegram's command menu
+var commandNames = []string{
+	"start",
+	"help",
+	"connect",
+	"addrepo",
+	"addorg",
+	"removerepo",
+	"repos",
+	"settings",
+	"template",
+	"close",
+	"reopen",
+	"approve",
+	"issue",
+	"label",
+	"assign",
+	"milestone",
+	"merge",
+	"review",
+	"dismiss",
+	"rerun",
+	"deploy",
+	"dispatch",
+	"prs",
+	"issues",
+	"delete",
+	"answer",
+	"privacy",
+	"logout",
+	"mentions",
+	"me",
+	"reload",
+}
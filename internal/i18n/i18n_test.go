@@ -0,0 +1,152 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+var verbRe = regexp.MustCompile(`%[0-9]*[a-z]`)
+
+func TestCatalogsComplete(t *testing.T) {
+	for lang, c := range catalogs {
+		if lang == DefaultLanguage {
+			continue
+		}
+		for key, msg := range en {
+			translated, ok := c.Messages[key]
+			if !ok {
+				t.Errorf("%s: missing %q", lang, key)
+				continue
+			}
+			if want, got := verbRe.FindAllString(msg, -1), verbRe.FindAllString(translated, -1); strings.Join(want, " ") != strings.Join(got, " ") {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+		for key := range c.Messages {
+			if _, ok := en[key]; !ok {
+				t.Errorf("%s: unknown key %q", lang, key)
+			}
+		}
+		for label, translated := range c.Labels {
+			// Labels are substituted into MarkdownV2 without escaping
+			if strings.ContainsAny(translated, "_*[]()~`>#+-=|{}.!\\") {
+				t.Errorf("%s: label %q translation %q needs MarkdownV2 escaping", lang, label, translated)
+			}
+		}
+		for word, translated := range c.Words {
+			if strings.ContainsAny(translated, "_*[]()~`>#+-=|{}.!\\") {
+				t.Errorf("%s: word %q translation %q needs MarkdownV2 escaping", lang, word, translated)
+			}
+		}
+		for english, translated := range c.Headlines {
+			if want, got := placeholderRe.FindAllString(english, -1), placeholderRe.FindAllString(translated, -1); strings.Join(want, " ") != strings.Join(got, " ") {
+				t.Errorf("%s: headline %q has placeholders %v, want %v", lang, english, got, want)
+			}
+			if want, got := markdownV2Markup(english), markdownV2Markup(translated); want != got {
+				t.Errorf("%s: headline %q translation %q has MarkdownV2 markup %q, want %q", lang, english, translated, got, want)
+			}
+		}
+		for english := range catalogs["es"].Headlines {
+			if _, ok := c.Headlines[english]; !ok {
+				t.Errorf("%s: missing headline %q", lang, english)
+			}
+		}
+	}
+}
+
+// markdownV2Markup returns the unescaped MarkdownV2 reserved characters of a line, sorted
+func markdownV2Markup(line string) string {
+	var markup []rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case strings.ContainsRune("_*[]()~`>#+-=|{}.!", r):
+			markup = append(markup, r)
+		}
+	}
+	slices.Sort(markup)
+	return string(markup)
+}
+
+// TestHelpHTML keeps placeholders such as <text> escaped, Telegram rejects the help message otherwise
+func TestHelpHTML(t *testing.T) {
+	tagRe := regexp.MustCompile(`</?([^<> ]*)[^<>]*>`)
+	for _, l := range Languages {
+		for _, m := range tagRe.FindAllStringSubmatch(T(l.Code, "help"), -1) {
+			if m[1] != "b" && m[1] != "i" && m[1] != "code" && m[1] != "a" {
+				t.Errorf("%s: help has unsupported tag %s", l.Code, m[0])
+			}
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := map[string]string{
+		"":       "en",
+		"es":     "es",
+		"es-419": "es",
+		"RU":     "ru",
+		"pt-br":  "en",
+	}
+	for code, want := range tests {
+		if got := Resolve(code); got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", code, got, want)
+		}
+	}
+
+	if got := Pick("ru", "es"); got != "ru" {
+		t.Errorf("Pick() should prefer the chat language, got %q", got)
+	}
+	if got := Pick("", "es"); got != "es" {
+		t.Errorf("Pick() should fall back to the user language, got %q", got)
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T("es", "approve.done", 7); got != "✅ PR #7 aprobado." {
+		t.Errorf("T() = %q", got)
+	}
+	if got := T("xx", "approve.done", 7); got != "✅ PR #7 approved." {
+		t.Errorf("T() should fall back to English, got %q", got)
+	}
+}
+
+func TestLocalizeHeadline(t *testing.T) {
+	tests := []struct {
+		lang, msg, want string
+	}{
+		{"es", "*📌 Opened issue \\#12*\n*Title:* x", "*📌 Abierto: issue \\#12*\n*Título:* x"},
+		{"ru", "👥 *Membership member\\_added*", "👥 *Членство: участник добавлен*"},
+		{"es", "🔎 *Deployment Review approved*\n", "🔎 *Revisión de despliegue: aprobado*\n"},
+		{"es", "🚦 *Deployment success*", "🚦 *Despliegue: éxito*"},
+		{"es", "🚦 *Deployment frobnicated*", "🚦 *Despliegue: frobnicated*"},
+		{"es", "🔨 *2 new commits to* `app:main` _\\(3 pushes\\)_\n\nbody", "🔨 *2 commits nuevos en* `app:main` _\\(3 envíos\\)_\n\nbody"},
+		{"es", "Not a headline\n*Title:* x", "Not a headline\n*Título:* x"},
+		{"en", "*📌 Opened issue \\#12*", "*📌 Opened issue \\#12*"},
+	}
+	for _, tt := range tests {
+		if got := Localize(tt.lang, tt.msg, nil); got != tt.want {
+			t.Errorf("Localize(%s, %q) = %q, want %q", tt.lang, tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	markup := &gotgbot.InlineKeyboardMarkup{InlineKeyboard: [][]gotgbot.InlineKeyboardButton{{{Text: "View Issue", Url: "https://github.com"}}}}
+	msg := Localize("es", "*Repository:* x\n*By:* y\nRepository: z", markup)
+
+	if msg != "*Repositorio:* x\n*Por:* y\nRepository: z" {
+		t.Errorf("Localize() = %q", msg)
+	}
+	if markup.InlineKeyboard[0][0].Text != "Ver issue" {
+		t.Errorf("Localize() button = %q", markup.InlineKeyboard[0][0].Text)
+	}
+}
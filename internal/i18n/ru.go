@@ -0,0 +1,793 @@
+package i18n
+
+var ru = catalog{
+	Messages: map[string]string{
+		"start": `<b>Добро пожаловать в GitHub-бота!</b> 🤖
+
+Я помогу управлять репозиториями и уведомлениями GitHub прямо из Telegram.
+
+<b>С чего начать:</b>
+1. Отправьте /connect, чтобы привязать аккаунт GitHub.
+2. Отправьте /addrepo, чтобы подключить репозиторий и получать уведомления.
+3. Отправьте /settings, чтобы настроить уведомления.
+
+Нужна помощь? Отправьте /help, чтобы увидеть все команды.`,
+
+		"help": `<b>Команды GitHub-бота:</b>
+
+<b>Аккаунт</b>
+/connect - Привязать аккаунт GitHub (<i>только в личном чате</i>)
+/mentions [on|off] - Получать упоминания, когда вас назначают, просят о ревью или упоминают
+/me - Личные уведомления и настройки упоминаний (<i>личный чат</i>)
+
+<b>Репозитории</b>
+/addrepo [owner/repo|название] - Подключить репозиторий или найти свои по названию и подключить несколько сразу
+/addorg &lt;org&gt; [шаблон...] [-шаблон...] - Подключить все репозитории организации, включая будущие
+/removerepo [owner/repo] - Отключить репозиторий; без аргумента — выбрать из списка
+/repos - Список подключённых репозиториев с темой, событиями, состоянием вебхука и тем, кто их подключил
+/close - Закрыть issue или PR (ответом на уведомление).
+/reopen - Переоткрыть issue или PR (ответом на уведомление).
+/approve - Одобрить PR (ответом на уведомление).
+/issue [owner/repo] Заголовок - Создать issue; описание, <code>labels:</code>, <code>assignees:</code> и <code>milestone:</code> на следующих строках. Ответьте на сообщение, чтобы процитировать его.
+/label [метки] - Добавить метки через запятую (ответом на уведомление).
+/unlabel [метки] - Убрать метки (ответом на уведомление).
+/assign [@user|me] - Назначить пользователей (ответом на уведомление).
+/unassign [@user|me] - Снять назначение (ответом на уведомление).
+/milestone [название|none] - Установить или убрать milestone (ответом на уведомление). Без аргумента каждая команда показывает список для выбора.
+/merge [merge|squash|rebase] - Слить PR после проверки конфликтов, ревью и обязательных проверок (ответом на уведомление).
+/review approve|changes|comment &lt;текст&gt; - Отправить ревью PR (ответом на уведомление).
+/dismiss &lt;причина&gt; - Отклонить своё последнее ревью PR (ответом на уведомление).
+/rerun [failed|all] - Перезапустить упавший workflow (ответом на уведомление).
+/deploy approve|reject [комментарий] - Одобрить или отклонить деплой, ожидающий проверки (ответом на уведомление).
+/dispatch [owner/repo] [workflow] - Запустить workflow с workflow_dispatch и входными параметрами и следить за запуском.
+/prs [owner/repo] [фильтры] - Открытые PR. Фильтры: author:, label:, review:required, is:draft.
+/issues [owner/repo] [фильтры] - Открытые issues. Фильтры: author:, label:, assignee:.
+/delete - Удалить комментарий на GitHub, опубликованный из сообщения, на которое вы отвечаете.
+/answer - Отметить комментарий в обсуждении как ответ (ответом на уведомление).
+
+<b>Настройки</b>
+/settings - Настроить уведомления и язык
+/template - Изменить шаблоны уведомлений
+/reload - Обновить кэш администраторов
+
+
+<b>Нужна помощь?</b>
+Подробности на <a href="https://github.com/AshokShau/GithubBot">странице GitHub</a>.`,
+
+		"privacy": `<b>Политика конфиденциальности</b>
+
+Мы ценим вашу конфиденциальность и заботимся о защите ваших данных. Здесь описано, как мы собираем, используем и защищаем информацию.
+
+<b>1. Сбор данных</b>
+• <b>Данные Telegram:</b> Мы храним ваш ID пользователя, ID чата и основные данные профиля, чтобы доставлять уведомления и проверять права.
+• <b>Данные GitHub:</b> При подключении аккаунта мы храним ваш OAuth-токен в зашифрованном виде, а также названия подключённых репозиториев и ID созданных вебхуков.
+• <b>События:</b> Мы обрабатываем входящие события вебхуков GitHub (например, push и issues), чтобы отправлять уведомления. Их содержимое обрабатывается в реальном времени и не хранится постоянно.
+
+<b>2. Использование данных</b>
+• <b>Функциональность:</b> Данные используются только для работы бота: отправки уведомлений, управления репозиториями и проверки прав.
+• <b>Безопасность:</b> OAuth-токены шифруются AES-GCM перед сохранением в базе данных.
+
+<b>3. Передача данных</b>
+• Мы <b>не</b> передаём, не продаём и не сдаём ваши данные третьим лицам.
+• Данные передаются только API GitHub в объёме, необходимом для выполнения запрошенных действий (например, создания вебхуков).
+
+<b>4. Управление данными</b>
+• <b>Отключение:</b> Вы можете отвязать аккаунт GitHub в любой момент, после чего сохранённый токен станет недействительным.
+• <b>Удаление:</b> Репозитории удаляются командой /removerepo. Для полного удаления данных свяжитесь с разработчиком или просто заблокируйте бота.
+
+<b>5. Контакты</b>
+Вопросы можно задать в нашем <a href="https://github.com/AshokShau/GithubBot">репозитории на GitHub</a> или в <a href="https://t.me/GuardxSupport">группе поддержки в Telegram</a>.`,
+
+		"cmd.start":      "Запустить бота",
+		"cmd.help":       "Показать список команд",
+		"cmd.connect":    "Привязать аккаунт GitHub",
+		"cmd.addrepo":    "Подключить репозиторий",
+		"cmd.addorg":     "Подключить все репозитории организации",
+		"cmd.removerepo": "Отключить репозиторий",
+		"cmd.repos":      "Список подключённых репозиториев",
+		"cmd.settings":   "Настроить уведомления и язык",
+		"cmd.template":   "Изменить шаблоны уведомлений",
+		"cmd.close":      "Закрыть issue или PR (ответом на уведомление)",
+		"cmd.reopen":     "Переоткрыть issue или PR (ответом на уведомление)",
+		"cmd.approve":    "Одобрить PR (ответом на уведомление)",
+		"cmd.issue":      "Создать issue (ответом — с цитатой)",
+		"cmd.label":      "Добавить метки (ответом на уведомление)",
+		"cmd.assign":     "Назначить пользователей (ответом на уведомление)",
+		"cmd.milestone":  "Установить milestone (ответом на уведомление)",
+		"cmd.merge":      "Слить PR (ответом на уведомление)",
+		"cmd.review":     "Ревью PR (ответом на уведомление)",
+		"cmd.dismiss":    "Отклонить своё ревью (ответом на уведомление)",
+		"cmd.rerun":      "Перезапустить упавший workflow (ответом на уведомление)",
+		"cmd.deploy":     "Одобрить или отклонить ожидающий деплой (ответом на уведомление)",
+		"cmd.dispatch":   "Запустить workflow с параметрами",
+		"cmd.prs":        "Открытые pull requests",
+		"cmd.issues":     "Открытые issues",
+		"cmd.delete":     "Удалить свой опубликованный комментарий (ответом)",
+		"cmd.answer":     "Отметить комментарий обсуждения как ответ",
+		"cmd.privacy":    "Политика конфиденциальности",
+		"cmd.logout":     "Отвязать аккаунт GitHub",
+		"cmd.mentions":   "Упоминания в Telegram",
+		"cmd.me":         "Настройки личных уведомлений",
+		"cmd.reload":     "Обновить кэш администраторов",
+
+		"connect.private_only": "⚠️ Команду /connect можно использовать только в личном чате с ботом.",
+		"connect.prompt":       "[Привяжите аккаунт GitHub](%s), чтобы бот мог сам настраивать вебхуки и выполнять действия, например одобрять PR.",
+		"oauth.connected":      "✅ Аккаунт GitHub <b>%s</b> подключён!",
+		"oauth.page_title":     "Подключено",
+		"oauth.page_heading":   "Вход выполнен",
+		"oauth.page_text":      "Ваш аккаунт GitHub подключён.",
+		"oauth.page_return":    "Если окно не закрылось само, <a href=\"%s\">вернитесь в Telegram</a>.",
+		"connect.first":        "Сначала [привяжите аккаунт GitHub](%s).",
+		"connect.first_repo":   "Сначала [привяжите аккаунт GitHub](%s), чтобы подключить репозиторий %s.",
+		"logout.error":         "Не удалось выйти.",
+		"logout.done":          "✅ Вы вышли из аккаунта. Отправьте /connect, чтобы подключиться снова.",
+		"mentions.status_on":   "🔔 Упоминания <b>включены</b> для <b>%s</b>. Уведомления упоминают вас, когда вас назначают, просят о ревью или @упоминают.\nОтправьте <code>/mentions off</code>, чтобы отключить их.",
+		"mentions.status_off":  "🔕 Упоминания <b>отключены</b> для <b>%s</b>. Вместо них уведомления ссылаются на ваш профиль GitHub.\nОтправьте <code>/mentions on</code>, чтобы включить их.",
+		"mentions.usage":       "Использование: /mentions on|off",
+		"mentions.enabled":     "🔔 Теперь вас будут упоминать в уведомлениях.",
+		"mentions.disabled":    "🔕 Вас больше не будут упоминать в уведомлениях.",
+		"me.private_only":      "⚠️ Команду /me можно использовать только в личном чате с ботом.",
+		"me.menu":              "<b>Личные уведомления</b> для <b>%s</b>\n\nЯ пишу вам сюда, когда вас просят о ревью PR, назначают или @упоминают в репозитории, привязанном к любому чату. Нажмите, чтобы переключить.",
+		"me.review":            "👀 Запросы ревью: %s",
+		"me.assign":            "📌 Назначения: %s",
+		"me.mention":           "💬 Упоминания: %s",
+		"me.group_mentions":    "🔔 Упоминать меня в групповых уведомлениях: %s",
+		"me.muted":             "🔕 Отключено. Отправьте /me, чтобы включить снова.",
+		"personal.review":      "*👀 Вас просят о ревью*",
+		"personal.assign":      "*📌 Вас назначили*",
+		"personal.mention":     "*💬 Вас упомянули*",
+		"personal.view":        "Открыть на GitHub",
+		"personal.approve":     "✅ Одобрить",
+		"personal.off":         "🔕 Отключить такие уведомления",
+
+		"admin.add_repo":        "Только администраторы могут подключать репозитории.",
+		"admin.remove_repo":     "Только администраторы могут отключать репозитории.",
+		"admin.settings":        "Только администраторы могут менять настройки.",
+		"admin.change_settings": "Только администраторы могут менять настройки",
+		"admin.template":        "Только администраторы могут менять шаблоны.",
+		"template.usage": `<b>Шаблоны уведомлений</b>
+
+Настройте вид уведомлений в этом чате с помощью синтаксиса Go <a href="https://pkg.go.dev/text/template">text/template</a>. Результат отправляется как MarkdownV2 Telegram.
+
+<b>Использование</b>
+/template - Список своих шаблонов
+/template show &lt;событие[:действие]&gt; - Показать шаблон
+/template set &lt;событие[:действие]&gt; - Задать шаблон (шаблон на следующих строках)
+/template preview &lt;событие[:действие]&gt; - Предпросмотр на примере (можно передать новый шаблон на следующих строках)
+/template reset &lt;событие[:действие]&gt;|all - Вернуть стандартное сообщение
+
+<b>Поля</b>
+<code>.Event .Action .Repo .RepoURL .Sender .Number .Title .Body .URL .State .Branch .Commits .Payload</code>
+У каждого коммита есть <code>.SHA .Message .Author .URL</code>.
+
+<b>Функции</b>
+<code>md</code> экранирует текст, <code>mdurl</code> экранирует URL, <code>user</code> ссылка на пользователя, <code>repo</code> ссылка на репозиторий, <code>link текст url</code>, <code>body</code> преобразует Markdown, <code>quote</code> цитирует длинный текст, <code>short</code> сокращает SHA, <code>truncate n</code>, <code>title</code>.
+Всегда экранируйте поля через <code>md</code>, иначе Telegram отклонит сообщение.
+
+<b>Пример</b>
+<code>/template set issues:opened
+🐞 {{user .Sender}} открыл {{link (printf "#%d %s" .Number .Title) .URL}} в {{repo .Repo}}</code>`,
+		"template.none":         "Нет своего шаблона для <code>%s</code>.",
+		"template.show":         "<b>Шаблон для</b> <code>%s</code>:\n<pre>%s</pre>",
+		"template.body_missing": "Напишите шаблон на строках после команды.",
+		"template.invalid":      "❌ Некорректный шаблон: %s",
+		"template.save_failed":  "Не удалось сохранить шаблон.",
+		"template.saved":        "✅ Шаблон для <code>%s</code> сохранён. Проверьте его через <code>/template preview %s</code>.",
+		"template.rejected":     "❌ Telegram отклонил сообщение: %s\n\nУбедитесь, что поля экранированы через <code>md</code>.",
+		"template.reset_done":   "✅ Стандартный формат уведомлений восстановлен.",
+		"template.custom":       "<b>Свои шаблоны в этом чате:</b>",
+		"template.samples":      "<b>Примеры событий:</b> %s",
+		"admin.reload":          "Только администраторы могут обновлять кэш.",
+
+		"err.auth":           "Ошибка авторизации.",
+		"err.auth_reconnect": "Ошибка авторизации. Подключитесь заново через /connect",
+		"err.auth_again":     "Ошибка авторизации. Отправьте /connect ещё раз.",
+		"err.client":         "Не удалось создать клиент GitHub.",
+		"err.webhook_token":  "Не удалось создать токен вебхука.",
+		"err.link_repo":      "Не удалось подключить репозиторий.",
+		"err.api":            "Ошибка API GitHub.",
+		"err.failed":         "Ошибка: %v",
+		"err.token_revoked":  "Ошибка авторизации GitHub. Токен отозван или истёк.",
+		"err.auth_failed":    "⚠️ <b>Ошибка авторизации GitHub.</b>\nПохоже, ваш токен истёк или был отозван. Отправьте /connect ещё раз.",
+		"err.forbidden":      "⛔ У вашего аккаунта GitHub нет прав на это: %s",
+		"err.connect_first":  "Сначала подключите GitHub через /connect.",
+		"err.connect_via":    "Сначала привяжите аккаунт GitHub через /connect",
+		"err.load_settings":  "Не удалось загрузить настройки.",
+		"err.save_settings":  "Не удалось сохранить настройки.",
+
+		"repo.invalid_format":        "Неверный формат репозитория. Используйте owner/repo",
+		"repo.not_found":             "❌ <b>Репозиторий не найден.</b>\nПроверьте название и наличие доступа.",
+		"repo.fetch_error":           "Не удалось получить репозиторий: %v",
+		"repo.no_permission":         "❌ <b>Недостаточно прав.</b>\nДля создания вебхуков нужны права администратора в репозитории <b>%s</b>.",
+		"repo.webhook_failed":        "⚠️ <b>Не удалось создать вебхук.</b>\nУбедитесь, что у вас есть права администратора, и попробуйте снова.",
+		"repo.webhook_failed_v":      "Не удалось создать вебхук: %v. Проверьте права",
+		"repo.linked":                "Репозиторий <b>%s</b> подключён!",
+		"org.usage":                  "Использование: /addorg <org> [шаблон...] [-шаблон...]\nШаблоны вроде api-* ограничивают репозитории, шаблоны с - в начале исключают их.",
+		"org.bad_pattern":            "Неверный шаблон репозитория. Используйте шаблоны вроде api-* или -legacy-*.",
+		"org.not_found":              "Организация <b>%s</b> не найдена.",
+		"org.scope_missing":          "У вашего подключения GitHub нет права <code>%s</code>, нужного для вебхуков организации. Выполните /connect ещё раз в личном чате, чтобы выдать его.",
+		"org.already_linked":         "<b>%s</b> уже подключена здесь. Сначала удалите её: <code>/removerepo %s</code>.",
+		"org.no_permission":          "Вы не можете создавать вебхуки в <b>%s</b>. Это могут только владельцы организации.",
+		"org.linked":                 "✅ Подключены все репозитории <b>%s</b>, включая созданные позже.",
+		"org.include":                "Только: %s",
+		"org.exclude":                "Кроме: %s",
+		"repo.linked_check":          "✅ Репозиторий <b>%s</b> подключён!",
+		"repo.connect_to_list":       "Сначала привяжите аккаунт GitHub через /connect, чтобы увидеть репозитории.",
+		"repo.fetch_failed":          "Не удалось получить репозитории из GitHub.",
+		"repo.none_found":            "Репозитории не найдены.",
+		"repo.select_add":            "Выберите репозиторий (страница %d):",
+		"repo.search_hint":           "Совет: найдите репозитории, которыми вы управляете, с помощью /addrepo <название>.",
+		"repo.search_header":         "🔎 Ваши репозитории с правами администратора по запросу <code>%s</code>: %d (страница %d/%d)\nНажмите, чтобы подключить к этому чату. ✅ отмечены уже подключённые, 🏢 — охваченные подключённой организацией.",
+		"repo.search_multi_hint":     "Отметьте репозитории и подключите их все сразу.",
+		"repo.search_truncated":      "⚠️ Поиск охватил только %d недавно обновлённых репозиториев; уточните название, если нужного нет в списке.",
+		"repo.search_empty":          "Нет репозиториев с правами администратора по запросу <code>%s</code>.",
+		"repo.search_select":         "☑️ Выбрать несколько",
+		"repo.search_link_selected":  "🔗 Подключить выбранные (%d)",
+		"repo.search_single":         "✖️ Отменить выбор",
+		"repo.search_none_selected":  "Сначала выберите хотя бы один репозиторий.",
+		"repo.search_already_linked": "%s уже подключён к этому чату.",
+		"repo.search_linked":         "✅ <b>%s</b> подключён",
+		"repo.search_failed":         "❌ <b>%s</b>: %s",
+		"repo.search_no_admin":       "для создания вебхуков нужны права администратора",
+		"repo.access_denied":         "Репозиторий не найден или нет доступа.",
+		"repo.link_not_found":        "Репозиторий не найден",
+		"repos.none":                 "Нет подключённых репозиториев.",
+		"repos.title":                "<b>Подключённые репозитории:</b>\n",
+		"repos.digest":               "⚙️ <i>Действует для всех подключений этого чата:</i>",
+		"repos.topic":                "📍 Тема #%d",
+		"repos.main_chat":            "📍 Основной чат",
+		"repos.events":               "📡 %d/%d событий",
+		"repos.hook_inactive":        "⏸ вебхук отключён",
+		"repos.delivery_none":        "🆕 доставок пока не было",
+		"repos.delivery_ok":          "✅ последняя доставка %d",
+		"repos.delivery_failed":      "⚠️ последняя доставка не удалась: %s",
+		"repos.hook_missing":         "❌ Вебхук не найден на GitHub",
+		"repos.hook_unknown":         "❔ Статус вебхука недоступен: %s",
+		"repos.hook_connect":         "❔ Выполните /connect, чтобы увидеть статус вебхука",
+		"repos.linked_by":            "👤 Подключил(а) %s, %s",
+		"nav.prev":                   "< Назад",
+		"nav.next":                   "Далее >",
+
+		"remove.not_found":                "Репозиторий не найден среди подключённых.",
+		"remove.db_error":                 "Не удалось удалить репозиторий из базы данных.",
+		"remove.done":                     "Репозиторий <b>%s</b> отключён.%s",
+		"remove.warn_connect":             "\n\n⚠️ <b>Внимание:</b> Вы не подключены к GitHub, поэтому вебхук не удалён из настроек репозитория. Удалите его вручную.",
+		"remove.warn_decrypt":             "\n\n⚠️ <b>Внимание:</b> Не удалось расшифровать токен доступа. Вебхук не удалён из GitHub.",
+		"remove.warn_client":              "\n\n⚠️ <b>Внимание:</b> Не удалось создать клиент GitHub. Вебхук не удалён.",
+		"remove.warn_auth":                "\n\n⚠️ <b>Внимание:</b> Ошибка авторизации GitHub. Вебхук не удалён.",
+		"remove.warn_failed":              "\n\n⚠️ <b>Внимание:</b> Не удалось удалить вебхук из GitHub: %v",
+		"remove.pick":                     "Выберите репозиторий, который нужно отключить от этого чата:",
+		"remove.confirm":                  "Отключить <b>%s</b> от этого чата? Его вебхук также будет удалён из GitHub.",
+		"remove.confirm_yes":              "🗑 Отключить",
+		"remove.back":                     "« Назад",
+		"remove.cancel":                   "✖️ Отмена",
+		"remove.cancelled":                "Ничего не отключено.",
+		"reload.wait":                     "Подождите %d мин. перед следующим обновлением.",
+		"reload.perm_failed":              "Не удалось проверить права.",
+		"reload.done":                     "Кэш администраторов обновлён.",
+		"reply.need_reply":                "Используйте эту команду в ответ на уведомление.",
+		"reply.no_context":                "Контекст не найден. Возможно, сообщение слишком старое.",
+		"reply.pr_only":                   "Эта команда только для pull request.",
+		"reply.issue_only":                "Эта команда только для issues и pull request.",
+		"approve.failed":                  "Не удалось одобрить: %v",
+		"approve.done":                    "✅ PR #%d одобрен.",
+		"issue.usage":                     "<b>Использование:</b>\n<code>/issue [owner/repo] Заголовок\nОписание на следующих строках\nlabels: bug, ui\nassignees: me, octocat\nmilestone: v1.0</code>\n\nОтветьте на сообщение, чтобы процитировать его в issue.",
+		"issue.no_repo":                   "К этому чату не привязан ни один репозиторий. Используйте <code>/issue owner/repo Заголовок</code> или сначала /addrepo.",
+		"issue.pick_repo":                 "Где создать <b>%s</b>?",
+		"issue.expired":                   "Черновик issue устарел. Отправьте /issue ещё раз.",
+		"issue.not_yours":                 "Выбрать репозиторий может только тот, кто отправил /issue.",
+		"issue.milestone_not_found":       "❌ Milestone <b>%s</b> не найден в <b>%s</b>.",
+		"issue.failed":                    "❌ Не удалось создать issue: %s",
+		"issue.created":                   "✅ Создан issue <a href=\"%s\">#%d</a> в <b>%s</b>: %s\n\nОтветьте на это сообщение, чтобы оставить комментарий.",
+		"triage.labeled":                  "✅ <b>%s</b> добавлено к #%d.",
+		"triage.unlabeled":                "✅ <b>%s</b> убрано из #%d.",
+		"triage.assigned":                 "✅ <b>%s</b> назначен(ы) на #%d.",
+		"triage.unassigned":               "✅ <b>%s</b> снят(ы) с #%d.",
+		"triage.milestoned":               "✅ Milestone для #%d: <b>%s</b>.",
+		"triage.milestone_cleared":        "✅ Milestone для #%d убран.",
+		"triage.pick_label":               "Выберите метку для #%d:",
+		"triage.pick_unlabel":             "Выберите метку, которую убрать из #%d:",
+		"triage.pick_assign":              "Кого назначить на #%d?",
+		"triage.pick_unassign":            "С кого снять #%d?",
+		"triage.pick_milestone":           "Выберите milestone для #%d:",
+		"triage.nothing":                  "Выбирать не из чего.",
+		"picker.expired":                  "Это меню устарело. Отправьте команду ещё раз.",
+		"picker.not_yours":                "Этим меню может пользоваться только тот, кто отправил команду.",
+		"merge.usage":                     "Использование: /merge [merge|squash|rebase] (ответом на уведомление о PR)",
+		"merge.title":                     "<b>Слияние PR #%d</b>: %s",
+		"merge.method":                    "<b>Способ:</b> %s",
+		"merge.method.merge":              "Merge-коммит",
+		"merge.method.squash":             "Squash",
+		"merge.method.rebase":             "Rebase",
+		"merge.commit_title":              "<b>Заголовок коммита:</b> <code>%s</code>",
+		"merge.reviews":                   "<b>Ревью:</b> %s",
+		"merge.review.approved":           "✅ одобрено",
+		"merge.review.changes_requested":  "❌ запрошены изменения",
+		"merge.review.review_required":    "⏳ требуется ревью",
+		"merge.review.none":               "не требуется",
+		"merge.checks":                    "<b>Проверки:</b> %d успешно, %d выполняется, %d с ошибкой",
+		"merge.blocked":                   "⚠️ <b>Слить пока нельзя:</b>",
+		"merge.blocker.closed":            "PR закрыт.",
+		"merge.blocker.merged":            "PR уже слит.",
+		"merge.blocker.draft":             "PR — черновик.",
+		"merge.blocker.conflicts":         "Есть конфликты с базовой веткой.",
+		"merge.blocker.changes_requested": "Ревьюер запросил изменения.",
+		"merge.blocker.review_required":   "Нужно одобряющее ревью.",
+		"merge.blocker.checks_failed":     "Обязательные проверки не прошли: %s",
+		"merge.blocker.checks_pending":    "Обязательные проверки ещё выполняются: %s",
+		"merge.blocker.behind":            "Ветка отстаёт от базовой.",
+		"merge.blocker.blocked":           "Заблокировано защитой ветки или правилами.",
+		"merge.blocker.unknown":           "GitHub ещё проверяет возможность слияния. Попробуйте чуть позже.",
+		"merge.auto_already":              "⏳ Автослияние уже включено.",
+		"merge.do":                        "🔀 Слить",
+		"merge.auto":                      "⏳ Включить автослияние",
+		"merge.cancel":                    "✖️ Отмена",
+		"merge.cancelled":                 "Слияние отменено.",
+		"merge.done":                      "✅ PR #%d слит (%s) как <code>%s</code>.",
+		"merge.auto_done":                 "⏳ Автослияние включено для PR #%d. GitHub сольёт его, когда все требования будут выполнены.",
+		"merge.update":                    "🔄 Обновить ветку",
+		"merge.update_done":               "🔄 PR #%d обновляется из базовой ветки. Проверки запустятся заново на новом коммите; используйте /merge, когда они пройдут.",
+		"merge.failed":                    "❌ Не удалось слить: %s",
+		"review.usage":                    "Использование: <code>/review approve|changes|comment &lt;текст&gt;</code> ответом на уведомление о PR. Для changes и comment текст обязателен.",
+		"review.failed":                   "❌ Не удалось отправить ревью: %s",
+		"review.done.approve":             "✅ <a href=\"%s\">Одобрен</a> PR #%d.",
+		"review.done.changes":             "❌ <a href=\"%s\">Запрошены изменения</a> в PR #%d.",
+		"review.done.comment":             "💬 <a href=\"%s\">Ревью</a> PR #%d отправлено.",
+		"dismiss.usage":                   "Использование: /dismiss <причина> (ответом на уведомление о PR)",
+		"dismiss.none":                    "В PR #%d нет вашего одобрения или запроса изменений, которые можно отклонить.",
+		"dismiss.failed":                  "❌ Не удалось отклонить ревью: %s",
+		"dismiss.done":                    "🚫 <a href=\"%s\">Ревью</a> PR #%d отклонено.",
+		"rerun.usage":                     "Использование: /rerun [failed|all] (ответом на уведомление об упавшем workflow)",
+		"rerun.not_run":                   "Эта команда работает только ответом на уведомление об упавшем workflow.",
+		"rerun.failed":                    "❌ Действие не выполнено: %s",
+		"rerun.done.f":                    "🔁 Упавшие задачи перезапущены.",
+		"rerun.done.a":                    "🔁 Все задачи перезапущены.",
+		"rerun.done.c":                    "⛔ Запуск отменён.",
+		"rerun.marked.f":                  "🔁 Упавшие задачи перезапустил(а) %s (попытка %d)",
+		"rerun.marked.a":                  "🔁 Все задачи перезапустил(а) %s (попытка %d)",
+		"rerun.marked.c":                  "⛔ Отменил(а) %s",
+		"deploy.usage":                    "Использование: /deploy approve|reject [комментарий] (ответом на уведомление о проверке деплоя)",
+		"deploy.not_pending":              "Эта команда работает только ответом на уведомление о проверке деплоя.",
+		"deploy.none_pending":             "Нет деплоев этого запуска, ожидающих проверки.",
+		"deploy.not_reviewer":             "Вы не обязательный ревьюер окружений, которых ждёт этот запуск.",
+		"deploy.failed":                   "❌ Не удалось проверить деплой: %s",
+		"deploy.done.approved":            "✅ Деплой одобрен.",
+		"deploy.done.rejected":            "❌ Деплой отклонён.",
+		"deploy.marked.approved":          "✅ Деплой в %s одобрил(а) %s",
+		"deploy.marked.rejected":          "❌ Деплой в %s отклонил(а) %s",
+		"alert.failed":                    "❌ Не удалось обновить оповещение: %s",
+		"alert.dismissed.tr":              "🙈 Отклонил(а) как допустимый риск: %s",
+		"alert.dismissed.fp":              "🙈 Отклонил(а) как ложное срабатывание: %s",
+		"alert.dismissed.nb":              "🙈 Отклонил(а) из-за нехватки времени: %s",
+		"alert.dismissed.fs":              "🙈 Отклонил(а) %s, исправление уже начато",
+		"alert.resolved.rv":               "🔑 Закрыл(а) как отозванный секрет: %s",
+		"alert.resolved.fp":               "🙅 Закрыл(а) как ложное срабатывание: %s",
+		"alert.reopened":                  "↩️ Переоткрыл(а) %s",
+		"alert.tracked":                   "📝 Задачу для отслеживания открыл(а) %s: %s",
+		"alert.tracked_assigned":          "📝 Задачу для отслеживания открыл %s: %s. Оповещение назначено на %s.",
+		"alert.public_warning":            "⚠️ Это публичный репозиторий. Задача раскроет оповещение всем ещё до исправления. Подтверждайте, только если это допустимо.",
+		"dispatch.no_repo":                "К этому чату не привязан репозиторий. Используйте <code>/dispatch owner/repo [workflow]</code>.",
+		"dispatch.pick_repo":              "Выберите репозиторий для запуска workflow:",
+		"dispatch.no_workflows":           "В %s нет workflow с триггером <code>workflow_dispatch</code>.",
+		"dispatch.workflow_not_found":     "Нет workflow с <code>workflow_dispatch</code>, подходящего под <b>%s</b>, в %s.",
+		"dispatch.pick_workflow":          "Выберите workflow для запуска в <b>%s</b>:",
+		"dispatch.input":                  "🚀 <b>%s</b>: параметр %d из %d",
+		"dispatch.input_required":         "(обязательный)",
+		"dispatch.reply":                  "Ответьте значением.",
+		"dispatch.reply_default":          "Ответьте значением или <code>-</code>, чтобы использовать значение по умолчанию <code>%s</code>.",
+		"dispatch.reply_optional":         "Ответьте значением или <code>-</code>, чтобы оставить пустым.",
+		"dispatch.required":               "Это обязательный параметр. Ответьте значением.",
+		"dispatch.not_number":             "<code>%s</code> — не число. Ответьте числом.",
+		"dispatch.pick_ref":               "🚀 <b>%s</b>: выберите ветку для запуска:",
+		"dispatch.confirm":                "🚀 Запустить <b>%s</b> в %s на <code>%s</code>?",
+		"dispatch.run":                    "🚀 Запустить",
+		"dispatch.cancel":                 "✖️ Отмена",
+		"dispatch.cancelled":              "Запуск отменён.",
+		"dispatch.failed":                 "❌ Не удалось запустить workflow: %s",
+		"dispatch.dispatched":             "🚀 <b>%s</b> запущен на <code>%s</code>. Ожидание запуска…",
+		"dispatch.status":                 "🚀 <b>%s</b> на <code>%s</code>\n%s %s",
+		"dispatch.view_run":               "Открыть запуск",
+		"list.no_repo":                    "К этому чату не привязан репозиторий. Используйте <code>%s owner/repo [фильтры]</code>.",
+		"list.failed":                     "❌ Поиск не удался: %s",
+		"list.header.pr":                  "🔀 <b>Pull requests</b>: найдено %d (страница %d)",
+		"list.header.issue":               "🐛 <b>Issues</b>: найдено %d (страница %d)",
+		"list.empty":                      "Ничего не найдено.",
+		"list.draft":                      "📝 Черновик",
+		"list.labels":                     "🏷 %s",
+		"list.assignees":                  "👤 %s",
+		"list.comments":                   "💬 Комментариев: %d",
+		"list.approve":                    "✅ Одобрить",
+		"list.close":                      "🔒 Закрыть",
+		"list.label":                      "🏷 Метка",
+		"list.back":                       "⬅️ Назад",
+		"list.open":                       "Открыть на GitHub",
+		"comment.delete_usage":            "Ответьте /delete на своё сообщение, опубликованное как комментарий на GitHub.",
+		"comment.not_posted":              "Это сообщение не публиковалось на GitHub или слишком старое.",
+		"comment.not_yours":               "Удалять можно только свои комментарии.",
+		"comment.delete_failed":           "❌ Не удалось удалить комментарий: %s",
+		"comment.deleted":                 "🗑 Комментарий удалён на GitHub.",
+		"comment.posted":                  "✅ <a href=\"%s\">Комментарий опубликован</a>",
+		"comment.connect_hint":            "💡 Ответы на уведомления публикуются как комментарии на GitHub, когда вы подключите аккаунт. Отправьте мне /connect в личном чате.",
+		"comment.err.no_permission":       "❌ У вашего аккаунта GitHub нет прав комментировать здесь.",
+		"comment.err.locked":              "🔒 Обсуждение заблокировано на GitHub, комментарий не сохранён.",
+		"comment.err.archived":            "📦 Репозиторий архивирован и доступен только для чтения, комментарий не сохранён.",
+		"comment.err.rate_limited":        "⏳ Достигнут лимит запросов GitHub. Попробуйте через несколько минут.",
+		"comment.err.failed":              "❌ Не удалось сохранить комментарий: %s",
+		"answer.not_comment":              "Ответьте на уведомление о комментарии в обсуждении, чтобы отметить его как ответ.",
+		"answer.failed":                   "❌ Не удалось отметить ответ: %s",
+		"answer.done":                     "✅ Отмечено как ответ.",
+		"commit.pick":                     "К какому коммиту добавить комментарий?",
+		"commit.cancel":                   "✖ Отмена",
+		"commit.expired":                  "Выбор устарел. Ответьте на уведомление ещё раз.",
+		"commit.not_yours":                "Выбрать коммит может только автор ответа.",
+		"state.failed":                    "Не удалось изменить состояние: %v",
+		"state.closed":                    "✅ Issue/PR #%d закрыт.",
+		"state.reopened":                  "✅ Issue/PR #%d переоткрыт.",
+		"pr.action_expired":               "Действие устарело. Откройте PR по ссылке.",
+		"pr.not_linked":                   "Этот чат не подключён к репозиторию.",
+		"pr.approved":                     "Одобрено!",
+		"pr.closed":                       "Закрыто!",
+
+		"settings.none_linked":   "Нет подключённых репозиториев. Сначала отправьте /addrepo.",
+		"settings.select_repo":   "Выберите репозиторий для настройки:",
+		"settings.notifications": "🔔 Уведомления",
+		"settings.language":      "🌐 Язык: %s",
+		"settings.config_for":    "Настройки <b>%s</b>:",
+		"settings.preset_push":   "Только push",
+		"settings.preset_all":    "Присылать всё",
+		"settings.individual":    "Выбрать события вручную",
+		"settings.back_list":     "🔙 К списку репозиториев",
+		"settings.back":          "🔙 Назад",
+		"settings.fetch_failed":  "Не удалось получить настройки GitHub.",
+		"settings.update_failed": "Не удалось обновить GitHub.",
+		"settings.hook_fetch":    "Не удалось получить вебхук GitHub.",
+		"settings.hook_update":   "Не удалось обновить вебхук GitHub.",
+		"settings.all_done":      "✅ <b>Готово!</b> Репозиторий будет присылать <b>все события</b>.",
+		"settings.push_done":     "✅ <b>Готово!</b> Репозиторий будет присылать <b>только push</b>.",
+		"events.must_connect":    "Ошибка: чтобы просматривать и менять настройки, подключите GitHub.",
+		"events.auth":            "Ошибка авторизации. Подключитесь заново.",
+		"events.fetch_failed":    "Не удалось получить настройки вебхука из GitHub. Проверьте права.",
+		"events.edit_on_github":  "🌐 Другие настройки на GitHub",
+		"events.title":           "События для <b>%s</b>:",
+		"lang.pick":              "Выберите язык ответов и уведомлений бота в этом чате:",
+		"lang.auto":              "🔄 Автоматически (язык Telegram каждого пользователя)",
+		"lang.auto_short":        "Автоматически",
+
+		"notify.on":  "Вкл",
+		"notify.off": "Выкл",
+		"notify.menu": "<b>Уведомления</b>\n\n" +
+			"<b>Тихие часы:</b> %s\n" +
+			"<b>Время:</b> %02d:00 – %02d:00 (%s)\n" +
+			"<b>В тихие часы:</b> %s\n\n" +
+			"События со звуком всегда звучат, даже в тихие часы.\n" +
+			"Группировка CI публикует одно сообщение на коммит и обновляет его по мере завершения workflow и job.\n" +
+			"Объединённые push редактируют предыдущее сообщение для той же ветки вместо отправки нового.",
+		"notify.mode_silent":   "🔕 Отправлять без звука",
+		"notify.mode_hold":     "⏰ Отложить до окончания",
+		"notify.quiet":         "🌙 Тихие часы: %s",
+		"notify.start":         "Начало %02d:00",
+		"notify.end":           "Конец %02d:00",
+		"notify.timezone":      "🌍 Часовой пояс: %s",
+		"notify.sounds":        "🔊 Звук по событиям",
+		"notify.ci":            "🧪 Группировать CI по коммиту: %s",
+		"notify.ci_final":      "⏭ Только завершённый CI: %s",
+		"ci.title":             "🧪 *CI для* [`%s`](%s)",
+		"ci.branch":            " *в* `%s`",
+		"ci.more":              "_\\.\\.\\. и ещё %d_",
+		"ci.repository":        "*Репозиторий:* %s",
+		"ci.commit":            "*Коммит:* %s",
+		"ci.overall":           "*Итог:* %s",
+		"ci.view_checks":       "Открыть проверки",
+		"ci.running":           "⏳ Выполняется",
+		"ci.failed":            "❌ Ошибка",
+		"ci.passed":            "✅ Успешно",
+		"notify.push_window":   "🔨 Объединять push за: %s",
+		"notify.reply_confirm": "💬 Подтверждать опубликованные ответы: %s",
+		"notify.tz_pick":       "Выберите часовой пояс для тихих часов:",
+		"notify.sounds_text": "<b>Звук по событиям</b>\n\n" +
+			"▫️ как в тихие часы\n" +
+			"🔕 всегда без звука\n" +
+			"🔊 всегда со звуком\n\n" +
+			"Нажмите на событие, чтобы сменить режим.",
+	},
+	Labels: map[string]string{
+		"Member":                      "Участник",
+		"Role":                        "Роль",
+		"Repository":                  "Репозиторий",
+		"By":                          "Автор",
+		"Title":                       "Заголовок",
+		"Description":                 "Описание",
+		"Status":                      "Статус",
+		"State":                       "Состояние",
+		"Name":                        "Название",
+		"Comment":                     "Комментарий",
+		"Organization":                "Организация",
+		"Branch":                      "Ветка",
+		"Labels":                      "Метки",
+		"Milestone":                   "Веха",
+		"Reviewers":                   "Ревьюеры",
+		"Assigned":                    "Назначено",
+		"Assigned to":                 "Назначено",
+		"Closed by":                   "Закрыл",
+		"Triggered by":                "Запустил",
+		"Started":                     "Начало",
+		"Completed":                   "Завершено",
+		"Result":                      "Результат",
+		"Severity":                    "Критичность",
+		"Environment":                 "Окружение",
+		"Commit":                      "Коммит",
+		"Attempt":                     "Попытка",
+		"Account":                     "Аккаунт",
+		"Action":                      "Действие",
+		"Billing":                     "Оплата",
+		"Blocked":                     "Заблокирован",
+		"CVE":                         "CVE",
+		"Changes":                     "Изменения",
+		"Color":                       "Цвет",
+		"Default branch":              "Основная ветка",
+		"Discussion":                  "Обсуждение",
+		"Draft Issue":                 "Черновик задачи",
+		"Edited by":                   "Изменил",
+		"Error":                       "Ошибка",
+		"Forks":                       "Форки",
+		"From Status":                 "Прежний статус",
+		"Hook ID":                     "ID вебхука",
+		"ID":                          "ID",
+		"Inputs":                      "Параметры",
+		"Install ID":                  "ID установки",
+		"Issue":                       "Задача",
+		"New Values":                  "Новые значения",
+		"Next Bill":                   "Следующий платёж",
+		"Notes":                       "Заметки",
+		"Org":                         "Организация",
+		"PR":                          "PR",
+		"Package":                     "Пакет",
+		"Page Changes":                "Изменённые страницы",
+		"Payload":                     "Данные",
+		"Plan":                        "Тариф",
+		"Previous Desc":               "Прежнее описание",
+		"Previous Name":               "Прежнее имя",
+		"Project":                     "Проект",
+		"Property Name":               "Свойство",
+		"Pull Request":                "Pull request",
+		"Reference":                   "Ссылка",
+		"Reported by":                 "Сообщил",
+		"Repositories Added":          "Добавлены репозитории",
+		"Repositories Removed":        "Удалены репозитории",
+		"Rule Name":                   "Правило",
+		"Ruleset":                     "Набор правил",
+		"Runner":                      "Раннер",
+		"Scope":                       "Область",
+		"Secret Type":                 "Тип секрета",
+		"Sponsor":                     "Спонсор",
+		"Stars":                       "Звёзды",
+		"Summary":                     "Сводка",
+		"Tag":                         "Тег",
+		"Target":                      "Цель",
+		"Team":                        "Команда",
+		"Tier":                        "Уровень",
+		"Unexpected watch action":     "Неожиданное действие подписки",
+		"Units":                       "Количество",
+		"Unknown installation action": "Неизвестное действие установки",
+		"User":                        "Пользователь",
+	},
+	Buttons: map[string]string{
+		"⚠️ Open public issue":       "⚠️ Открыть публичную задачу",
+		"🙈 Dismiss":                  "🙈 Отклонить",
+		"📝 Track issue":              "📝 Задача",
+		"↩️ Reopen":                  "↩️ Переоткрыть",
+		"🔑 Revoked":                  "🔑 Отозван",
+		"🙅 False positive":           "🙅 Ложное срабатывание",
+		"Tolerable risk":             "Допустимый риск",
+		"False positive":             "Ложное срабатывание",
+		"No bandwidth":               "Нет времени",
+		"Fix started":                "Исправление начато",
+		"« Back":                     "« Назад",
+		"✅ Approve deploy":           "✅ Одобрить деплой",
+		"❌ Reject":                   "❌ Отклонить",
+		"View Repository":            "Открыть репозиторий",
+		"View Issue":                 "Открыть issue",
+		"View PR":                    "Открыть PR",
+		"🔀 Merge":                    "🔀 Слить",
+		"View Comment":               "Открыть комментарий",
+		"View Commits":               "Открыть коммиты",
+		"View Commit":                "Открыть коммит",
+		"View Release":               "Открыть релиз",
+		"View Review":                "Открыть ревью",
+		"View Discussion":            "Открыть обсуждение",
+		"View Workflow Run":          "Открыть запуск",
+		"View Run":                   "Открыть запуск",
+		"View Job":                   "Открыть job",
+		"🔁 Re-run failed":            "🔁 Перезапустить упавшие",
+		"🔁 Re-run all":               "🔁 Перезапустить все",
+		"⛔ Cancel":                   "⛔ Отменить",
+		"View Alert":                 "Открыть оповещение",
+		"View Deployment":            "Открыть деплой",
+		"View Fork":                  "Открыть форк",
+		"View Milestone":             "Открыть веху",
+		"View Advisory":              "Открыть бюллетень",
+		"View Branch Settings":       "Настройки веток",
+		"View Details":               "Подробнее",
+		"View Installation":          "Открыть установку",
+		"View Item":                  "Открыть элемент",
+		"View Organization":          "Открыть организацию",
+		"View Organization Settings": "Настройки организации",
+		"View Package":               "Открыть пакет",
+		"View Project":               "Открыть проект",
+		"View Repository Settings":   "Настройки репозитория",
+		"View Ruleset":               "Открыть правила",
+		"View Security Settings":     "Настройки безопасности",
+		"View Sponsorship":           "Открыть спонсорство",
+		"View Team":                  "Открыть команду",
+		"View Thread":                "Открыть ветку",
+		"View User":                  "Открыть профиль",
+	},
+	Headlines: map[string]string{
+		"*📌 %a issue \\#%d*":                            "*📌 %a: задача \\#%d*",
+		"*🚀 PR %a \\#%d: %s*":                           "*🚀 %a PR \\#%d: %s*",
+		"🔨 *%d new commit to* `%s`":                     "🔨 *Коммитов: %d →* `%s`",
+		"🔨 *%d new commits to* `%s`":                    "🔨 *Коммитов: %d →* `%s`",
+		"🔨 *%d new commit to* `%s` _\\(%d pushes\\)_":   "🔨 *Коммитов: %d →* `%s` _\\(пушей: %d\\)_",
+		"🔨 *%d new commits to* `%s` _\\(%d pushes\\)_":  "🔨 *Коммитов: %d →* `%s` _\\(пушей: %d\\)_",
+		"⚠️ *Force push to* `%s`":                       "⚠️ *Принудительный push в* `%s`",
+		"⚠️ *Force push to* `%s` _\\(%d pushes\\)_":     "⚠️ *Принудительный push в* `%s` _\\(пушей: %d\\)_",
+		"✨ *New %a created*":                            "✨ *Создано: %a*",
+		"%s *Deleted %a:* `%s`":                         "%s *Удалено: %a* `%s`",
+		"🍴 %s forked by %s":                             "🍴 %s: форк от %s",
+		"%s *%s %a comment on commit*":                  "%s *%s: комментарий к коммиту — %a*",
+		"🔓 *Repository made public*":                    "🔓 *Репозиторий стал публичным*",
+		"%s *%s %a comment on* [%s\\#%d](%s)":           "%s *%s: комментарий — %a,* [%s\\#%d](%s)",
+		"%s *%s* added to *%s*":                         "%s *%s* добавлен в *%s*",
+		"%s *%s* removed from *%s*":                     "%s *%s* удалён из *%s*",
+		"%s *%s* updated in *%s*":                       "%s *%s* изменён в *%s*",
+		"%s *%s* performed action on *%s*":              "%s *%s* выполнил действие в *%s*",
+		"%s %s created":                                 "%s %s создан",
+		"%s %s archived":                                "%s %s архивирован",
+		"%s %s unarchived":                              "%s %s разархивирован",
+		"%s %s renamed to %s":                           "%s %s переименован в %s",
+		"%s *%a in* %s":                                 "%s *%a в* %s",
+		"⭐ %s starred %s":                               "⭐ %s добавил в избранное %s",
+		"%s %s starred %s":                              "%s %s добавил в избранное %s",
+		"%s %s unstarred %s":                            "%s %s убрал из избранного %s",
+		"%s *%a for commit* [`%s`](%s)":                 "%s *%a для коммита* [`%s`](%s)",
+		"%s *%s workflow*":                              "%s *Workflow %s*",
+		"⚙️ *No workflow job data*":                     "⚙️ *Нет данных о задании*",
+		"⚙️ *Invalid workflow job*":                     "⚙️ *Некорректное задание*",
+		"%s *Workflow Job %a*":                          "%s *Задание: %a*",
+		"🚀 *%s manually triggered*":                     "🚀 *%s запущен вручную*",
+		"👥 *Team added*":                                "👥 *Команда добавлена*",
+		"%s *Team %a*":                                  "%s *Команда: %a*",
+		"🚀 *Repository Dispatch*":                       "🚀 *Событие репозитория*",
+		"%s *PR Review Comment %a*":                     "%s *Комментарий ревью: %a*",
+		"%s *PR Review %a*":                             "%s *Ревью PR: %a*",
+		"🏓 *Webhook Ping Received*":                     "🏓 *Получен ping вебхука*",
+		"💖 *Sponsorship %a*":                            "💖 *Спонсорство: %a*",
+		"👤 *User %a*":                                   "👤 *Пользователь: %a*",
+		"📥 *Repository Import %a*":                      "📥 *Импорт репозитория: %a*",
+		"📜 *Repository Ruleset %a*":                     "📜 *Набор правил: %a*",
+		"🤫 *Secret Scanning Alert %a*":                  "🤫 *Оповещение о секрете: %a*",
+		"📍 *Secret Scanning Alert Location %a*":         "📍 *Место утечки секрета: %a*",
+		"🔒 *Security & Analysis Settings Updated*":      "🔒 *Настройки безопасности и анализа обновлены*",
+		"🧵 *PR Review Thread %a*":                       "🧵 *Ветка ревью: %a*",
+		"🎯 *PR Target %a*":                              "🎯 *PR target: %a*",
+		"📦 *Registry Package %a*":                       "📦 *Пакет реестра: %a*",
+		"🔄 *Merge Group %a*":                            "🔄 *Группа слияния: %a*",
+		"🔑 *Personal Access Token Request %a*":          "🔑 *Запрос токена доступа: %a*",
+		"📋 *Project %a*":                                "📋 *Проект: %a*",
+		"📄 *Project Item %a*":                           "📄 *Элемент проекта: %a*",
+		"🔒 *GitHub App Authorization %a*":               "🔒 *Авторизация GitHub App: %a*",
+		"📦 *Installation Repositories %a*":              "📦 *Репозитории установки: %a*",
+		"🎯 *Installation Target %a*":                    "🎯 *Цель установки: %a*",
+		"💬 *Discussion Comment %a*":                     "💬 *Комментарий в обсуждении: %a*",
+		"📣 *Discussion %a*":                             "📣 *Обсуждение: %a*",
+		"🤖 *Dependabot Alert %a*":                       "🤖 *Оповещение Dependabot: %a*",
+		"🛡️ *Deployment Protection Rule %a*":            "🛡️ *Правило защиты развёртывания: %a*",
+		"🔎 *Deployment Review %a*":                      "🔎 *Проверка развёртывания: %a*",
+		"🔗 *Content Reference %a*":                      "🔗 *Ссылка на контент: %a*",
+		"📝 *Custom Property %a*":                        "📝 *Пользовательское свойство: %a*",
+		"🔄 *Custom Property Values Updated*":            "🔄 *Значения свойств обновлены*",
+		"🛡️ *Branch Protection Rule %a*":                "🛡️ *Правило защиты ветки: %a*",
+		"🛡️ *Branch Protection Configuration %a*":       "🛡️ *Настройка защиты веток: %a*",
+		"🚨 *Vulnerability Alert: %s*":                   "🚨 *Уязвимость: %s*",
+		"🏗️ *GitHub Pages Build*":                       "🏗️ *Сборка GitHub Pages*",
+		"📦 *Package Event*":                             "📦 *Событие пакета*",
+		"🚫 *Organization Block*":                        "🚫 *Блокировка в организации*",
+		"🏢 *Organization %a*":                           "🏢 *Организация: %a*",
+		"🏁 *Milestone %a*":                              "🏁 *Веха: %a*",
+		"⚙️ *Meta Event*":                               "⚙️ *Метасобытие*",
+		"🚫 *No membership event data*":                  "🚫 *Нет данных о членстве*",
+		"👥 *Membership %a*":                             "👥 *Членство: %a*",
+		"🚀 *Deployment Event*":                          "🚀 *Событие развёртывания*",
+		"🏷️ *No label event data*":                      "🏷️ *Нет данных о метке*",
+		"🏷️ *Label %a*":                                 "🏷️ *Метка: %a*",
+		"🛒 *No marketplace data*":                       "🛒 *Нет данных Marketplace*",
+		"🛒 *Marketplace %a*":                            "🛒 *Marketplace: %a*",
+		"📚 *No wiki update data available*":             "📚 *Нет данных об изменении вики*",
+		"📚 *Wiki Update*":                               "📚 *Изменение вики*",
+		"🔑 *No deploy key data*":                        "🔑 *Нет данных о ключе развёртывания*",
+		"🔑 *Deploy Key %a*":                             "🔑 *Ключ развёртывания: %a*",
+		"✅ *No check suite data*":                       "✅ *Нет данных о наборе проверок*",
+		"✅ *Check Suite: %a*":                           "✅ *Набор проверок: %a*",
+		"⚙️ *No check run data*":                        "⚙️ *Нет данных о проверке*",
+		"⚙️ *Check Run: %a*":                            "⚙️ *Проверка: %a*",
+		"🚦 *No deployment status data*":                 "🚦 *Нет данных о статусе развёртывания*",
+		"🚦 *Deployment %a*":                             "🚦 *Развёртывание: %a*",
+		"⚠️ *No security advisory data*":                "⚠️ *Нет данных о бюллетене безопасности*",
+		"⚠️ *Security Advisory %a*":                     "⚠️ *Бюллетень безопасности: %a*",
+		"🎉 *New installation*\\! Welcome aboard\\! 🎉":   "🎉 *Новая установка*\\! Добро пожаловать\\! 🎉",
+		"🗑️ *Installation uninstalled*\\! Goodbye\\! 👋": "🗑️ *Установка удалена*\\! До свидания\\! 👋",
+	},
+	Words: map[string]string{
+		"opened":                 "открыто",
+		"closed":                 "закрыто",
+		"reopened":               "переоткрыто",
+		"edited":                 "изменено",
+		"deleted":                "удалено",
+		"created":                "создано",
+		"added":                  "добавлено",
+		"removed":                "удалено",
+		"assigned":               "назначено",
+		"unassigned":             "снято назначение",
+		"labeled":                "добавлена метка",
+		"unlabeled":              "снята метка",
+		"synchronize":            "обновлено",
+		"ready_for_review":       "готово к ревью",
+		"review_requested":       "запрошено ревью",
+		"review_request_removed": "запрос ревью снят",
+		"converted_to_draft":     "переведено в черновик",
+		"submitted":              "отправлено",
+		"dismissed":              "отклонено",
+		"published":              "опубликовано",
+		"transferred":            "перенесено",
+		"pinned":                 "закреплено",
+		"unpinned":               "откреплено",
+		"locked":                 "заблокировано",
+		"unlocked":               "разблокировано",
+		"milestoned":             "добавлено в веху",
+		"demilestoned":           "убрано из вехи",
+		"answered":               "получен ответ",
+		"unanswered":             "ответ снят",
+		"resolved":               "решено",
+		"unresolved":             "не решено",
+		"completed":              "завершено",
+		"requested":              "запрошено",
+		"rerequested":            "перезапрошено",
+		"in_progress":            "выполняется",
+		"queued":                 "в очереди",
+		"waiting":                "ожидает",
+		"running":                "выполняется",
+		"success":                "успешно",
+		"failure":                "сбой",
+		"failed":                 "сбой",
+		"error":                  "ошибка",
+		"pending":                "ожидает",
+		"cancelled":              "отменено",
+		"neutral":                "нейтрально",
+		"approved":               "одобрено",
+		"rejected":               "отклонено",
+		"fixed":                  "исправлено",
+		"auto_dismissed":         "отклонено автоматически",
+		"auto_reopened":          "переоткрыто автоматически",
+		"renamed":                "переименовано",
+		"archived":               "архивировано",
+		"unarchived":             "разархивировано",
+		"modified":               "изменено",
+		"started":                "начато",
+		"member_added":           "участник добавлен",
+		"member_removed":         "участник удалён",
+		"member_invited":         "участник приглашён",
+		"branch":                 "ветка",
+		"tag":                    "тег",
+		"checks_requested":       "запрошены проверки",
+		"destroyed":              "удалено",
+		"new_release":            "новый релиз",
+		"release_published":      "релиз опубликован",
+		"release_deleted":        "релиз удалён",
+		"release_edited":         "релиз изменён",
+		"reported":               "сообщено",
+		"denied":                 "отклонено",
+		"suspend":                "приостановлено",
+		"unsuspend":              "возобновлено",
+		"promoted_to_repository": "перенесено в репозиторий",
+	},
+}
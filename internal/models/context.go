@@ -8,10 +8,40 @@ type MessageContext struct {
 	Repo        string
 	IssueNumber int
 	CommentID   int64
-	Type        string
+	RunID       int64 // Workflow run of CI notifications
+	RunAttempt  int
+	// GraphQL node IDs of discussion notifications; discussions have no IssueNumber
+	DiscussionID  string
+	CommentNodeID string
+	CommitSHA     string      // Commit of single-commit pushes and commit comments
+	Commits       []CommitRef // Commits of multi-commit pushes, one is picked when replying
+	Type          string
 }
 
+// CommitRef is a pushed commit that replies can comment on
+type CommitRef struct {
+	SHA   string
+	Title string // First line of the commit message
+}
+
+// PostedComment links a Telegram reply to the GitHub comment it created, so edits and deletions can follow it.
+// It is stored by the chat and message ID of the reply.
+type PostedComment struct {
+	ChatID    int64     `bson:"chat_id"`
+	MessageID int64     `bson:"message_id"`
+	Owner     string    `bson:"owner"`
+	Repo      string    `bson:"repo"`
+	CommentID int64     `bson:"comment_id,omitempty"`
+	Review    bool      `bson:"review,omitempty"`  // Pull request review comment rather than an issue comment
+	UserID    int64     `bson:"user_id"`           // Telegram user who wrote the reply
+	NodeID    string    `bson:"node_id,omitempty"` // Discussion comment, which is only reachable through GraphQL
+	Commit    bool      `bson:"commit,omitempty"`  // Commit comment
+	CreatedAt time.Time `bson:"created_at"`        // Expires the record through a TTL index
+}
+
+// OAuthState is a pending /connect login, keyed by its OAuth state parameter
 type OAuthState struct {
 	TelegramID int64
 	CreatedAt  time.Time
+	Language   string // Language of the chat the login started in, used for the confirmation
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -11,20 +13,87 @@ type User struct {
 	GitHubUsername      string   `bson:"github_username" json:"github_username"`
 	EncryptedOAuthToken string   `bson:"encrypted_oauth_token" json:"-"`
 	Scopes              []string `bson:"scopes" json:"scopes"`
+	// NoMentions stops notifications from mentioning the user on Telegram
+	NoMentions bool `bson:"no_mentions" json:"no_mentions"`
+	// DMOff lists the personal notification reasons (review, assign, mention) the user does not want in private chat
+	DMOff []string `bson:"dm_off,omitempty" json:"dm_off,omitempty"`
+	// TelegramUsername is the user's last seen Telegram @username, used to resolve mentions in replies
+	TelegramUsername string `bson:"telegram_username,omitempty" json:"telegram_username,omitempty"`
 }
 
 // RepoLink represents a link to a GitHub repository within a chat
 type RepoLink struct {
 	RepoFullName string `bson:"repo_full_name" json:"repo_full_name"`
 	WebhookID    int64  `bson:"webhook_id,omitempty" json:"webhook_id,omitempty"`
+	// Org marks an organization webhook, linked as "org/*", which covers every repository of the org
+	Org bool `bson:"org,omitempty" json:"org,omitempty"`
+	// Include and Exclude are repository name patterns that filter what an organization webhook delivers
+	Include []string `bson:"include,omitempty" json:"include,omitempty"`
+	Exclude []string `bson:"exclude,omitempty" json:"exclude,omitempty"`
+	// CreatedBy is the Telegram user who linked the repository and CreatedByName their name at the time;
+	// links made before this was recorded have neither
+	CreatedBy     int64     `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	CreatedByName string    `bson:"created_by_name,omitempty" json:"created_by_name,omitempty"`
+	CreatedAt     time.Time `bson:"created_at,omitempty" json:"created_at,omitzero"`
 }
 
 // Chat represents a Telegram chat (group, channel, or private)
 type Chat struct {
-	ID       int64      `bson:"_id" json:"chat_id"`
-	ChatType string     `bson:"chat_type" json:"chat_type"`
-	Title    string     `bson:"title" json:"title"`
-	Links    []RepoLink `bson:"links" json:"links"`
+	ID       int64        `bson:"_id" json:"chat_id"`
+	ChatType string       `bson:"chat_type" json:"chat_type"`
+	Title    string       `bson:"title" json:"title"`
+	Links    []RepoLink   `bson:"links" json:"links"`
+	Settings ChatSettings `bson:"settings" json:"settings"`
+}
+
+// Notification sound modes for an event type
+const (
+	SoundDefault = ""
+	SoundSilent  = "silent"
+	SoundLoud    = "loud"
+)
+
+// ChatSettings holds chat-wide notification preferences
+type ChatSettings struct {
+	QuietHours QuietHours        `bson:"quiet_hours" json:"quiet_hours"`
+	EventSound map[string]string `bson:"event_sound,omitempty" json:"event_sound,omitempty"`
+
+	// CIAggregate merges workflow, job, check and status events into one live message per commit
+	CIAggregate bool `bson:"ci_aggregate" json:"ci_aggregate"`
+	// CIFinalOnly holds the aggregated CI message back until every check has finished
+	CIFinalOnly bool `bson:"ci_final_only" json:"ci_final_only"`
+	// PushWindow is the number of seconds during which pushes to the same branch are merged into one message, 0 disables it
+	PushWindow int `bson:"push_window" json:"push_window"`
+	// Language is the chat's language code; empty follows each user's Telegram language
+	Language string `bson:"language,omitempty" json:"language,omitempty"`
+	// Templates are custom notification templates keyed by "event" or "event:action"
+	Templates map[string]string `bson:"templates,omitempty" json:"templates,omitempty"`
+	// NoReplyConfirm stops the bot from confirming comments posted by replying to notifications
+	NoReplyConfirm bool `bson:"no_reply_confirm" json:"no_reply_confirm"`
+}
+
+// QuietHours describes a daily window during which notifications are muted.
+// Start and End are hours of the day (0-23) in Timezone; the window may wrap past midnight.
+type QuietHours struct {
+	Enabled  bool   `bson:"enabled" json:"enabled"`
+	Start    int    `bson:"start" json:"start"`
+	End      int    `bson:"end" json:"end"`
+	Timezone string `bson:"timezone" json:"timezone"`
+	Defer    bool   `bson:"defer" json:"defer"`
+}
+
+// DeferredMessage is a notification held back until a chat's quiet hours end.
+// The event is kept as its webhook payload so replies to the message still reach GitHub once it is sent.
+type DeferredMessage struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	ChatID    int64         `bson:"chat_id"`
+	TopicID   int64         `bson:"topic_id,omitempty"`
+	Until     time.Time     `bson:"until"`
+	Text      string        `bson:"text"`
+	Fallback  string        `bson:"fallback,omitempty"` // Default rendering, sent if a custom template fails
+	Markup    string        `bson:"markup,omitempty"`   // JSON of the inline keyboard
+	EventType string        `bson:"event_type"`
+	Payload   string        `bson:"payload"`
 }
 
 // Repository represents a GitHub repository where the App is installed
@@ -42,3 +111,24 @@ type PRActionContext struct {
 	Repo     string
 	PRNumber int
 }
+
+// RunActionContext stores the workflow run behind the re-run and cancel buttons of CI notifications,
+// and the deployment review buttons
+type RunActionContext struct {
+	Owner   string
+	Repo    string
+	RunID   int64
+	Attempt int
+}
+
+// AlertActionContext stores the security alert behind the triage buttons of alert notifications
+type AlertActionContext struct {
+	Kind   string // dependabot, secret_scanning or vulnerability
+	Owner  string
+	Repo   string
+	Number int // Alert number, 0 for legacy vulnerability alerts, which can only be tracked
+	Open   bool
+	Title  string // Summary used as the tracking issue's title
+	URL    string
+	Issue  int // Tracking issue opened from the notification
+}